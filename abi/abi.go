@@ -22,6 +22,7 @@ import (
 	"github.com/tchain/go-tchain-sdk/crypto/config"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"io"
+	"math/big"
 
 	"github.com/tchain/go-tchain-sdk/crypto"
 )
@@ -33,6 +34,7 @@ type ABI struct {
 	Constructor Method
 	Methods     map[string]Method
 	Events      map[string]Event
+	Errors      map[string]Error
 
 	// Additional "special" functions introduced in solidity v0.6.0.
 	// It's separated from the original default fallback. Each contract
@@ -151,6 +153,7 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 	}
 	abi.Methods = make(map[string]Method)
 	abi.Events = make(map[string]Event)
+	abi.Errors = make(map[string]Error)
 	for _, field := range fields {
 		switch field.Type {
 		case "constructor":
@@ -178,6 +181,10 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 		case "event":
 			name := abi.overloadedEventName(field.Name)
 			abi.Events[name] = NewEvent(name, field.Name, field.Anonymous, field.Inputs)
+		case "error":
+			// Errors cannot be overloaded or overridden but are inherited,
+			// no need to resolve the name conflict here.
+			abi.Errors[field.Name] = NewError(field.Name, field.Inputs)
 		default:
 			return fmt.Errorf("abi: could not recognize type %v of field %v", field.Type, field.Name)
 		}
@@ -240,6 +247,17 @@ func (abi *ABI) EventByID(topic utils.Hash) (*Event, error) {
 	return nil, fmt.Errorf("no event with id: %#x", topic.Hex())
 }
 
+// ErrorByID looks up an error by the 4-byte id, the selector of the revert
+// data. It returns nil if none found.
+func (abi *ABI) ErrorByID(sigdata [4]byte) (*Error, error) {
+	for _, errABI := range abi.Errors {
+		if bytes.Equal(errABI.ID[:4], sigdata[:]) {
+			return &errABI, nil
+		}
+	}
+	return nil, fmt.Errorf("no error with id: %#x", sigdata[:])
+}
+
 // HasFallback returns an indicator whether a fallback function is included.
 func (abi *ABI) HasFallback() bool {
 	return abi.Fallback.Type == Fallback
@@ -271,3 +289,25 @@ func UnpackRevert(data []byte) (string, error) {
 	}
 	return reason, nil
 }
+
+// panicSelector is the function selector of the panics of solidity v0.8.0,
+// e.g. a failed assert or an arithmetic overflow.
+var panicSelector = crypto.Keccak256(config.SECP256K1, []byte("Panic(uint256)"))[:4]
+
+// UnpackPanic resolves the code of a panic, the revert data of a failed
+// assert, an arithmetic overflow, a division by zero or an out of bounds
+// access is abi-encoded as if it were a call to a function `Panic(uint256)`.
+func UnpackPanic(data []byte) (*big.Int, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	if !bytes.Equal(data[:4], panicSelector) {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	var code *big.Int
+	typ, _ := NewType("uint256", "", nil)
+	if err := (Arguments{{Type: typ}}).Unpack(&code, data[4:]); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
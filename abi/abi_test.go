@@ -1144,3 +1144,87 @@ func TestUnpackRevert(t *testing.T) {
 		})
 	}
 }
+
+func TestUnpackPanic(t *testing.T) {
+	t.Parallel()
+
+	var cases = []struct {
+		input     string
+		expect    int64
+		expectErr error
+	}{
+		{"", 0, errors.New("invalid data for unpacking")},
+		{"08c379a00000000000000000000000000000000000000000000000000000000000000011", 0, errors.New("invalid data for unpacking")},
+		{"4e487b710000000000000000000000000000000000000000000000000000000000000011", 0x11, nil},
+	}
+	for index, c := range cases {
+		t.Run(fmt.Sprintf("case %d", index), func(t *testing.T) {
+			got, err := UnpackPanic(utils.Hex2Bytes(c.input))
+			if c.expectErr != nil {
+				if err == nil || err.Error() != c.expectErr.Error() {
+					t.Fatalf("Expected error mismatch, want %v, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Int64() != c.expect {
+				t.Fatalf("Output mismatch, want %v, got %v", c.expect, got)
+			}
+		})
+	}
+}
+
+func TestCustomError(t *testing.T) {
+	t.Parallel()
+
+	abi, err := JSON(strings.NewReader(`[
+		{"type": "error", "name": "InsufficientBalance", "inputs": [{"name": "available", "type": "uint256"}, {"name": "required", "type": "uint256"}]},
+		{"type": "error", "name": "Unauthorized", "inputs": []}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(abi.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(abi.Errors))
+	}
+
+	insufficient := abi.Errors["InsufficientBalance"]
+	if insufficient.Sig != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("unexpected signature %s", insufficient.Sig)
+	}
+	if insufficient.String() != "error InsufficientBalance(uint256 available, uint256 required)" {
+		t.Errorf("unexpected string %s", insufficient.String())
+	}
+	if !bytes.Equal(insufficient.ID[:4], crypto.Keccak256(config.SECP256K1, []byte(insufficient.Sig))[:4]) {
+		t.Errorf("unexpected id %x", insufficient.ID)
+	}
+
+	data := append(insufficient.ID[:4:4], math.U256Bytes(big.NewInt(5))...)
+	data = append(data, math.U256Bytes(big.NewInt(10))...)
+
+	var selector [4]byte
+	copy(selector[:], data)
+	found, err := abi.ErrorByID(selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name != "InsufficientBalance" {
+		t.Fatalf("found error %s", found.Name)
+	}
+	values, err := found.Unpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0].(*big.Int).Int64() != 5 || values[1].(*big.Int).Int64() != 10 {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	if _, err := abi.Errors["Unauthorized"].Unpack(data); err == nil {
+		t.Error("expected an error for the data of another error")
+	}
+	if _, err := abi.ErrorByID([4]byte{1, 2, 3, 4}); err == nil {
+		t.Error("expected an error for an unknown selector")
+	}
+}
@@ -0,0 +1,342 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package bind
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/tchain/go-tchain-sdk/abi"
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils"
+)
+
+// ErrNoCode is returned by a call which gets no output while the method has
+// outputs, usually because there's no contract at the address
+var ErrNoCode = core.ErrNoCode
+
+// CallOpts are the options of a call of a constant method
+type CallOpts struct {
+	Context context.Context // the context of the call, context.Background() when nil
+	Sender  string          // the sender of the call, in did:bid form, optional
+	Block   string          // block.NUMBER(n) or a tag, block.LATEST when empty
+}
+
+// TransactOpts are the options of a transaction, the fields left empty are
+// filled by Builder, without Builder the transaction is signed as it is
+type TransactOpts struct {
+	Context    context.Context // the context of the transaction, context.Background() when nil
+	PrivateKey string          // the private key signing the transaction
+	IsSM2      bool            // whether PrivateKey is a SM2 key
+
+	ChainId  uint64
+	Nonce    *big.Int
+	GasPrice *big.Int
+	GasLimit uint64
+	Amount   *big.Int
+
+	Builder *core.TxBuilder // fills the ChainId, Nonce, GasPrice and GasLimit left empty
+}
+
+// FilterOpts are the options of a query of past events
+type FilterOpts struct {
+	Context context.Context // the context of the query, context.Background() when nil
+	Start   uint64          // the first block of the query
+	End     *uint64         // the last block of the query, nil for the latest block
+}
+
+// WatchOpts are the options of a subscription to new events
+type WatchOpts struct {
+	Context context.Context // the subscription ends when it's done, context.Background() when nil
+}
+
+// BoundContract is the contract at an address used by the generated bindings,
+// it packs the arguments of the methods and unpacks their outputs and events
+type BoundContract struct {
+	address  string
+	abi      abi.ABI
+	core     *core.Core
+	contract *core.Contract
+
+	lock    sync.Mutex
+	chainId uint64
+}
+
+// NewBoundContract binds the contract at address, in did:bid form, described by abiJSON
+func NewBoundContract(address string, abiJSON string, backend *core.Core) (*BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	contract, err := backend.NewContract(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundContract{address: address, abi: parsed, core: backend, contract: contract}, nil
+}
+
+/*
+  DeployContract:
+   	EN - Deploys a contract, the address of the contract is the ContractAddress of the receipt of the returned transaction
+ 	CN - 部署合约，合约地址为返回交易的回执中的ContractAddress
+  Params:
+  	- opts, *TransactOpts, 交易选项
+  	- abiJSON, string, 合约ABI
+  	- bytecode, string, 合约字节码
+  	- backend, *core.Core
+  	- params, ...interface{}, 构造函数参数
+
+  Returns:
+  	- string, 交易哈希
+ 	- error
+
+  Call permissions: Anyone
+*/
+func DeployContract(opts *TransactOpts, abiJSON string, bytecode string, backend *core.Core, params ...interface{}) (string, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return "", err
+	}
+	input, err := parsed.Pack("", params...)
+	if err != nil {
+		return "", err
+	}
+
+	return transact(opts, backend, nil, append(utils.FromHex(bytecode), input...))
+}
+
+// Address returns the address of the contract in did:bid form
+func (c *BoundContract) Address() string {
+	return c.address
+}
+
+// Call calls the constant method with params and unpacks its outputs into
+// result, a pointer to the single output or to a []interface{} of pointers
+// to the outputs, see core.Contract.CallInto
+func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string, params ...interface{}) error {
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	ctx := ensureContext(opts.Context)
+
+	chainId, err := c.getChainId(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.contract.CallIntoCtx(ctx, result, &core.CallOpts{
+		Transaction: &dto.TransactionParameters{ChainId: chainId, Sender: opts.Sender, Recipient: c.address},
+		Block:       opts.Block,
+	}, method, params...)
+}
+
+// Transact signs and sends a transaction calling method with params, it
+// returns the hash of the transaction
+func (c *BoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (string, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return "", err
+	}
+	recipient := utils.StringToAddress(c.address)
+	return transact(opts, c.core, &recipient, input)
+}
+
+// FilterLogs returns the past logs of the event name matching the rules of
+// the indexed arguments, a nil rule matches any value
+func (c *BoundContract) FilterLogs(opts *FilterOpts, name string, query ...[]interface{}) ([]dto.TransactionLogs, error) {
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+	filter, err := c.filterQuery(name, query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.FromBlock = block.NUMBER(new(big.Int).SetUint64(opts.Start))
+	filter.ToBlock = block.LATEST
+	if opts.End != nil {
+		filter.ToBlock = block.NUMBER(new(big.Int).SetUint64(*opts.End))
+	}
+	return c.core.GetLogsCtx(ensureContext(opts.Context), filter)
+}
+
+// WatchLogs subscribes to the new logs of the event name matching the rules
+// of the indexed arguments and calls handle for each of them. The delivery
+// stops when the context of opts is done, the subscription fails or handle
+// fails, the subscription is then unsubscribed.
+func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, handle func(ctx context.Context, log dto.TransactionLogs) error, query ...[]interface{}) (*providers.Subscription, error) {
+	if opts == nil {
+		opts = new(WatchOpts)
+	}
+	ctx := ensureContext(opts.Context)
+
+	filter, err := c.filterQuery(name, query)
+	if err != nil {
+		return nil, err
+	}
+	logs := make(chan *dto.TransactionLogs, 128)
+	sub, err := c.core.SubscribeLogs(ctx, filter, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle is cancelled as well when the subscription fails
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-sub.Done():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// the null notifications are dropped
+				if log == nil {
+					continue
+				}
+				if err := handle(ctx, *log); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// UnpackLog unpacks the log of the event name into out, a pointer to a
+// struct with a field for each argument of the event
+func (c *BoundContract) UnpackLog(out interface{}, name string, log dto.TransactionLogs) error {
+	event, ok := c.abi.Events[name]
+	if !ok {
+		return fmt.Errorf("event %s not found", name)
+	}
+
+	topics := make([]utils.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = utils.HexToHash(topic)
+	}
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return fmt.Errorf("log isn't an event %s", name)
+		}
+		topics = topics[1:]
+	}
+
+	if data := utils.FromHex(log.Data); len(data) > 0 {
+		if err := c.abi.Unpack(out, name, data); err != nil {
+			return err
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	return abi.ParseTopics(out, indexed, topics)
+}
+
+// filterQuery returns the query of the logs of the event name
+func (c *BoundContract) filterQuery(name string, query [][]interface{}) (dto.FilterQuery, error) {
+	event, ok := c.abi.Events[name]
+	if !ok {
+		return dto.FilterQuery{}, fmt.Errorf("event %s not found", name)
+	}
+	if !event.Anonymous {
+		query = append([][]interface{}{{event.ID}}, query...)
+	}
+
+	topics, err := abi.MakeTopics(query...)
+	if err != nil {
+		return dto.FilterQuery{}, err
+	}
+	filter := dto.FilterQuery{Addresses: []string{c.address}, Topics: make([][]string, len(topics))}
+	for i, rule := range topics {
+		for _, topic := range rule {
+			filter.Topics[i] = append(filter.Topics[i], topic.Hex())
+		}
+	}
+	return filter, nil
+}
+
+func (c *BoundContract) getChainId(ctx context.Context) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.chainId == 0 {
+		chainId, err := c.core.GetChainIdCtx(ctx)
+		if err != nil {
+			return 0, err
+		}
+		c.chainId = chainId
+	}
+	return c.chainId, nil
+}
+
+// transact signs and sends the transaction to recipient, nil for a deployment
+func transact(opts *TransactOpts, backend *core.Core, recipient *utils.Address, payload []byte) (string, error) {
+	ctx := ensureContext(opts.Context)
+
+	from, err := account.PriKeyToAccount(opts.PrivateKey, opts.IsSM2, "")
+	if err != nil {
+		return "", err
+	}
+	sender := utils.StringToAddress(from)
+
+	tx := &account.SignTxParams{
+		ChainId:   opts.ChainId,
+		Nonce:     opts.Nonce,
+		GasPrice:  opts.GasPrice,
+		GasLimit:  opts.GasLimit,
+		Sender:    &sender,
+		Recipient: recipient,
+		Amount:    opts.Amount,
+		Payload:   payload,
+	}
+	if tx.Amount == nil {
+		tx.Amount = new(big.Int)
+	}
+
+	if opts.Builder != nil {
+		return opts.Builder.SignAndSend(ctx, tx, opts.PrivateKey, opts.IsSM2)
+	}
+	signed, err := account.SignTransaction(tx, opts.PrivateKey, opts.IsSM2)
+	if err != nil {
+		return "", err
+	}
+	return backend.SendRawTransactionCtx(ctx, signed.Raw.String())
+}
+
+func ensureContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
@@ -0,0 +1,346 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+// Package bind generates typed Go bindings of contracts from their ABI, and
+// holds the BoundContract the generated code is built on.
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/tchain/go-tchain-sdk/abi"
+	"github.com/tchain/go-tchain-sdk/compiler"
+)
+
+// tmplData is the data of the template of a generated file
+type tmplData struct {
+	Package   string
+	Contracts []*tmplContract
+	Structs   []*tmplStruct
+}
+
+// tmplContract is the data of a contract
+type tmplContract struct {
+	Type        string
+	InputABI    string
+	InputBin    string
+	Constructor []tmplArg // the arguments of the constructor
+	Calls       []*tmplMethod
+	Transacts   []*tmplMethod
+	Events      []*tmplEvent
+}
+
+// tmplMethod is a method of a contract
+type tmplMethod struct {
+	Original   abi.Method
+	Normalized string // the name of the Go method
+	Inputs     []tmplArg
+	Outputs    []tmplArg
+}
+
+// tmplEvent is an event of a contract
+type tmplEvent struct {
+	Original   abi.Event
+	Normalized string // the name of the Go event type suffix
+	Fields     []tmplArg
+	Indexed    []tmplArg
+}
+
+// tmplArg is an argument, an output or a field
+type tmplArg struct {
+	Name string
+	Type string
+}
+
+// tmplStruct is a struct of the tuples of the contracts
+type tmplStruct struct {
+	Name   string
+	Fields []tmplArg
+}
+
+/*
+  Bind:
+   	EN - Generates the Go bindings of contracts from their ABI and bytecode
+ 	CN - 根据合约的ABI及字节码生成Go绑定代码
+  Params:
+  	- names, []string, 合约名称，即生成的Go类型名称
+  	- abis, []string, 合约ABI（JSON）
+  	- bytecodes, []string, 合约字节码，为空时不生成部署函数
+  	- pkg, string, 生成代码的包名
+
+  Returns:
+  	- string, 格式化后的Go代码
+ 	- error
+
+  Call permissions: Anyone
+*/
+func Bind(names []string, abis []string, bytecodes []string, pkg string) (string, error) {
+	if len(names) != len(abis) || len(names) != len(bytecodes) {
+		return "", errors.New("the numbers of names, ABIs and bytecodes differ")
+	}
+
+	data := &tmplData{Package: pkg}
+	structs := make(map[string]*tmplStruct)
+	for i, name := range names {
+		contract, err := bindContract(name, abis[i], bytecodes[i], structs)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", name, err)
+		}
+		data.Contracts = append(data.Contracts, contract)
+	}
+	for _, s := range structs {
+		data.Structs = append(data.Structs, s)
+	}
+	sort.Slice(data.Structs, func(i, j int) bool { return data.Structs[i].Name < data.Structs[j].Name })
+
+	var buffer bytes.Buffer
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{
+		"capitalise": capitalise,
+	}).Parse(tmplSource))
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer.Bytes())
+	}
+	return string(code), nil
+}
+
+/*
+  BindOutput:
+   	EN - Generates the Go bindings of the contracts of a compiler output
+ 	CN - 根据编译器输出生成其中所有合约的Go绑定代码
+  Params:
+  	- output, *compiler.Output, Solidity编译器的输出
+  	- pkg, string, 生成代码的包名
+
+  Returns:
+  	- string, 格式化后的Go代码
+ 	- error
+
+  Call permissions: Anyone
+*/
+func BindOutput(output *compiler.Output, pkg string) (string, error) {
+	keys := make([]string, 0, len(output.Contracts))
+	for key := range output.Contracts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var names, abis, bytecodes []string
+	for _, key := range keys {
+		artifact := output.Contracts[key]
+		// the contracts are named <source>:<name>
+		name := key[strings.LastIndex(key, ":")+1:]
+		names = append(names, name)
+		abis = append(abis, artifact.Abi)
+		bytecodes = append(bytecodes, artifact.Bin)
+	}
+	return Bind(names, abis, bytecodes, pkg)
+}
+
+func bindContract(name, abiJSON, bytecode string, structs map[string]*tmplStruct) (*tmplContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	// the ABI is embedded without its spacing
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(abiJSON)); err != nil {
+		return nil, err
+	}
+	if bytecode != "" && !strings.HasPrefix(bytecode, "0x") {
+		bytecode = "0x" + bytecode
+	}
+
+	contract := &tmplContract{
+		Type:        capitalise(name),
+		InputABI:    compact.String(),
+		InputBin:    bytecode,
+		Constructor: bindArgs(parsed.Constructor.Inputs, structs),
+	}
+
+	methods := make([]string, 0, len(parsed.Methods))
+	for method := range parsed.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, key := range methods {
+		original := parsed.Methods[key]
+		method := &tmplMethod{
+			Original:   original,
+			Normalized: capitalise(original.Name),
+			Inputs:     bindArgs(original.Inputs, structs),
+			Outputs:    bindArgs(original.Outputs, structs),
+		}
+		if original.IsConstant() {
+			contract.Calls = append(contract.Calls, method)
+		} else {
+			contract.Transacts = append(contract.Transacts, method)
+		}
+	}
+
+	events := make([]string, 0, len(parsed.Events))
+	for event := range parsed.Events {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, key := range events {
+		original := parsed.Events[key]
+		event := &tmplEvent{Original: original, Normalized: capitalise(original.Name)}
+		for _, input := range original.Inputs {
+			field := tmplArg{Name: abi.ToCamelCase(input.Name), Type: bindType(input.Type, structs)}
+			if input.Indexed {
+				field.Type = bindTopicType(input.Type, structs)
+				event.Indexed = append(event.Indexed, tmplArg{Name: argName(input.Name), Type: field.Type})
+			}
+			event.Fields = append(event.Fields, field)
+		}
+		contract.Events = append(contract.Events, event)
+	}
+	return contract, nil
+}
+
+// bindArgs returns the arguments with their Go names and types, the unnamed
+// arguments are named arg<index>
+func bindArgs(args abi.Arguments, structs map[string]*tmplStruct) []tmplArg {
+	bound := make([]tmplArg, len(args))
+	for i, arg := range args {
+		name := arg.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		bound[i] = tmplArg{Name: argName(name), Type: bindType(arg.Type, structs)}
+	}
+	return bound
+}
+
+// bindType returns the Go type of an ABI type, it's the one the abi package
+// packs and unpacks
+func bindType(t abi.Type, structs map[string]*tmplStruct) string {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		prefix := "int"
+		if t.T == abi.UintTy {
+			prefix = "uint"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size)
+		}
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.AddressTy:
+		return "utils.Address"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.HashTy:
+		return "utils.Hash"
+	case abi.FunctionTy:
+		return "[24]byte"
+	case abi.SliceTy:
+		return "[]" + bindType(*t.Elem, structs)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]", t.Size) + bindType(*t.Elem, structs)
+	case abi.TupleTy:
+		return bindStruct(t, structs)
+	default:
+		return "[32]byte"
+	}
+}
+
+// bindTopicType returns the Go type of an indexed argument, the dynamic types
+// are in the topics as their hash
+func bindTopicType(t abi.Type, structs map[string]*tmplStruct) string {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "utils.Hash"
+	}
+	return bindType(t, structs)
+}
+
+// bindStruct returns the name of the struct of a tuple, it's declared once
+// for all the contracts
+func bindStruct(t abi.Type, structs map[string]*tmplStruct) string {
+	var fields []tmplArg
+	for i, elem := range t.TupleElems {
+		fields = append(fields, tmplArg{Name: abi.ToCamelCase(t.TupleRawNames[i]), Type: bindType(*elem, structs)})
+	}
+
+	name := capitalise(t.TupleRawName)
+	if name == "" {
+		// the tuples without a struct name are named after their signature
+		hash := fnv.New32a()
+		hash.Write([]byte(t.String()))
+		name = fmt.Sprintf("Tuple%08x", hash.Sum32())
+	}
+	if _, ok := structs[name]; !ok {
+		structs[name] = &tmplStruct{Name: name, Fields: fields}
+	}
+	return name
+}
+
+// reserved are the names used by the generated methods besides their arguments
+var reserved = map[string]bool{
+	"opts": true, "sink": true, "ctx": true, "err": true, "out": true,
+	"event": true, "events": true, "log": true, "logs": true, "i": true,
+}
+
+// argName returns the name of the Go parameter of an argument
+func argName(name string) string {
+	name = decapitalise(name)
+	if reserved[name] || strings.HasPrefix(name, "ret") || strings.HasSuffix(name, "Rule") || strings.HasSuffix(name, "Item") {
+		name += "_"
+	}
+	return name
+}
+
+// capitalise makes name an exported Go identifier
+func capitalise(name string) string {
+	name = abi.ToCamelCase(strings.TrimLeft(name, "_"))
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// decapitalise makes name an unexported Go identifier which isn't a keyword
+func decapitalise(name string) string {
+	name = abi.ToCamelCase(strings.TrimLeft(name, "_"))
+	if name == "" {
+		return name
+	}
+	name = strings.ToLower(name[:1]) + name[1:]
+	if token.Lookup(name).IsKeyword() {
+		name += "_"
+	}
+	return name
+}
@@ -0,0 +1,220 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package bind
+
+// tmplSource is the template of the generated bindings
+const tmplSource = `// Code generated by abigen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/tchain/go-tchain-sdk/abi/bind"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = context.Background
+	_ = dto.TransactionLogs{}
+	_ = (*providers.Subscription)(nil)
+	_ = utils.Address{}
+)
+
+{{range .Structs}}
+// {{.Name}} is an auto generated low-level Go binding around a user-defined struct.
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}}
+{{end}}}
+{{end}}
+
+{{range $contract := .Contracts}}
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+const {{.Type}}ABI = {{printf "%q" .InputABI}}
+
+{{if .InputBin}}
+// {{.Type}}Bin is the compiled bytecode used for deploying new contracts.
+const {{.Type}}Bin = {{printf "%q" .InputBin}}
+
+// Deploy{{.Type}} deploys a new {{.Type}} contract, it returns the hash of the
+// transaction, the address of the contract is in its receipt.
+func Deploy{{.Type}}(opts *bind.TransactOpts, backend *core.Core{{range .Constructor}}, {{.Name}} {{.Type}}{{end}}) (string, error) {
+	return bind.DeployContract(opts, {{.Type}}ABI, {{.Type}}Bin, backend{{range .Constructor}}, {{.Name}}{{end}})
+}
+{{end}}
+
+// {{.Type}} is an auto generated Go binding around a contract.
+type {{.Type}} struct {
+	{{.Type}}Caller     // Read-only binding to the contract
+	{{.Type}}Transactor // Write-only binding to the contract
+	{{.Type}}Filterer   // Log filterer for contract events
+}
+
+// {{.Type}}Caller is an auto generated read-only Go binding around a contract.
+type {{.Type}}Caller struct {
+	contract *bind.BoundContract
+}
+
+// {{.Type}}Transactor is an auto generated write-only Go binding around a contract.
+type {{.Type}}Transactor struct {
+	contract *bind.BoundContract
+}
+
+// {{.Type}}Filterer is an auto generated log filtering Go binding around a contract's events.
+type {{.Type}}Filterer struct {
+	contract *bind.BoundContract
+}
+
+// New{{.Type}} creates a new instance of {{.Type}}, bound to the contract at
+// address, in did:bid form.
+func New{{.Type}}(address string, backend *core.Core) (*{{.Type}}, error) {
+	contract, err := bind.NewBoundContract(address, {{.Type}}ABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+}
+
+// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to the contract at address.
+func New{{.Type}}Caller(address string, backend *core.Core) (*{{.Type}}Caller, error) {
+	contract, err := bind.NewBoundContract(address, {{.Type}}ABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Caller{contract: contract}, nil
+}
+
+// New{{.Type}}Transactor creates a new write-only instance of {{.Type}}, bound to the contract at address.
+func New{{.Type}}Transactor(address string, backend *core.Core) (*{{.Type}}Transactor, error) {
+	contract, err := bind.NewBoundContract(address, {{.Type}}ABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Transactor{contract: contract}, nil
+}
+
+// New{{.Type}}Filterer creates a new log filterer instance of {{.Type}}, bound to the contract at address.
+func New{{.Type}}Filterer(address string, backend *core.Core) (*{{.Type}}Filterer, error) {
+	contract, err := bind.NewBoundContract(address, {{.Type}}ABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}Filterer{contract: contract}, nil
+}
+
+{{range .Calls}}
+// {{.Normalized}} is a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Caller) {{.Normalized}}(opts *bind.CallOpts{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) ({{range .Outputs}}{{.Type}}, {{end}}error) {
+	{{if not .Outputs}}return _{{$contract.Type}}.contract.Call(opts, nil, {{printf "%q" .Original.Name}}{{range .Inputs}}, {{.Name}}{{end}})
+	{{- else}}var (
+		{{range $i, $_ := .Outputs}}ret{{$i}} = new({{.Type}})
+		{{end}}
+	)
+	{{if eq (len .Outputs) 1}}out := ret0{{else}}out := &[]interface{}{
+		{{range $i, $_ := .Outputs}}ret{{$i}},
+		{{end}}
+	}{{end}}
+	err := _{{$contract.Type}}.contract.Call(opts, out, {{printf "%q" .Original.Name}}{{range .Inputs}}, {{.Name}}{{end}})
+	return {{range $i, $_ := .Outputs}}*ret{{$i}}, {{end}}err
+	{{- end}}
+}
+{{end}}
+
+{{range .Transacts}}
+// {{.Normalized}} is a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}},
+// it returns the hash of the transaction.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Transactor) {{.Normalized}}(opts *bind.TransactOpts{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) (string, error) {
+	return _{{$contract.Type}}.contract.Transact(opts, {{printf "%q" .Original.Name}}{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+
+{{range .Events}}
+// {{$contract.Type}}{{.Normalized}} represents a {{.Original.Name}} event raised by the {{$contract.Type}} contract.
+type {{$contract.Type}}{{.Normalized}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}}
+{{end}}	Raw dto.TransactionLogs // Blockchain specific contextual infos
+}
+
+// Filter{{.Normalized}} is a free log retrieval operation binding the contract event 0x{{printf "%x" .Original.ID}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Filter{{.Normalized}}(opts *bind.FilterOpts{{range .Indexed}}, {{.Name}} []{{.Type}}{{end}}) ([]*{{$contract.Type}}{{.Normalized}}, error) {
+	{{range .Indexed}}var {{.Name}}Rule []interface{}
+	for _, {{.Name}}Item := range {{.Name}} {
+		{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
+	}
+	{{end}}
+	logs, err := _{{$contract.Type}}.contract.FilterLogs(opts, {{printf "%q" .Original.Name}}{{range .Indexed}}, {{.Name}}Rule{{end}})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*{{$contract.Type}}{{.Normalized}}, len(logs))
+	for i, log := range logs {
+		if events[i], err = _{{$contract.Type}}.Parse{{.Normalized}}(log); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// Watch{{.Normalized}} is a free log subscription operation binding the contract event 0x{{printf "%x" .Original.ID}},
+// the events are sent to sink until the context of opts is done or the subscription fails.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Watch{{.Normalized}}(opts *bind.WatchOpts, sink chan<- *{{$contract.Type}}{{.Normalized}}{{range .Indexed}}, {{.Name}} []{{.Type}}{{end}}) (*providers.Subscription, error) {
+	{{range .Indexed}}var {{.Name}}Rule []interface{}
+	for _, {{.Name}}Item := range {{.Name}} {
+		{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
+	}
+	{{end}}
+	return _{{$contract.Type}}.contract.WatchLogs(opts, {{printf "%q" .Original.Name}}, func(ctx context.Context, log dto.TransactionLogs) error {
+		event, err := _{{$contract.Type}}.Parse{{.Normalized}}(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}{{range .Indexed}}, {{.Name}}Rule{{end}})
+}
+
+// Parse{{.Normalized}} is a log parse operation binding the contract event 0x{{printf "%x" .Original.ID}}.
+//
+// Solidity: {{.Original.String}}
+func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Parse{{.Normalized}}(log dto.TransactionLogs) (*{{$contract.Type}}{{.Normalized}}, error) {
+	event := new({{$contract.Type}}{{.Normalized}})
+	if err := _{{$contract.Type}}.contract.UnpackLog(event, {{printf "%q" .Original.Name}}, log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+{{end}}
+{{end}}
+`
@@ -15,15 +15,84 @@
 package abi
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/utils"
 )
 
 var (
 	errBadBool = errors.New("abi: improperly encoded boolean value")
 )
 
+// Error is a custom error declared by a contract, raised with revert
+// CustomError(args) since solidity v0.8.4.
+type Error struct {
+	Name   string
+	Inputs Arguments
+	str    string
+	// Sig contains the string signature according to the ABI spec.
+	// e.g.	 error foo(uint32 a, int b) = "foo(uint32,int256)"
+	Sig string
+	// ID is the hash of Sig, its first 4 bytes select the error in the revert data.
+	ID utils.Hash
+}
+
+// NewError creates a new Error, it precomputes its id, signature and string
+// representation.
+func NewError(name string, inputs Arguments) Error {
+	names := make([]string, len(inputs))
+	types := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input.Name == "" {
+			inputs[i] = Argument{
+				Name:    fmt.Sprintf("arg%d", i),
+				Indexed: input.Indexed,
+				Type:    input.Type,
+			}
+		} else {
+			inputs[i] = input
+		}
+		// string representation
+		names[i] = fmt.Sprintf("%v %v", input.Type, inputs[i].Name)
+		// sig representation
+		types[i] = input.Type.String()
+	}
+
+	str := fmt.Sprintf("error %v(%v)", name, strings.Join(names, ", "))
+	sig := fmt.Sprintf("%v(%v)", name, strings.Join(types, ","))
+	id := utils.BytesToHash(crypto.Keccak256(config.SECP256K1, []byte(sig)))
+
+	return Error{
+		Name:   name,
+		Inputs: inputs,
+		str:    str,
+		Sig:    sig,
+		ID:     id,
+	}
+}
+
+func (e Error) String() string {
+	return e.str
+}
+
+// Unpack decodes the arguments of the error from the revert data, selector
+// included.
+func (e Error) Unpack(data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	if !bytes.Equal(data[:4], e.ID[:4]) {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	return e.Inputs.UnpackValues(data[4:])
+}
+
 // formatSliceString formats the reflection kind with the given slice size
 // and returns a formatted string representation.
 func formatSliceString(kind reflect.Kind, sliceSize int) string {
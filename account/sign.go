@@ -7,6 +7,7 @@ import (
 	"errors"
 	"github.com/tchain/go-tchain-sdk/crypto"
 	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"github.com/tchain/go-tchain-sdk/utils/rlp"
@@ -143,6 +144,64 @@ func SignTransaction(signData *SignTxParams, privateKey string, isSM2 bool) (*Si
 	return &SignTransactionResult{data, signed}, nil
 }
 
+/*
+  EncodeTransaction:
+   	EN - Returns the RLP encoding of a transaction returned by the node, the value of the transaction in the transactions trie of its block
+ 	CN - 返回节点所返回交易的RLP编码，即该交易在区块交易树中的值
+  Params:
+  	- tx, *dto.TransactionResponse, GetTransactionByHash或GetBlockWithTransactions返回的交易
+
+  Returns:
+  	- []byte, 与SignTransaction的Raw相同格式的RLP编码
+ 	- error
+
+  Call permissions: Anyone
+*/
+func EncodeTransaction(tx *dto.TransactionResponse) ([]byte, error) {
+	if len(tx.SignUser) == 0 || tx.SignUser[0] == nil {
+		return nil, errors.New("transaction isn't signed")
+	}
+
+	data := &txData{
+		ChainId:   tx.ChainId,
+		Nonce:     tx.Nonce,
+		GasPrice:  tx.GasPrice,
+		GasLimit:  tx.Gas,
+		Sender:    toAddress(tx.Sender),
+		Recipient: toAddress(tx.Recipient),
+		Amount:    tx.Amount,
+		Payload:   utils.FromHex(tx.Payload),
+	}
+	if data.GasPrice == nil {
+		data.GasPrice = new(big.Int)
+	}
+	if data.Amount == nil {
+		data.Amount = new(big.Int)
+	}
+
+	var b bytes.Buffer
+	b.Write(tx.SignUser[0].PublicKey)
+	b.Write(tx.SignUser[0].CryptoType)
+	b.Write(tx.SignUser[0].Signature)
+	data.SignUser = b.Bytes()
+
+	return rlp.EncodeToBytes(data)
+}
+
+// toAddress converts a did:bid or hex address, nil when it's empty
+func toAddress(s string) *utils.Address {
+	if s == "" {
+		return nil
+	}
+	var address utils.Address
+	if utils.Has0xPrefix(s) {
+		address = utils.BytesToAddress(utils.FromHex(s))
+	} else {
+		address = utils.StringToAddress(s)
+	}
+	return &address
+}
+
 /*
   RecoverTransaction:
    	EN - Recovers the Bif address which was used to sign the given RLP encoded transaction.
@@ -16,6 +16,7 @@
 package bif
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/account/types"
 	"github.com/tchain/go-tchain-sdk/core"
 	"github.com/tchain/go-tchain-sdk/debug"
@@ -80,10 +81,15 @@ func NewBif(provider providers.ProviderInterface) *Bif {
   Call permissions: Anyone
 */
 func (bif Bif) ClientVersion() (string, error) {
+	return bif.ClientVersionCtx(context.Background())
+}
+
+// ClientVersionCtx is like ClientVersion but honours the cancellation and deadline of ctx.
+func (bif Bif) ClientVersionCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := bif.Provider.SendRequest(pointer, "bif_clientVersion", nil)
+	err := bif.Provider.SendRequestContext(ctx, pointer, "bif_clientVersion", nil)
 
 	if err != nil {
 		return "", err
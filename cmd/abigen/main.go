@@ -0,0 +1,113 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+// abigen generates the typed Go bindings of contracts, from an ABI file, the
+// combined JSON output of solc or Solidity sources.
+//
+//	abigen -abi token.abi -bin token.bin -type Token -pkg token -out token.go
+//	abigen -combined-json contracts.json -pkg contracts
+//	abigen -sol token.sol -solc /usr/bin/solc -pkg token -out token.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tchain/go-tchain-sdk/abi/bind"
+	"github.com/tchain/go-tchain-sdk/compiler"
+)
+
+var (
+	abiFlag      = flag.String("abi", "", "path to the ABI JSON of the contract")
+	binFlag      = flag.String("bin", "", "path to the bytecode of the contract, to generate its deploy function")
+	typeFlag     = flag.String("type", "", "Go type name of the contract, the name of the ABI file by default")
+	combinedFlag = flag.String("combined-json", "", "path to the combined JSON output of solc")
+	solFlag      = flag.String("sol", "", "path to the Solidity source to compile")
+	solcFlag     = flag.String("solc", "solc", "solc compiler used with -sol")
+	pkgFlag      = flag.String("pkg", "", "package name of the generated code")
+	outFlag      = flag.String("out", "", "output file, stdout by default")
+)
+
+func main() {
+	flag.Parse()
+
+	if *pkgFlag == "" {
+		fatalf("no package name, set -pkg")
+	}
+
+	var (
+		code string
+		err  error
+	)
+	switch {
+	case *abiFlag != "":
+		code, err = bindABI()
+	case *combinedFlag != "":
+		var output compiler.Output
+		var data []byte
+		if data, err = ioutil.ReadFile(*combinedFlag); err == nil {
+			if err = json.Unmarshal(data, &output); err == nil {
+				code, err = bind.BindOutput(&output, *pkgFlag)
+			}
+		}
+	case *solFlag != "":
+		var output *compiler.Output
+		if output, err = compiler.NewSolidityCompiler(*solcFlag).Compile(*solFlag); err == nil {
+			code, err = bind.BindOutput(output, *pkgFlag)
+		}
+	default:
+		fatalf("no contract, set -abi, -combined-json or -sol")
+	}
+	if err != nil {
+		fatalf("failed to generate the bindings: %v", err)
+	}
+
+	if *outFlag == "" {
+		fmt.Print(code)
+		return
+	}
+	if err := ioutil.WriteFile(*outFlag, []byte(code), 0644); err != nil {
+		fatalf("failed to write the bindings: %v", err)
+	}
+}
+
+func bindABI() (string, error) {
+	abiJSON, err := ioutil.ReadFile(*abiFlag)
+	if err != nil {
+		return "", err
+	}
+
+	var bytecode []byte
+	if *binFlag != "" {
+		if bytecode, err = ioutil.ReadFile(*binFlag); err != nil {
+			return "", err
+		}
+	}
+
+	name := *typeFlag
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(*abiFlag), filepath.Ext(*abiFlag))
+	}
+	return bind.Bind([]string{name}, []string{string(abiJSON)}, []string{strings.TrimSpace(string(bytecode))}, *pkgFlag)
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
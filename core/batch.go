@@ -0,0 +1,155 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+)
+
+const (
+	// maxBlockRange is the largest number of blocks returned by GetBlocksByRange
+	maxBlockRange = 10000
+	// blocksPerBatch is the number of blocks requested by each batch of GetBlocksByRange
+	blocksPerBatch = 100
+)
+
+/*
+  GetTransactionReceipts:
+   	EN - Returns the transaction receipts for the given transaction hashes in a single batch request
+ 	CN - 通过一次批量请求返回给定交易哈希的交易收据
+  Params:
+  	- hashes, []string, 32 Bytes 交易哈希列表
+
+  Returns:
+  	- []*dto.TransactionReceipt, 与hashes一一对应的交易收据，交易尚未打包时对应位置为nil
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetTransactionReceipts(hashes []string) ([]*dto.TransactionReceipt, error) {
+	return core.GetTransactionReceiptsCtx(context.Background(), hashes)
+}
+
+// GetTransactionReceiptsCtx is like GetTransactionReceipts but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionReceiptsCtx(ctx context.Context, hashes []string) ([]*dto.TransactionReceipt, error) {
+
+	elems := make([]providers.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = providers.BatchElem{
+			Method: "core_getTransactionReceipt",
+			Params: []string{hash},
+			Result: &dto.CoreRequestResult{},
+		}
+	}
+
+	if err := providers.BatchSendRequestContext(ctx, core.provider, elems); err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*dto.TransactionReceipt, len(hashes))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("receipt of %s: %w", hashes[i], elem.Error)
+		}
+
+		receipt, err := elem.Result.(*dto.CoreRequestResult).ToTransactionReceipt()
+		if errors.Is(err, dto.EMPTYRESPONSE) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receipt of %s: %w", hashes[i], err)
+		}
+		receipts[i] = receipt
+	}
+
+	return receipts, nil
+}
+
+/*
+  GetBlocksByRange:
+   	EN - Returns the blocks from number from to number to (both included), requested by batches of 100 blocks
+ 	CN - 通过批量请求（每批100个区块）返回区块号从from到to（包含两端）的区块信息
+  Params:
+  	- from, uint64, 起始区块号
+  	- to, uint64, 结束区块号，区间最多包含10000个区块
+  	- transactionDetails,bool, 如果为True，返回区块内详细的交易信息和其他信息；如果为false则仅返回区块内交易hash和其他信息
+
+  Returns:
+  	- []interface{}, 按区块号排列，如果transactionDetails为true，则元素是*dto.BlockDetails；如果为false，则是*dto.BlockNoDetails
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetBlocksByRange(from uint64, to uint64, transactionDetails bool) ([]interface{}, error) {
+	return core.GetBlocksByRangeCtx(context.Background(), from, to, transactionDetails)
+}
+
+// GetBlocksByRangeCtx is like GetBlocksByRange but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlocksByRangeCtx(ctx context.Context, from uint64, to uint64, transactionDetails bool) ([]interface{}, error) {
+	if from > to {
+		return nil, errors.New("invalid block range")
+	}
+	if to-from >= maxBlockRange {
+		return nil, fmt.Errorf("block range larger than %d blocks", maxBlockRange)
+	}
+
+	blocks := make([]interface{}, 0, to-from+1)
+	for start := from; start <= to; start += blocksPerBatch {
+		end := to
+		if to-start >= blocksPerBatch {
+			end = start + blocksPerBatch - 1
+		}
+
+		elems := make([]providers.BatchElem, 0, end-start+1)
+		for number := start; number <= end; number++ {
+			elems = append(elems, providers.BatchElem{
+				Method: "core_getBlockByNumber",
+				Params: []interface{}{hexutil.EncodeUint64(number), transactionDetails},
+				Result: &dto.CoreRequestResult{},
+			})
+			if number == end {
+				break
+			}
+		}
+
+		if err := providers.BatchSendRequestContext(ctx, core.provider, elems); err != nil {
+			return nil, err
+		}
+
+		for i, elem := range elems {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("block %d: %w", start+uint64(i), elem.Error)
+			}
+
+			block, err := elem.Result.(*dto.CoreRequestResult).ToBlock(transactionDetails)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", start+uint64(i), err)
+			}
+			blocks = append(blocks, block)
+		}
+
+		if end == to {
+			break
+		}
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,252 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+)
+
+/*
+  GetHeaderByNumber:
+   	EN - Returns the header of a block requested by blockNumber, with its decoded Istanbul extra data
+ 	CN - 根据区块号返回区块头，包含解析后的Istanbul共识数据（验证者、签名）
+  Params:
+	- blockNumber, string, options are:
+	 (1) block.NUMBER(n) or a decimal string - an integer block number
+	 (2) block.EARLIEST - for the genesis block
+	 (3) block.LATEST - for the latest mined block
+	 (4) block.PENDING - for the pending block
+
+  Returns:
+  	- *dto.BlockHeader, 区块头
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetHeaderByNumber(blockNumber string) (*dto.BlockHeader, error) {
+	return core.GetHeaderByNumberCtx(context.Background(), blockNumber)
+}
+
+// GetHeaderByNumberCtx is like GetHeaderByNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetHeaderByNumberCtx(ctx context.Context, blockNumber string) (*dto.BlockHeader, error) {
+
+	pointer, err := core.getBlockByNumber(ctx, blockNumber, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockHeader()
+}
+
+/*
+  GetHeaderByHash:
+   	EN - Returns the header of a block requested by block hash, with its decoded Istanbul extra data
+ 	CN - 根据区块哈希返回区块头，包含解析后的Istanbul共识数据（验证者、签名）
+  Params:
+	- blockHash, string, 32 Bytes 区块哈希
+
+  Returns:
+  	- *dto.BlockHeader, 区块头
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetHeaderByHash(blockHash string) (*dto.BlockHeader, error) {
+	return core.GetHeaderByHashCtx(context.Background(), blockHash)
+}
+
+// GetHeaderByHashCtx is like GetHeaderByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetHeaderByHashCtx(ctx context.Context, blockHash string) (*dto.BlockHeader, error) {
+
+	pointer, err := core.getBlockByHash(ctx, blockHash, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockHeader()
+}
+
+/*
+  GetBlockWithHashesByNumber:
+   	EN - Returns a block requested by blockNumber, with the hashes of its transactions
+ 	CN - 根据区块号返回区块，包含区块内的交易哈希
+  Params:
+	- blockNumber, string, 同GetHeaderByNumber
+
+  Returns:
+  	- *dto.Block, 区块
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetBlockWithHashesByNumber(blockNumber string) (*dto.Block, error) {
+	return core.GetBlockWithHashesByNumberCtx(context.Background(), blockNumber)
+}
+
+// GetBlockWithHashesByNumberCtx is like GetBlockWithHashesByNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockWithHashesByNumberCtx(ctx context.Context, blockNumber string) (*dto.Block, error) {
+
+	pointer, err := core.getBlockByNumber(ctx, blockNumber, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockWithHashes()
+}
+
+/*
+  GetBlockWithHashesByHash:
+   	EN - Returns a block requested by block hash, with the hashes of its transactions
+ 	CN - 根据区块哈希返回区块，包含区块内的交易哈希
+  Params:
+	- blockHash, string, 32 Bytes 区块哈希
+
+  Returns:
+  	- *dto.Block, 区块
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetBlockWithHashesByHash(blockHash string) (*dto.Block, error) {
+	return core.GetBlockWithHashesByHashCtx(context.Background(), blockHash)
+}
+
+// GetBlockWithHashesByHashCtx is like GetBlockWithHashesByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockWithHashesByHashCtx(ctx context.Context, blockHash string) (*dto.Block, error) {
+
+	pointer, err := core.getBlockByHash(ctx, blockHash, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockWithHashes()
+}
+
+/*
+  GetBlockWithTransactionsByNumber:
+   	EN - Returns a block requested by blockNumber, with its full transactions
+ 	CN - 根据区块号返回区块，包含区块内的详细交易信息
+  Params:
+	- blockNumber, string, 同GetHeaderByNumber
+
+  Returns:
+  	- *dto.BlockWithTransactions, 区块
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetBlockWithTransactionsByNumber(blockNumber string) (*dto.BlockWithTransactions, error) {
+	return core.GetBlockWithTransactionsByNumberCtx(context.Background(), blockNumber)
+}
+
+// GetBlockWithTransactionsByNumberCtx is like GetBlockWithTransactionsByNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockWithTransactionsByNumberCtx(ctx context.Context, blockNumber string) (*dto.BlockWithTransactions, error) {
+
+	pointer, err := core.getBlockByNumber(ctx, blockNumber, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockWithTransactions()
+}
+
+/*
+  GetBlockWithTransactionsByHash:
+   	EN - Returns a block requested by block hash, with its full transactions
+ 	CN - 根据区块哈希返回区块，包含区块内的详细交易信息
+  Params:
+	- blockHash, string, 32 Bytes 区块哈希
+
+  Returns:
+  	- *dto.BlockWithTransactions, 区块
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetBlockWithTransactionsByHash(blockHash string) (*dto.BlockWithTransactions, error) {
+	return core.GetBlockWithTransactionsByHashCtx(context.Background(), blockHash)
+}
+
+// GetBlockWithTransactionsByHashCtx is like GetBlockWithTransactionsByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockWithTransactionsByHashCtx(ctx context.Context, blockHash string) (*dto.BlockWithTransactions, error) {
+
+	pointer, err := core.getBlockByHash(ctx, blockHash, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBlockWithTransactions()
+}
+
+func (core *Core) getBlockByNumber(ctx context.Context, blockNumber string, transactionDetails bool) (*dto.CoreRequestResult, error) {
+
+	number, err := blockNumberParam(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer := &dto.CoreRequestResult{}
+
+	err = core.provider.SendRequestContext(ctx, pointer, "core_getBlockByNumber", []interface{}{number, transactionDetails})
+
+	return pointer, err
+}
+
+func (core *Core) getBlockByHash(ctx context.Context, blockHash string, transactionDetails bool) (*dto.CoreRequestResult, error) {
+
+	hash, err := blockHashParam(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer := &dto.CoreRequestResult{}
+
+	err = core.provider.SendRequestContext(ctx, pointer, "core_getBlockByHash", []interface{}{hash, transactionDetails})
+
+	return pointer, err
+}
+
+// blockNumberParam converts a block tag, or a block number in decimal or hex,
+// to the block parameter of the JSON-RPC methods
+func blockNumberParam(blockNumber string) (string, error) {
+	switch blockNumber {
+	case block.EARLIEST, block.LATEST, block.PENDING:
+		return blockNumber, nil
+	}
+
+	number, ok := new(big.Int).SetString(blockNumber, 0)
+	if !ok || number.Sign() < 0 {
+		return "", errors.New("invalid blockNumber")
+	}
+	return fmt.Sprintf("0x%x", number), nil
+}
+
+// blockHashParam checks the format of a block hash, with or without 0x
+func blockHashParam(blockHash string) (string, error) {
+	if !strings.HasPrefix(blockHash, "0x") {
+		blockHash = "0x" + blockHash
+	}
+	if len(blockHash) != 66 {
+		return "", errors.New("malformed block hash")
+	}
+	return blockHash, nil
+}
@@ -0,0 +1,324 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/types"
+)
+
+const (
+	defaultGasMultiplier    = 1.2
+	defaultPercentileBlocks = 20
+	defaultPercentile       = 60
+)
+
+// GasPriceStrategy decides the gas price of the transactions built by a TxBuilder
+type GasPriceStrategy interface {
+	GasPrice(ctx context.Context, core *Core) (*big.Int, error)
+}
+
+// NodeGasPrice takes the gas price suggested by the node, core_gasPrice
+type NodeGasPrice struct{}
+
+func (NodeGasPrice) GasPrice(ctx context.Context, core *Core) (*big.Int, error) {
+	return core.GetGasPriceCtx(ctx)
+}
+
+// FixedGasPrice always takes Price
+type FixedGasPrice struct {
+	Price *big.Int
+}
+
+func (strategy FixedGasPrice) GasPrice(ctx context.Context, core *Core) (*big.Int, error) {
+	if strategy.Price == nil {
+		return nil, errors.New("fixed gas price is nil")
+	}
+	return new(big.Int).Set(strategy.Price), nil
+}
+
+// PercentileGasPrice takes the Percentile of the gas prices of the
+// transactions in the last Blocks blocks, the price of the node when the
+// blocks are empty
+type PercentileGasPrice struct {
+	// Blocks is the number of blocks read, 20 by default
+	Blocks int
+	// Percentile is between 0 and 100, 60 by default
+	Percentile float64
+}
+
+func (strategy PercentileGasPrice) GasPrice(ctx context.Context, core *Core) (*big.Int, error) {
+	blocks := strategy.Blocks
+	if blocks <= 0 {
+		blocks = defaultPercentileBlocks
+	}
+	percentile := strategy.Percentile
+	if percentile <= 0 || percentile > 100 {
+		percentile = defaultPercentile
+	}
+
+	head, err := core.GetBlockNumberCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks are read with a single batch request
+	to := head.Uint64()
+	from := uint64(0)
+	if to >= uint64(blocks) {
+		from = to - uint64(blocks) + 1
+	}
+	fetched, err := core.GetBlocksByRangeCtx(ctx, from, to, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []*big.Int
+	for _, fetchedBlock := range fetched {
+		b, ok := fetchedBlock.(*dto.BlockDetails)
+		if !ok {
+			return nil, fmt.Errorf("unexpected block %T", fetchedBlock)
+		}
+		for _, tx := range b.Transactions {
+			if tx.GasPrice != nil {
+				prices = append(prices, tx.GasPrice)
+			}
+		}
+	}
+
+	if len(prices) == 0 {
+		return core.GetGasPriceCtx(ctx)
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+	index := int(math.Ceil(percentile/100*float64(len(prices)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return new(big.Int).Set(prices[index]), nil
+}
+
+// CappedGasPrice takes the price of Strategy, at most Cap
+type CappedGasPrice struct {
+	Strategy GasPriceStrategy
+	Cap      *big.Int
+}
+
+func (strategy CappedGasPrice) GasPrice(ctx context.Context, core *Core) (*big.Int, error) {
+	price, err := strategy.Strategy.GasPrice(ctx, core)
+	if err != nil {
+		return nil, err
+	}
+	if strategy.Cap != nil && price.Cmp(strategy.Cap) > 0 {
+		return new(big.Int).Set(strategy.Cap), nil
+	}
+	return price, nil
+}
+
+// BuilderOptions configures a TxBuilder, the zero values take the defaults
+type BuilderOptions struct {
+	// GasPrice is the strategy of the gas price, NodeGasPrice by default
+	GasPrice GasPriceStrategy
+	// GasMultiplier is applied to the estimated gas as a safety margin, 1.2 by default
+	GasMultiplier float64
+	// Nonces hands out the nonces, the pending transaction count of the
+	// sender is read for every transaction when nil
+	Nonces *NonceManager
+}
+
+// TxBuilder fills the fields of the transactions which are left empty by the caller
+type TxBuilder struct {
+	core *Core
+	opts BuilderOptions
+
+	lock    sync.Mutex
+	chainId uint64
+}
+
+/*
+  NewTxBuilder:
+   	EN - Creates a transaction builder, which fills ChainId, Nonce, GasPrice and GasLimit when they're missing from the transactions
+ 	CN - 创建交易构建器，自动填充交易中缺少的ChainId、Nonce、GasPrice和GasLimit
+  Params:
+  	- opts, *BuilderOptions, 为nil时使用默认值
+	  - GasPrice       GasPriceStrategy  gas价格策略：NodeGasPrice、PercentileGasPrice、FixedGasPrice或CappedGasPrice
+	  - GasMultiplier  float64           估算gas的安全系数，默认1.2
+	  - Nonces         *NonceManager     nonce管理器，为nil时每笔交易查询pending交易数
+
+  Returns:
+  	- *TxBuilder
+
+  Call permissions: Anyone
+*/
+func (core *Core) NewTxBuilder(opts *BuilderOptions) *TxBuilder {
+	builder := &TxBuilder{core: core}
+	if opts != nil {
+		builder.opts = *opts
+	}
+	if builder.opts.GasPrice == nil {
+		builder.opts.GasPrice = NodeGasPrice{}
+	}
+	if builder.opts.GasMultiplier <= 0 {
+		builder.opts.GasMultiplier = defaultGasMultiplier
+	}
+	return builder
+}
+
+/*
+  Fill:
+   	EN - Fills the missing ChainId, Nonce, GasPrice and GasLimit of tx, the fields set by the caller are kept
+ 	CN - 填充交易中缺少的ChainId、Nonce、GasPrice和GasLimit，调用方已设置的字段保持不变
+  Params:
+  	- ctx, context.Context
+  	- tx, *account.SignTxParams, 交易，Sender不能为空
+
+  Returns:
+  	- *NonceReservation, 使用nonce管理器分配nonce时返回，交易广播后调用Commit，否则调用Release或Fail；其他情况为nil
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (builder *TxBuilder) Fill(ctx context.Context, tx *account.SignTxParams) (*NonceReservation, error) {
+	if tx.Sender == nil {
+		return nil, errors.New("sender can't be nil")
+	}
+	sender := tx.Sender.String("")
+
+	if tx.ChainId == 0 {
+		chainId, err := builder.getChainId(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tx.ChainId = chainId
+	}
+
+	if tx.GasPrice == nil {
+		price, err := builder.opts.GasPrice.GasPrice(ctx, builder.core)
+		if err != nil {
+			return nil, err
+		}
+		tx.GasPrice = price
+	}
+
+	if tx.GasLimit == 0 {
+		params := &dto.TransactionParameters{
+			ChainId:  tx.ChainId,
+			Sender:   sender,
+			GasPrice: tx.GasPrice,
+			Amount:   tx.Amount,
+		}
+		if tx.Recipient != nil {
+			params.Recipient = tx.Recipient.String("")
+		}
+		if len(tx.Payload) > 0 {
+			params.Payload = types.ComplexString("0x" + utils.Bytes2Hex(tx.Payload))
+		}
+
+		gas, err := builder.core.EstimateGasCtx(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		tx.GasLimit = uint64(math.Ceil(float64(gas.Uint64()) * builder.opts.GasMultiplier))
+	}
+
+	// the nonce comes last, a reservation isn't left behind by the errors above
+	if tx.Nonce == nil {
+		if builder.opts.Nonces != nil {
+			reservation, err := builder.opts.Nonces.Next(ctx, sender)
+			if err != nil {
+				return nil, err
+			}
+			tx.Nonce = new(big.Int).Set(reservation.Nonce)
+			return reservation, nil
+		}
+
+		nonce, err := builder.core.GetTransactionCountCtx(ctx, sender, block.PENDING)
+		if err != nil {
+			return nil, err
+		}
+		tx.Nonce = nonce
+	}
+
+	return nil, nil
+}
+
+/*
+  SignAndSend:
+   	EN - Fills the missing fields of tx, signs it with privateKey and sends it, the nonce reserved for the transaction is released when it isn't accepted by the node
+ 	CN - 填充交易中缺少的字段，使用私钥签名并发送交易；节点未接受交易时释放为其分配的nonce
+  Params:
+  	- ctx, context.Context
+  	- tx, *account.SignTxParams, 交易，Sender不能为空
+  	- privateKey, string, 私钥
+  	- isSM2, bool, 私钥生成是否采用国密
+
+  Returns:
+  	- string, 交易哈希
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (builder *TxBuilder) SignAndSend(ctx context.Context, tx *account.SignTxParams, privateKey string, isSM2 bool) (string, error) {
+	reservation, err := builder.Fill(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+
+	signTx, err := account.SignTransaction(tx, privateKey, isSM2)
+	if err != nil {
+		if reservation != nil {
+			reservation.Release()
+		}
+		return "", err
+	}
+
+	hash, err := builder.core.SendRawTransactionCtx(ctx, signTx.Raw.String())
+	if reservation != nil {
+		if err != nil {
+			if failErr := reservation.Fail(ctx, err); failErr != nil {
+				err = fmt.Errorf("%w (resyncing the nonce failed: %v)", err, failErr)
+			}
+		} else {
+			reservation.Commit()
+		}
+	}
+	return hash, err
+}
+
+// getChainId returns the chain id of the node, it's read once
+func (builder *TxBuilder) getChainId(ctx context.Context) (uint64, error) {
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+
+	if builder.chainId == 0 {
+		chainId, err := builder.core.GetChainIdCtx(ctx)
+		if err != nil {
+			return 0, err
+		}
+		builder.chainId = chainId
+	}
+	return builder.chainId, nil
+}
@@ -0,0 +1,389 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultDroppedAfter = time.Minute
+)
+
+var (
+	// ErrWaitTimeout is returned when the transaction isn't confirmed before
+	// WaitOptions.Timeout or the deadline of the context
+	ErrWaitTimeout = errors.New("timeout waiting for the transaction")
+	// ErrTransactionDropped is returned when the node doesn't know the
+	// transaction, neither in a block nor in its pool, for WaitOptions.DroppedAfter
+	ErrTransactionDropped = errors.New("transaction dropped")
+	// ErrTrackerClosed ends the tracking of the transactions when their tracker is closed
+	ErrTrackerClosed = errors.New("confirmation tracker closed")
+)
+
+// WaitOptions configures WaitMined and the ConfirmationTracker, the zero
+// values take the defaults
+type WaitOptions struct {
+	// Confirmations is the number of blocks on top of the block of the
+	// transaction, 0 returns as soon as the transaction is mined
+	Confirmations uint64
+	// PollInterval is the interval between two checks when the provider
+	// doesn't support the newHeads subscription, 1 second by default
+	PollInterval time.Duration
+	// Timeout bounds the wait of each transaction, 0 is only bound by the context
+	Timeout time.Duration
+	// DroppedAfter is how long the transaction can be unknown to the node
+	// before it's reported as dropped, 1 minute by default
+	DroppedAfter time.Duration
+}
+
+// ConfirmationStatus is the state of a tracked transaction
+type ConfirmationStatus int
+
+const (
+	// StatusPending - the transaction is in the pool of the node
+	StatusPending ConfirmationStatus = iota
+	// StatusMined - the transaction is in a block, without enough confirmations yet
+	StatusMined
+	// StatusReorged - the block of the transaction left the chain, it's
+	// waited for again
+	StatusReorged
+	// StatusConfirmed - the transaction has the confirmations, final
+	StatusConfirmed
+	// StatusDropped - the node lost the transaction, final
+	StatusDropped
+	// StatusTimedOut - the transaction wasn't confirmed in time, final
+	StatusTimedOut
+	// StatusCanceled - the context was canceled or the tracker closed, final
+	StatusCanceled
+)
+
+func (status ConfirmationStatus) String() string {
+	switch status {
+	case StatusPending:
+		return "pending"
+	case StatusMined:
+		return "mined"
+	case StatusReorged:
+		return "reorged"
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusDropped:
+		return "dropped"
+	case StatusTimedOut:
+		return "timed out"
+	case StatusCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// ConfirmationEvent is a change of the state of a tracked transaction
+type ConfirmationEvent struct {
+	Hash   string
+	Status ConfirmationStatus
+	// Receipt is the receipt of the transaction when it's mined or confirmed,
+	// the receipt which left the chain when it's reorged
+	Receipt *dto.TransactionReceipt
+	// Confirmations is the number of blocks on top of the block of the transaction
+	Confirmations uint64
+	// Err is the cause of the final states but StatusConfirmed
+	Err error
+}
+
+// Final reports whether the event is the last one of the transaction
+func (event ConfirmationEvent) Final() bool {
+	return event.Status >= StatusConfirmed
+}
+
+/*
+  WaitMined:
+   	EN - Waits for a transaction to be mined with opts.Confirmations blocks on top of its block, following the reorgs
+ 	CN - 等待交易上链并达到opts.Confirmations个确认区块，区块重组后会继续等待
+  Params:
+  	- ctx, context.Context, 超时或取消等待
+  	- hash, string, 交易哈希，如SendRawTransaction或System写方法的返回值
+  	- opts, *WaitOptions, 为nil时使用默认值
+
+  Returns:
+  	- *dto.TransactionReceipt, 交易收据，交易执行失败时Status为false
+ 	- error, 超时返回ErrWaitTimeout，交易被丢弃返回ErrTransactionDropped
+
+  Call permissions: Anyone
+*/
+func (core *Core) WaitMined(ctx context.Context, hash string, opts *WaitOptions) (*dto.TransactionReceipt, error) {
+	tracker := core.NewConfirmationTracker(opts)
+	defer tracker.Close()
+
+	var last ConfirmationEvent
+	for event := range tracker.Track(ctx, hash) {
+		last = event
+	}
+
+	if last.Status != StatusConfirmed {
+		return nil, last.Err
+	}
+	return last.Receipt, nil
+}
+
+// ConfirmationTracker follows the confirmations of transactions, it's driven
+// by the newHeads subscription when the provider supports it and polls the
+// node otherwise
+type ConfirmationTracker struct {
+	core *Core
+	opts WaitOptions
+
+	lock     sync.Mutex
+	watchers map[chan struct{}]struct{}
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewConfirmationTracker starts a tracker, it must be closed to stop
+// following the blocks
+func (core *Core) NewConfirmationTracker(opts *WaitOptions) *ConfirmationTracker {
+	tracker := &ConfirmationTracker{
+		core:     core,
+		watchers: make(map[chan struct{}]struct{}),
+		closeCh:  make(chan struct{}),
+	}
+	if opts != nil {
+		tracker.opts = *opts
+	}
+	if tracker.opts.PollInterval <= 0 {
+		tracker.opts.PollInterval = defaultPollInterval
+	}
+	if tracker.opts.DroppedAfter <= 0 {
+		tracker.opts.DroppedAfter = defaultDroppedAfter
+	}
+
+	go tracker.followBlocks()
+	return tracker
+}
+
+// Track follows the transaction hash until it's confirmed, dropped, timed
+// out or ctx ends. The events are sent to the returned channel, which is
+// closed after the final one. The channel must be drained.
+func (tracker *ConfirmationTracker) Track(ctx context.Context, hash string) <-chan ConfirmationEvent {
+	events := make(chan ConfirmationEvent, 16)
+	wake := make(chan struct{}, 1)
+
+	tracker.lock.Lock()
+	tracker.watchers[wake] = struct{}{}
+	tracker.lock.Unlock()
+
+	go func() {
+		defer close(events)
+		defer func() {
+			tracker.lock.Lock()
+			delete(tracker.watchers, wake)
+			tracker.lock.Unlock()
+		}()
+
+		if tracker.opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, tracker.opts.Timeout)
+			defer cancel()
+		}
+
+		w := &txWatcher{core: tracker.core, hash: hash, opts: tracker.opts, lastSeen: time.Now()}
+		emit := func(event ConfirmationEvent) {
+			event.Hash = hash
+			events <- event
+		}
+
+		for {
+			if w.check(ctx, emit) {
+				return
+			}
+
+			select {
+			case <-wake:
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					emit(ConfirmationEvent{Status: StatusTimedOut, Receipt: w.receipt, Err: fmt.Errorf("%w %s", ErrWaitTimeout, hash)})
+				} else {
+					emit(ConfirmationEvent{Status: StatusCanceled, Receipt: w.receipt, Err: ctx.Err()})
+				}
+				return
+			case <-tracker.closeCh:
+				emit(ConfirmationEvent{Status: StatusCanceled, Receipt: w.receipt, Err: ErrTrackerClosed})
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// Close stops the tracker, the tracked transactions end with ErrTrackerClosed
+func (tracker *ConfirmationTracker) Close() {
+	tracker.closeOnce.Do(func() {
+		close(tracker.closeCh)
+	})
+}
+
+// followBlocks wakes the watchers on every new block, from the newHeads
+// subscription or else every PollInterval
+func (tracker *ConfirmationTracker) followBlocks() {
+	heads := make(chan *dto.BlockHeader, 16)
+	var subErr <-chan error
+
+	sub, err := tracker.core.SubscribeNewHeads(context.Background(), heads)
+	if err == nil {
+		defer sub.Unsubscribe()
+		subErr = sub.Err()
+	}
+
+	var ticks <-chan time.Time
+	if sub == nil {
+		ticker := time.NewTicker(tracker.opts.PollInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-heads:
+		case <-ticks:
+		case <-subErr:
+			// the subscription failed, fall back to polling
+			subErr = nil
+			ticker := time.NewTicker(tracker.opts.PollInterval)
+			defer ticker.Stop()
+			ticks = ticker.C
+			continue
+		case <-tracker.closeCh:
+			return
+		}
+		tracker.wakeAll()
+	}
+}
+
+func (tracker *ConfirmationTracker) wakeAll() {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	for wake := range tracker.watchers {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// txWatcher is the state of a tracked transaction
+type txWatcher struct {
+	core *Core
+	hash string
+	opts WaitOptions
+
+	// receipt is the last receipt seen, nil until the transaction is mined
+	receipt  *dto.TransactionReceipt
+	pending  bool
+	notified uint64
+	lastSeen time.Time
+}
+
+// check reads the state of the transaction on the node and emits its
+// changes, it returns true once the state is final. The requests which fail
+// are tried again on the next block.
+func (w *txWatcher) check(ctx context.Context, emit func(ConfirmationEvent)) bool {
+	receipt, err := w.core.GetTransactionReceiptCtx(ctx, w.hash)
+	if err != nil && !errors.Is(err, dto.EMPTYRESPONSE) {
+		return false
+	}
+
+	if err != nil {
+		// the receipt isn't known, the transaction is pending or its block left the chain
+		if w.receipt != nil {
+			w.reorged(emit)
+		}
+
+		_, err := w.core.GetTransactionByHashCtx(ctx, w.hash)
+		switch {
+		case err == nil:
+			w.lastSeen = time.Now()
+			if !w.pending {
+				w.pending = true
+				emit(ConfirmationEvent{Status: StatusPending})
+			}
+		case errors.Is(err, dto.EMPTYRESPONSE) && time.Since(w.lastSeen) >= w.opts.DroppedAfter:
+			emit(ConfirmationEvent{Status: StatusDropped, Err: fmt.Errorf("%w %s", ErrTransactionDropped, w.hash)})
+			return true
+		}
+		return false
+	}
+
+	if w.receipt != nil && w.receipt.BlockHash != receipt.BlockHash {
+		w.reorged(emit)
+	}
+	w.lastSeen = time.Now()
+
+	// the node may still serve the receipt of a block which left the chain
+	header, err := w.core.GetHeaderByNumberCtx(ctx, block.NUMBER(receipt.BlockNumber))
+	if err != nil {
+		return false
+	}
+	if header.Hash != receipt.BlockHash {
+		if w.receipt != nil {
+			w.reorged(emit)
+		}
+		return false
+	}
+
+	head, err := w.core.GetBlockNumberCtx(ctx)
+	if err != nil {
+		return false
+	}
+
+	var confirmations uint64
+	if head.Cmp(receipt.BlockNumber) > 0 {
+		confirmations = head.Uint64() - receipt.BlockNumber.Uint64()
+	}
+
+	first := w.receipt == nil
+	w.receipt = receipt
+	w.pending = false
+
+	if confirmations >= w.opts.Confirmations {
+		emit(ConfirmationEvent{Status: StatusConfirmed, Receipt: receipt, Confirmations: confirmations})
+		return true
+	}
+	if first || confirmations != w.notified {
+		w.notified = confirmations
+		emit(ConfirmationEvent{Status: StatusMined, Receipt: receipt, Confirmations: confirmations})
+	}
+	return false
+}
+
+// reorged reports that the block of the last receipt left the chain
+func (w *txWatcher) reorged(emit func(ConfirmationEvent)) {
+	emit(ConfirmationEvent{Status: StatusReorged, Receipt: w.receipt})
+	w.receipt = nil
+	w.pending = false
+	w.notified = 0
+	w.lastSeen = time.Now()
+}
@@ -15,18 +15,80 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	Abi "github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/crypto/secp"
 	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
 	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"github.com/tchain/go-tchain-sdk/utils/types"
 	"strings"
 )
 
+// ErrNoCode is returned by a call which gets no output while the method has
+// outputs, usually because there's no contract at the recipient
+var ErrNoCode = errors.New("no contract code at given address")
+
+// ErrDeployFailed is returned with the receipt of a deployment whose
+// transaction failed
+var ErrDeployFailed = errors.New("contract deployment failed")
+
+// CallOpts are the options of CallInto and CallValues
+type CallOpts struct {
+	Transaction *dto.TransactionParameters // the sender, the contract as recipient and the chain id of the call, by default the bound address and the chain id of the node
+	Block       string                     // block.NUMBER(n) or a tag, block.LATEST when empty
+}
+
+// RevertError is returned by a call reverted by the contract, errors.Is
+// matches it with providers.ErrExecutionReverted
+type RevertError struct {
+	Reason string // the reason given to revert or require, empty without one
+	Data   []byte // the revert data returned by the contract, if the node returned it
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
+
+// Is makes errors.Is(err, providers.ErrExecutionReverted) hold
+func (e *RevertError) Is(target error) bool {
+	return target == providers.ErrExecutionReverted
+}
+
+// revertError converts the error of a call reverted by the contract into a
+// *RevertError, the other errors are returned as they are
+func revertError(err error) error {
+	var rpcErr *providers.RPCError
+	if !errors.As(err, &rpcErr) || !errors.Is(rpcErr, providers.ErrExecutionReverted) {
+		return err
+	}
+
+	revert := &RevertError{}
+	if data, ok := rpcErr.Data.(string); ok {
+		revert.Data, _ = hexutil.Decode(data)
+	}
+	if reason, err := Abi.UnpackRevert(revert.Data); err == nil {
+		revert.Reason = reason
+	} else if i := strings.Index(rpcErr.Message, "execution reverted: "); i >= 0 {
+		revert.Reason = rpcErr.Message[i+len("execution reverted: "):]
+	}
+	return revert
+}
+
 // Contract ...
 type Contract struct {
-	super *Core
-	abi   Abi.ABI
+	super   *Core
+	abi     Abi.ABI
+	builder *TxBuilder
+	address string // the address the contract is bound to, empty when unbound
 }
 
 // NewContract - Contract abstraction
@@ -42,35 +104,184 @@ func (core *Core) NewContract(abi string) (*Contract, error) {
 	return contract, nil
 }
 
+// At returns a copy of the contract bound to address, in did:bid form, it's
+// the default recipient of the calls and address of the event queries
+func (contract *Contract) At(address string) *Contract {
+	bound := *contract
+	bound.address = address
+	return &bound
+}
+
+// Address returns the address the contract is bound to, empty when unbound
+func (contract *Contract) Address() string {
+	return contract.address
+}
+
+// SetTxBuilder makes Send and Deploy fill the missing fields of the
+// transactions with builder, nil signs the transactions as they are
+func (contract *Contract) SetTxBuilder(builder *TxBuilder) {
+	contract.builder = builder
+}
+
 func (contract *Contract) Call(transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*dto.RequestResult, error) {
+	return contract.CallCtx(context.Background(), transaction, functionName, args...)
+}
+
+// CallCtx is like Call but honours the cancellation and deadline of ctx.
+func (contract *Contract) CallCtx(ctx context.Context, transaction *dto.TransactionParameters, functionName string, args ...interface{}) (*dto.RequestResult, error) {
 	inputEncode, err := contract.abi.Pack(functionName, args...)
 	if err != nil {
 		return nil, err
 	}
 	transaction.Payload = types.ComplexString("0x" + utils.Bytes2Hex(inputEncode))
 
-	return contract.super.Call(transaction)
+	return contract.super.CallCtx(ctx, transaction)
 
 }
 
-func (contract *Contract) Send(tx *account.SignTxParams, isSM2 bool, signPriKey, functionName string, args ...interface{}) (string, error) {
+/*
+  CallInto:
+   	EN - Calls a constant method and decodes its outputs into out, a pointer to the single output, to a struct with a field per output or to a []interface{} of pointers to the outputs
+ 	CN - 调用合约的只读方法，并按方法的Outputs将返回值解码到out中
+  Params:
+  	- out, interface{}, 单个返回值的指针、每个返回值对应一个字段的结构体指针或返回值指针组成的[]interface{}的指针
+  	- opts, *CallOpts, 调用参数（交易及区块）
+  	- functionName, string, 方法名
+  	- args, ...interface{}, 方法参数
+
+  Returns:
+ 	- error, 调用被合约revert时返回*RevertError，其中包含解码后的revert原因
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) CallInto(out interface{}, opts *CallOpts, functionName string, args ...interface{}) error {
+	return contract.CallIntoCtx(context.Background(), out, opts, functionName, args...)
+}
+
+// CallIntoCtx is like CallInto but honours the cancellation and deadline of ctx.
+func (contract *Contract) CallIntoCtx(ctx context.Context, out interface{}, opts *CallOpts, functionName string, args ...interface{}) error {
+	output, err := contract.call(ctx, opts, functionName, args...)
+	if err != nil {
+		return err
+	}
+	return contract.abi.Unpack(out, functionName, output)
+}
+
+/*
+  CallValues:
+   	EN - Calls a constant method and returns its decoded outputs
+ 	CN - 调用合约的只读方法，返回按方法的Outputs解码后的返回值
+  Params:
+  	- opts, *CallOpts, 调用参数（交易及区块）
+  	- functionName, string, 方法名
+  	- args, ...interface{}, 方法参数
+
+  Returns:
+  	- []interface{}, 按顺序排列的返回值
+ 	- error, 调用被合约revert时返回*RevertError，其中包含解码后的revert原因
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) CallValues(opts *CallOpts, functionName string, args ...interface{}) ([]interface{}, error) {
+	return contract.CallValuesCtx(context.Background(), opts, functionName, args...)
+}
+
+// CallValuesCtx is like CallValues but honours the cancellation and deadline of ctx.
+func (contract *Contract) CallValuesCtx(ctx context.Context, opts *CallOpts, functionName string, args ...interface{}) ([]interface{}, error) {
+	output, err := contract.call(ctx, opts, functionName, args...)
+	if err != nil {
+		return nil, err
+	}
+	return contract.abi.Methods[functionName].Outputs.UnpackValues(output)
+}
+
+// call calls the method and returns its output, the reverted calls are
+// returned as a *RevertError
+func (contract *Contract) call(ctx context.Context, opts *CallOpts, functionName string, args ...interface{}) ([]byte, error) {
+	method, ok := contract.abi.Methods[functionName]
+	if !ok {
+		return nil, fmt.Errorf("method '%s' not found", functionName)
+	}
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	var transaction dto.TransactionParameters
+	switch {
+	case opts.Transaction != nil:
+		transaction = *opts.Transaction
+	case contract.address == "":
+		return nil, errors.New("no call transaction in opts")
+	}
+	if transaction.Recipient == "" {
+		transaction.Recipient = contract.address
+	}
+	if transaction.ChainId == 0 {
+		chainId, err := contract.super.GetChainIdCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		transaction.ChainId = chainId
+	}
+
 	inputEncode, err := contract.abi.Pack(functionName, args...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	transaction.Payload = types.ComplexString("0x" + utils.Bytes2Hex(inputEncode))
 
-	tx.Payload = inputEncode
+	blockNumber := opts.Block
+	if blockNumber == "" {
+		blockNumber = block.LATEST
+	}
 
-	signTx, err := account.SignTransaction(tx, signPriKey, isSM2)
+	res, err := contract.super.CallAtBlockCtx(ctx, &transaction, blockNumber)
+	if err != nil {
+		return nil, revertError(err)
+	}
+	result, err := res.ToString()
+	if err != nil {
+		return nil, err
+	}
+	output, err := hexutil.Decode(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// the outputs are words of 32 bytes, the revert data starts with a selector
+	if len(output)%32 == 4 {
+		if reason, err := Abi.UnpackRevert(output); err == nil {
+			return nil, &RevertError{Reason: reason, Data: output}
+		}
+	}
+	if len(output) == 0 && len(method.Outputs) > 0 {
+		return nil, ErrNoCode
+	}
+	return output, nil
+}
+
+func (contract *Contract) Send(tx *account.SignTxParams, isSM2 bool, signPriKey, functionName string, args ...interface{}) (string, error) {
+	return contract.SendCtx(context.Background(), tx, isSM2, signPriKey, functionName, args...)
+}
+
+// SendCtx is like Send but honours the cancellation and deadline of ctx.
+func (contract *Contract) SendCtx(ctx context.Context, tx *account.SignTxParams, isSM2 bool, signPriKey, functionName string, args ...interface{}) (string, error) {
+	inputEncode, err := contract.abi.Pack(functionName, args...)
 	if err != nil {
 		return "", err
 	}
 
-	return contract.super.SendRawTransaction(signTx.Raw.String())
+	tx.Payload = inputEncode
+
+	return contract.signAndSend(ctx, tx, isSM2, signPriKey)
 
 }
 
 func (contract *Contract) Deploy(tx *account.SignTxParams, isSM2 bool, signPriKey, byteCode string, args ...interface{}) (string, error) {
+	return contract.DeployCtx(context.Background(), tx, isSM2, signPriKey, byteCode, args...)
+}
+
+// DeployCtx is like Deploy but honours the cancellation and deadline of ctx.
+func (contract *Contract) DeployCtx(ctx context.Context, tx *account.SignTxParams, isSM2 bool, signPriKey, byteCode string, args ...interface{}) (string, error) {
 	inputEncode, err := contract.abi.Pack("", args...)
 	if err != nil {
 		return "", err
@@ -78,11 +289,105 @@ func (contract *Contract) Deploy(tx *account.SignTxParams, isSM2 bool, signPriKe
 
 	tx.Payload = append(utils.Hex2Bytes(byteCode), inputEncode...)
 
+	return contract.signAndSend(ctx, tx, isSM2, signPriKey)
+}
+
+/*
+  DeployAndWait:
+   	EN - Deploys a contract and waits for its transaction to be mined, it returns the address of the contract, the receipt and the contract bound to the address
+ 	CN - 部署合约并等待交易上链，返回合约地址、交易收据及绑定该地址的合约
+  Params:
+  	- ctx, context.Context, 超时或取消等待
+  	- tx, *account.SignTxParams, 部署交易参数
+  	- isSM2, bool, 私钥是否为SM2私钥
+  	- signPriKey, string, 签名私钥
+  	- byteCode, string, 合约字节码
+  	- opts, *WaitOptions, 等待选项，为nil时使用默认值
+  	- args, ...interface{}, 构造函数参数
+
+  Returns:
+  	- string, 合约地址（did:bid）
+  	- *dto.TransactionReceipt, 交易收据
+  	- *Contract, 绑定合约地址的合约
+ 	- error, 交易执行失败时返回ErrDeployFailed及交易收据
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) DeployAndWait(ctx context.Context, tx *account.SignTxParams, isSM2 bool, signPriKey, byteCode string, opts *WaitOptions, args ...interface{}) (string, *dto.TransactionReceipt, *Contract, error) {
+	hash, err := contract.DeployCtx(ctx, tx, isSM2, signPriKey, byteCode, args...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	receipt, err := contract.super.WaitMined(ctx, hash, opts)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if !receipt.Status {
+		return "", receipt, nil, ErrDeployFailed
+	}
+	if receipt.ContractAddress == "" {
+		return "", receipt, nil, fmt.Errorf("no contract address in the receipt of %s", hash)
+	}
+	return receipt.ContractAddress, receipt, contract.At(receipt.ContractAddress), nil
+}
+
+/*
+  PredictContractAddress:
+   	EN - Returns the address of the contract deployed by sender with nonce, it's known before the deployment is sent
+ 	CN - 根据部署者地址及nonce计算合约地址，可在发送部署交易前得到
+  Params:
+  	- sender, string, 部署者地址（did:bid）
+  	- nonce, uint64, 部署交易的nonce
+  	- chainCode, string, 返回地址的链码，可为空
+
+  Returns:
+  	- string, 合约地址（did:bid）
+ 	- error
+
+  Call permissions: Anyone
+*/
+func PredictContractAddress(sender string, nonce uint64, chainCode string) (string, error) {
+	address := utils.StringToAddress(sender)
+	if address == (utils.Address{}) {
+		return "", fmt.Errorf("invalid sender address %q", sender)
+	}
+	return secp.CreateAddress(address, nonce).String(chainCode), nil
+}
+
+/*
+  PredictContractAddress2:
+   	EN - Returns the address of the contract created by sender with CREATE2, from the salt and the init code of the contract (its bytecode followed by the encoded constructor arguments)
+ 	CN - 根据部署者地址、salt及合约初始化代码（字节码及编码后的构造函数参数）计算CREATE2创建的合约地址
+  Params:
+  	- sender, string, 执行CREATE2的合约地址（did:bid）
+  	- salt, [32]byte, salt
+  	- initCode, []byte, 合约初始化代码
+  	- chainCode, string, 返回地址的链码，可为空
+
+  Returns:
+  	- string, 合约地址（did:bid）
+ 	- error
+
+  Call permissions: Anyone
+*/
+func PredictContractAddress2(sender string, salt [32]byte, initCode []byte, chainCode string) (string, error) {
+	address := utils.StringToAddress(sender)
+	if address == (utils.Address{}) {
+		return "", fmt.Errorf("invalid sender address %q", sender)
+	}
+	return secp.CreateAddress2(address, salt, secp.Keccak256Btc(initCode)).String(chainCode), nil
+}
+
+func (contract *Contract) signAndSend(ctx context.Context, tx *account.SignTxParams, isSM2 bool, signPriKey string) (string, error) {
+	if contract.builder != nil {
+		return contract.builder.SignAndSend(ctx, tx, signPriKey, isSM2)
+	}
+
 	signTx, err := account.SignTransaction(tx, signPriKey, isSM2)
 	if err != nil {
 		return "", err
 	}
 
-	return contract.super.SendRawTransaction(signTx.Raw.String())
+	return contract.super.SendRawTransactionCtx(ctx, signTx.Raw.String())
 }
-
@@ -15,6 +15,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk/core/block"
@@ -57,10 +58,15 @@ func (core *Core) Contract(jsonInterface string) (*Contract, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetProtocolVersion() (uint64, error) {
+	return core.GetProtocolVersionCtx(context.Background())
+}
+
+// GetProtocolVersionCtx is like GetProtocolVersion but honours the cancellation and deadline of ctx.
+func (core *Core) GetProtocolVersionCtx(ctx context.Context) (uint64, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_protocolVersion", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_protocolVersion", nil)
 
 	if err != nil {
 		return 0, err
@@ -87,10 +93,15 @@ func (core *Core) GetProtocolVersion() (uint64, error) {
   Call permissions: Anyone
 */
 func (core *Core) IsSyncing() (*dto.SyncingResponse, error) {
+	return core.IsSyncingCtx(context.Background())
+}
+
+// IsSyncingCtx is like IsSyncing but honours the cancellation and deadline of ctx.
+func (core *Core) IsSyncingCtx(ctx context.Context) (*dto.SyncingResponse, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_syncing", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_syncing", nil)
 
 	if err != nil {
 		return nil, err
@@ -114,10 +125,15 @@ func (core *Core) IsSyncing() (*dto.SyncingResponse, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetGenerator() (string, error) {
+	return core.GetGeneratorCtx(context.Background())
+}
+
+// GetGeneratorCtx is like GetGenerator but honours the cancellation and deadline of ctx.
+func (core *Core) GetGeneratorCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_generator", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_generator", nil)
 
 	if err != nil {
 		return "", err
@@ -141,10 +157,15 @@ func (core *Core) GetGenerator() (string, error) {
   Call permissions: Anyone
 */
 func (core *Core) Generating() (bool, error) {
+	return core.GeneratingCtx(context.Background())
+}
+
+// GeneratingCtx is like Generating but honours the cancellation and deadline of ctx.
+func (core *Core) GeneratingCtx(ctx context.Context) (bool, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_generating", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_generating", nil)
 
 	if err != nil {
 		return false, err
@@ -169,10 +190,15 @@ func (core *Core) Generating() (bool, error) {
 
 */
 func (core *Core) GetHashRate() (*big.Int, error) {
+	return core.GetHashRateCtx(context.Background())
+}
+
+// GetHashRateCtx is like GetHashRate but honours the cancellation and deadline of ctx.
+func (core *Core) GetHashRateCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_hashrate", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_hashrate", nil)
 
 	if err != nil {
 		return nil, err
@@ -195,10 +221,15 @@ func (core *Core) GetHashRate() (*big.Int, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetGasPrice() (*big.Int, error) {
+	return core.GetGasPriceCtx(context.Background())
+}
+
+// GetGasPriceCtx is like GetGasPrice but honours the cancellation and deadline of ctx.
+func (core *Core) GetGasPriceCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_gasPrice", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_gasPrice", nil)
 
 	if err != nil {
 		return nil, err
@@ -221,10 +252,15 @@ func (core *Core) GetGasPrice() (*big.Int, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetAccounts() ([]string, error) {
+	return core.GetAccountsCtx(context.Background())
+}
+
+// GetAccountsCtx is like GetAccounts but honours the cancellation and deadline of ctx.
+func (core *Core) GetAccountsCtx(ctx context.Context) ([]string, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_accounts", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_accounts", nil)
 
 	if err != nil {
 		return nil, err
@@ -248,10 +284,15 @@ func (core *Core) GetAccounts() ([]string, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetBlockNumber() (*big.Int, error) {
+	return core.GetBlockNumberCtx(context.Background())
+}
+
+// GetBlockNumberCtx is like GetBlockNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockNumberCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_blockNumber", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_blockNumber", nil)
 
 	if err != nil {
 		return nil, err
@@ -278,6 +319,11 @@ func (core *Core) GetBlockNumber() (*big.Int, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetBalance(address string, blockNumber string) (*big.Int, error) {
+	return core.GetBalanceCtx(context.Background(), address, blockNumber)
+}
+
+// GetBalanceCtx is like GetBalance but honours the cancellation and deadline of ctx.
+func (core *Core) GetBalanceCtx(ctx context.Context, address string, blockNumber string) (*big.Int, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -285,7 +331,7 @@ func (core *Core) GetBalance(address string, blockNumber string) (*big.Int, erro
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getBalance", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getBalance", params)
 
 	if err != nil {
 		return nil, err
@@ -312,6 +358,11 @@ func (core *Core) GetBalance(address string, blockNumber string) (*big.Int, erro
   Call permissions: Anyone
 */
 func (core *Core) GetTransactionCount(address string, blockNumber string) (*big.Int, error) {
+	return core.GetTransactionCountCtx(context.Background(), address, blockNumber)
+}
+
+// GetTransactionCountCtx is like GetTransactionCount but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionCountCtx(ctx context.Context, address string, blockNumber string) (*big.Int, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -319,7 +370,7 @@ func (core *Core) GetTransactionCount(address string, blockNumber string) (*big.
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getTransactionCount", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getTransactionCount", params)
 
 	if err != nil {
 		return nil, err
@@ -343,6 +394,11 @@ func (core *Core) GetTransactionCount(address string, blockNumber string) (*big.
   Call permissions: Anyone
 */
 func (core *Core) EstimateGas(transaction *dto.TransactionParameters) (*big.Int, error) {
+	return core.EstimateGasCtx(context.Background(), transaction)
+}
+
+// EstimateGasCtx is like EstimateGas but honours the cancellation and deadline of ctx.
+func (core *Core) EstimateGasCtx(ctx context.Context, transaction *dto.TransactionParameters) (*big.Int, error) {
 	if transaction.ChainId == 0 {
 		return nil, errors.New("chainId can't be zero")
 	}
@@ -353,7 +409,7 @@ func (core *Core) EstimateGas(transaction *dto.TransactionParameters) (*big.Int,
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(&pointer, "core_estimateGas", params)
+	err := core.provider.SendRequestContext(ctx, &pointer, "core_estimateGas", params)
 
 	if err != nil {
 		return nil, err
@@ -364,6 +420,11 @@ func (core *Core) EstimateGas(transaction *dto.TransactionParameters) (*big.Int,
 
 // Deprecated: This operation is not supported
 func (core *Core) SendTransaction(transaction *dto.TransactionParameters) (string, error) {
+	return core.SendTransactionCtx(context.Background(), transaction)
+}
+
+// SendTransactionCtx is like SendTransaction but honours the cancellation and deadline of ctx.
+func (core *Core) SendTransactionCtx(ctx context.Context, transaction *dto.TransactionParameters) (string, error) {
 	if transaction.ChainId == 0 {
 		return "", errors.New("chainId can't be zero")
 	}
@@ -373,7 +434,7 @@ func (core *Core) SendTransaction(transaction *dto.TransactionParameters) (strin
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(&pointer, "core_sendTransaction", params)
+	err := core.provider.SendRequestContext(ctx, &pointer, "core_sendTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -397,13 +458,18 @@ func (core *Core) SendTransaction(transaction *dto.TransactionParameters) (strin
   Call permissions: Anyone
 */
 func (core *Core) SendRawTransaction(encodedTx string) (string, error) {
+	return core.SendRawTransactionCtx(context.Background(), encodedTx)
+}
+
+// SendRawTransactionCtx is like SendRawTransaction but honours the cancellation and deadline of ctx.
+func (core *Core) SendRawTransactionCtx(ctx context.Context, encodedTx string) (string, error) {
 
 	params := make([]string, 1)
 	params[0] = encodedTx
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(&pointer, "core_sendRawTransaction", params)
+	err := core.provider.SendRequestContext(ctx, &pointer, "core_sendRawTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -447,6 +513,11 @@ func (core *Core) SendRawTransaction(encodedTx string) (string, error) {
 
 */
 func (core *Core) SignTransaction(transaction *dto.TransactionParameters) (*dto.SignTransactionResponse, error) {
+	return core.SignTransactionCtx(context.Background(), transaction)
+}
+
+// SignTransactionCtx is like SignTransaction but honours the cancellation and deadline of ctx.
+func (core *Core) SignTransactionCtx(ctx context.Context, transaction *dto.TransactionParameters) (*dto.SignTransactionResponse, error) {
 	if transaction.ChainId == 0 {
 		return nil, errors.New("chainId can't be zero")
 	}
@@ -456,7 +527,7 @@ func (core *Core) SignTransaction(transaction *dto.TransactionParameters) (*dto.
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(&pointer, "core_signTransaction", params)
+	err := core.provider.SendRequestContext(ctx, &pointer, "core_signTransaction", params)
 
 	if err != nil {
 		return &dto.SignTransactionResponse{}, err
@@ -487,17 +558,45 @@ func (core *Core) SignTransaction(transaction *dto.TransactionParameters) (*dto.
   Bug 待测试，需要比对rpc中的callArgs和sendTxArgs！！！！！，数据结构
 */
 func (core *Core) Call(transaction *dto.TransactionParameters) (*dto.RequestResult, error) {
+	return core.CallCtx(context.Background(), transaction)
+}
+
+// CallCtx is like Call but honours the cancellation and deadline of ctx.
+func (core *Core) CallCtx(ctx context.Context, transaction *dto.TransactionParameters) (*dto.RequestResult, error) {
+	return core.CallAtBlockCtx(ctx, transaction, block.LATEST)
+}
+
+/*
+  CallAtBlock:
+   	EN - Executes a new message call against the state of the given block, without creating a transaction
+ 	CN - 基于指定区块的状态执行新的消息调用，而无需在区块链上创建交易
+  Params:
+	- transaction，*dto.TransactionParameters，交易Call的对象，同Call
+	- blockNumber，string，区块高度block.NUMBER(n)或"latest"、"earliest"、"pending"
+
+  Returns:
+  	- 已执行合约的返回值
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) CallAtBlock(transaction *dto.TransactionParameters, blockNumber string) (*dto.RequestResult, error) {
+	return core.CallAtBlockCtx(context.Background(), transaction, blockNumber)
+}
+
+// CallAtBlockCtx is like CallAtBlock but honours the cancellation and deadline of ctx.
+func (core *Core) CallAtBlockCtx(ctx context.Context, transaction *dto.TransactionParameters, blockNumber string) (*dto.RequestResult, error) {
 	if transaction.ChainId == 0 {
 		return nil, errors.New("chainId can't be zero")
 	}
 
 	params := make([]interface{}, 2)
 	params[0] = transaction.Transform()
-	params[1] = block.LATEST
+	params[1] = blockNumber
 
 	pointer := &dto.RequestResult{}
 
-	err := core.provider.SendRequest(&pointer, "core_call", params)
+	err := core.provider.SendRequestContext(ctx, &pointer, "core_call", params)
 
 	if err != nil {
 		return nil, err
@@ -533,13 +632,18 @@ func (core *Core) Call(transaction *dto.TransactionParameters) (*dto.RequestResu
   Call permissions: Anyone
 */
 func (core *Core) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, error) {
+	return core.GetTransactionReceiptCtx(context.Background(), hash)
+}
+
+// GetTransactionReceiptCtx is like GetTransactionReceipt but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionReceiptCtx(ctx context.Context, hash string) (*dto.TransactionReceipt, error) {
 
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getTransactionReceipt", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getTransactionReceipt", params)
 
 	if err != nil {
 		return nil, err
@@ -563,6 +667,11 @@ func (core *Core) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, e
   Call permissions: Anyone
 */
 func (core *Core) GetBlockTransactionCountByHash(blockHash string) (uint64, error) {
+	return core.GetBlockTransactionCountByHashCtx(context.Background(), blockHash)
+}
+
+// GetBlockTransactionCountByHashCtx is like GetBlockTransactionCountByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockTransactionCountByHashCtx(ctx context.Context, blockHash string) (uint64, error) {
 	// ensure that the hash is correctly formatted
 	if strings.HasPrefix(blockHash, "0x") {
 		if len(blockHash) != 66 {
@@ -577,7 +686,7 @@ func (core *Core) GetBlockTransactionCountByHash(blockHash string) (uint64, erro
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getBlockTransactionCountByHash", []string{blockHash})
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getBlockTransactionCountByHash", []string{blockHash})
 
 	if err != nil {
 		return 0, err
@@ -603,13 +712,18 @@ func (core *Core) GetBlockTransactionCountByHash(blockHash string) (uint64, erro
   Call permissions: Anyone
 */
 func (core *Core) GetBlockTransactionCountByNumber(blockNumber string) (uint64, error) {
+	return core.GetBlockTransactionCountByNumberCtx(context.Background(), blockNumber)
+}
+
+// GetBlockTransactionCountByNumberCtx is like GetBlockTransactionCountByNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockTransactionCountByNumberCtx(ctx context.Context, blockNumber string) (uint64, error) {
 
 	params := make([]string, 1)
 	params[0] = blockNumber
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getBlockTransactionCountByNumber", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getBlockTransactionCountByNumber", params)
 
 	if err != nil {
 		return 0, err
@@ -631,11 +745,17 @@ func (core *Core) GetBlockTransactionCountByNumber(blockNumber string) (uint64,
 
   Returns:
   	- interface{}, 如果transactionDetails为true，则是*dto.BlockDetails；如果为false，则是*dto.BlockNoDetails
+	  （类型化的结果见GetHeaderByNumber、GetBlockWithHashesByNumber、GetBlockWithTransactionsByNumber）
  	- error
 
   Call permissions: Anyone
 */
 func (core *Core) GetBlockByNumber(blockNumber string, transactionDetails bool) (interface{}, error) {
+	return core.GetBlockByNumberCtx(context.Background(), blockNumber, transactionDetails)
+}
+
+// GetBlockByNumberCtx is like GetBlockByNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockByNumberCtx(ctx context.Context, blockNumber string, transactionDetails bool) (interface{}, error) {
 
 	params := make([]interface{}, 2)
 
@@ -657,7 +777,7 @@ func (core *Core) GetBlockByNumber(blockNumber string, transactionDetails bool)
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getBlockByNumber", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getBlockByNumber", params)
 
 	if err != nil {
 		return nil, err
@@ -676,11 +796,17 @@ func (core *Core) GetBlockByNumber(blockNumber string, transactionDetails bool)
 
   Returns:
   	- interface{}, 如果transactionDetails为true，则是*dto.BlockDetails；如果为false，则是*dto.BlockNoDetails
+	  （类型化的结果见GetHeaderByHash、GetBlockWithHashesByHash、GetBlockWithTransactionsByHash）
  	- error
 
   Call permissions: Anyone
 */
 func (core *Core) GetBlockByHash(blockHash string, transactionDetails bool) (interface{}, error) {
+	return core.GetBlockByHashCtx(context.Background(), blockHash, transactionDetails)
+}
+
+// GetBlockByHashCtx is like GetBlockByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetBlockByHashCtx(ctx context.Context, blockHash string, transactionDetails bool) (interface{}, error) {
 	// ensure that the hash is correctly formatted
 	if strings.HasPrefix(blockHash, "0x") {
 		if len(blockHash) != 66 {
@@ -699,7 +825,7 @@ func (core *Core) GetBlockByHash(blockHash string, transactionDetails bool) (int
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getBlockByHash", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getBlockByHash", params)
 
 	if err != nil {
 		return nil, err
@@ -726,6 +852,11 @@ func (core *Core) GetBlockByHash(blockHash string, transactionDetails bool) (int
   Call permissions: Anyone
 */
 func (core *Core) GetCode(address string, blockNumber string) (string, error) {
+	return core.GetCodeCtx(context.Background(), address, blockNumber)
+}
+
+// GetCodeCtx is like GetCode but honours the cancellation and deadline of ctx.
+func (core *Core) GetCodeCtx(ctx context.Context, address string, blockNumber string) (string, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -733,7 +864,7 @@ func (core *Core) GetCode(address string, blockNumber string) (string, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getCode", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getCode", params)
 
 	if err != nil {
 		return "", err
@@ -756,6 +887,11 @@ func (core *Core) GetCode(address string, blockNumber string) (string, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetTrustNumber(address string) (uint64, error) {
+	return core.GetTrustNumberCtx(context.Background(), address)
+}
+
+// GetTrustNumberCtx is like GetTrustNumber but honours the cancellation and deadline of ctx.
+func (core *Core) GetTrustNumberCtx(ctx context.Context, address string) (uint64, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -763,7 +899,7 @@ func (core *Core) GetTrustNumber(address string) (uint64, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getCanTrust", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getCanTrust", params)
 
 	if err != nil {
 		return 0, err
@@ -786,9 +922,14 @@ func (core *Core) GetTrustNumber(address string) (uint64, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetChainId() (uint64, error) {
+	return core.GetChainIdCtx(context.Background())
+}
+
+// GetChainIdCtx is like GetChainId but honours the cancellation and deadline of ctx.
+func (core *Core) GetChainIdCtx(ctx context.Context) (uint64, error) {
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_chainId", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_chainId", nil)
 
 	if err != nil {
 		return 0, err
@@ -816,6 +957,11 @@ func (core *Core) GetChainId() (uint64, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetProof(address string, storageKeys []string, blockNumber string) (*dto.AccountResult, error) {
+	return core.GetProofCtx(context.Background(), address, storageKeys, blockNumber)
+}
+
+// GetProofCtx is like GetProof but honours the cancellation and deadline of ctx.
+func (core *Core) GetProofCtx(ctx context.Context, address string, storageKeys []string, blockNumber string) (*dto.AccountResult, error) {
 
 	params := make([]interface{}, 3)
 	params[0] = address
@@ -824,7 +970,7 @@ func (core *Core) GetProof(address string, storageKeys []string, blockNumber str
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getProof", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getProof", params)
 	if err != nil {
 		return nil, err
 	}
@@ -851,6 +997,11 @@ func (core *Core) GetProof(address string, storageKeys []string, blockNumber str
   Call permissions: Anyone
 */
 func (core *Core) GetStorageAt(address string, key *big.Int, blockNumber string) (string, error) {
+	return core.GetStorageAtCtx(context.Background(), address, key, blockNumber)
+}
+
+// GetStorageAtCtx is like GetStorageAt but honours the cancellation and deadline of ctx.
+func (core *Core) GetStorageAtCtx(ctx context.Context, address string, key *big.Int, blockNumber string) (string, error) {
 
 	params := make([]string, 3)
 	params[0] = address
@@ -859,7 +1010,7 @@ func (core *Core) GetStorageAt(address string, key *big.Int, blockNumber string)
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getStorageAt", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getStorageAt", params)
 
 	if err != nil {
 		return "", err
@@ -882,10 +1033,15 @@ func (core *Core) GetStorageAt(address string, key *big.Int, blockNumber string)
   Call permissions: Anyone
 */
 func (core *Core) GetPendingTransactions() ([]*dto.TransactionResponse, error) {
+	return core.GetPendingTransactionsCtx(context.Background())
+}
+
+// GetPendingTransactionsCtx is like GetPendingTransactions but honours the cancellation and deadline of ctx.
+func (core *Core) GetPendingTransactionsCtx(ctx context.Context) ([]*dto.TransactionResponse, error) {
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_pendingTransactions", nil)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_pendingTransactions", nil)
 
 	if err != nil {
 		return nil, err
@@ -920,13 +1076,18 @@ func (core *Core) GetPendingTransactions() ([]*dto.TransactionResponse, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetTransactionByHash(hash string) (*dto.TransactionResponse, error) {
+	return core.GetTransactionByHashCtx(context.Background(), hash)
+}
+
+// GetTransactionByHashCtx is like GetTransactionByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionByHashCtx(ctx context.Context, hash string) (*dto.TransactionResponse, error) {
 
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getTransactionByHash", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getTransactionByHash", params)
 
 	if err != nil {
 		return nil, err
@@ -950,13 +1111,18 @@ func (core *Core) GetTransactionByHash(hash string) (*dto.TransactionResponse, e
   Call permissions: Anyone
 */
 func (core *Core) GetRawTransactionByHash(hash string) (string, error) {
+	return core.GetRawTransactionByHashCtx(context.Background(), hash)
+}
+
+// GetRawTransactionByHashCtx is like GetRawTransactionByHash but honours the cancellation and deadline of ctx.
+func (core *Core) GetRawTransactionByHashCtx(ctx context.Context, hash string) (string, error) {
 
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getRawTransactionByHash", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getRawTransactionByHash", params)
 
 	if err != nil {
 		return "", err
@@ -981,6 +1147,11 @@ func (core *Core) GetRawTransactionByHash(hash string) (string, error) {
   Call permissions: Anyone
 */
 func (core *Core) GetTransactionByBlockHashAndIndex(hash string, index uint) (*dto.TransactionResponse, error) {
+	return core.GetTransactionByBlockHashAndIndexCtx(context.Background(), hash, index)
+}
+
+// GetTransactionByBlockHashAndIndexCtx is like GetTransactionByBlockHashAndIndex but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionByBlockHashAndIndexCtx(ctx context.Context, hash string, index uint) (*dto.TransactionResponse, error) {
 
 	// ensure that the hash is correctly formatted
 	if strings.HasPrefix(hash, "0x") {
@@ -1001,7 +1172,7 @@ func (core *Core) GetTransactionByBlockHashAndIndex(hash string, index uint) (*d
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getTransactionByBlockHashAndIndex", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getTransactionByBlockHashAndIndex", params)
 
 	if err != nil {
 		return nil, err
@@ -1025,6 +1196,11 @@ func (core *Core) GetTransactionByBlockHashAndIndex(hash string, index uint) (*d
   Call permissions: Anyone
 */
 func (core *Core) GetRawTransactionByBlockHashAndIndex(hash string, index uint) (string, error) {
+	return core.GetRawTransactionByBlockHashAndIndexCtx(context.Background(), hash, index)
+}
+
+// GetRawTransactionByBlockHashAndIndexCtx is like GetRawTransactionByBlockHashAndIndex but honours the cancellation and deadline of ctx.
+func (core *Core) GetRawTransactionByBlockHashAndIndexCtx(ctx context.Context, hash string, index uint) (string, error) {
 
 	// ensure that the hash is correctly formatted
 	if strings.HasPrefix(hash, "0x") {
@@ -1045,7 +1221,7 @@ func (core *Core) GetRawTransactionByBlockHashAndIndex(hash string, index uint)
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getRawTransactionByBlockHashAndIndex", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getRawTransactionByBlockHashAndIndex", params)
 
 	if err != nil {
 		return "", err
@@ -1072,6 +1248,11 @@ func (core *Core) GetRawTransactionByBlockHashAndIndex(hash string, index uint)
   Call permissions: Anyone
 */
 func (core *Core) GetTransactionByBlockNumberAndIndex(blockNumber string, index uint) (*dto.TransactionResponse, error) {
+	return core.GetTransactionByBlockNumberAndIndexCtx(context.Background(), blockNumber, index)
+}
+
+// GetTransactionByBlockNumberAndIndexCtx is like GetTransactionByBlockNumberAndIndex but honours the cancellation and deadline of ctx.
+func (core *Core) GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNumber string, index uint) (*dto.TransactionResponse, error) {
 
 	params := make([]string, 2)
 	params[0] = blockNumber
@@ -1079,7 +1260,7 @@ func (core *Core) GetTransactionByBlockNumberAndIndex(blockNumber string, index
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getTransactionByBlockNumberAndIndex", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getTransactionByBlockNumberAndIndex", params)
 
 	if err != nil {
 		return nil, err
@@ -1107,6 +1288,11 @@ func (core *Core) GetTransactionByBlockNumberAndIndex(blockNumber string, index
   Call permissions: Anyone
 */
 func (core *Core) GetRawTransactionByBlockNumberAndIndex(blockNumber string, index uint) (string, error) {
+	return core.GetRawTransactionByBlockNumberAndIndexCtx(context.Background(), blockNumber, index)
+}
+
+// GetRawTransactionByBlockNumberAndIndexCtx is like GetRawTransactionByBlockNumberAndIndex but honours the cancellation and deadline of ctx.
+func (core *Core) GetRawTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNumber string, index uint) (string, error) {
 
 	params := make([]string, 2)
 	params[0] = blockNumber
@@ -1114,7 +1300,7 @@ func (core *Core) GetRawTransactionByBlockNumberAndIndex(blockNumber string, ind
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := core.provider.SendRequest(pointer, "core_getRawTransactionByBlockNumberAndIndex", params)
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getRawTransactionByBlockNumberAndIndex", params)
 
 	if err != nil {
 		return "", err
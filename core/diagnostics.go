@@ -0,0 +1,287 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	Abi "github.com/tchain/go-tchain-sdk/abi"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/types"
+)
+
+// ErrTransactionSucceeded is returned by the diagnosis of a transaction whose
+// receipt has a successful status
+var ErrTransactionSucceeded = errors.New("transaction succeeded")
+
+// FailureKind is the cause of the failure of a transaction
+type FailureKind int
+
+const (
+	// FailureUnknown - the replay doesn't explain the failure, e.g. the state
+	// changed by the transactions before it in its block
+	FailureUnknown FailureKind = iota
+	// FailureRevert - revert or require, with or without a reason
+	FailureRevert
+	// FailurePanic - Panic(uint256) of solidity v0.8.0, e.g. a failed assert
+	// or an arithmetic overflow
+	FailurePanic
+	// FailureCustomError - a custom error of the ABI of the contract
+	FailureCustomError
+	// FailureOutOfGas - the transaction used all its gas
+	FailureOutOfGas
+)
+
+func (kind FailureKind) String() string {
+	switch kind {
+	case FailureRevert:
+		return "revert"
+	case FailurePanic:
+		return "panic"
+	case FailureCustomError:
+		return "custom error"
+	case FailureOutOfGas:
+		return "out of gas"
+	default:
+		return "unknown"
+	}
+}
+
+// panicReasons are the meanings of the codes of Panic(uint256)
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// ExecutionError explains the failure of a transaction, errors.Is matches
+// the reverts, panics and custom errors with providers.ErrExecutionReverted
+type ExecutionError struct {
+	Hash string      // the hash of the transaction
+	Kind FailureKind // the cause of the failure
+
+	Reason    string        // the reason of a revert, the meaning of a panic or the message of the node
+	PanicCode *big.Int      // the code of a panic
+	ErrorName string        // the name of a custom error
+	ErrorArgs []interface{} // the arguments of a custom error
+	Data      []byte        // the revert data of the replay
+
+	GasUsed  uint64 // the gas used by the transaction
+	GasLimit uint64 // the gas limit of the transaction
+}
+
+func (e *ExecutionError) Error() string {
+	var detail string
+	switch e.Kind {
+	case FailureRevert:
+		detail = "execution reverted"
+		if e.Reason != "" {
+			detail += ": " + e.Reason
+		}
+	case FailurePanic:
+		detail = fmt.Sprintf("panic 0x%x: %s", e.PanicCode, e.Reason)
+	case FailureCustomError:
+		args := make([]string, len(e.ErrorArgs))
+		for i, arg := range e.ErrorArgs {
+			if address, ok := arg.(utils.Address); ok {
+				args[i] = address.String("")
+			} else {
+				args[i] = fmt.Sprint(arg)
+			}
+		}
+		detail = fmt.Sprintf("execution reverted: %s(%s)", e.ErrorName, strings.Join(args, ", "))
+	case FailureOutOfGas:
+		detail = fmt.Sprintf("out of gas, used %d of %d", e.GasUsed, e.GasLimit)
+	default:
+		detail = "unknown failure"
+		if e.Reason != "" {
+			detail += ": " + e.Reason
+		}
+	}
+	return fmt.Sprintf("transaction %s failed: %s", e.Hash, detail)
+}
+
+// Is makes errors.Is(err, providers.ErrExecutionReverted) hold for the reverts
+func (e *ExecutionError) Is(target error) bool {
+	switch e.Kind {
+	case FailureRevert, FailurePanic, FailureCustomError:
+		return target == providers.ErrExecutionReverted
+	}
+	return false
+}
+
+/*
+  DiagnoseTransaction:
+   	EN - Explains the failure of a mined transaction, it's replayed with core_call on the state of its parent block and its revert data is decoded as Error(string) or Panic(uint256)
+ 	CN - 分析已上链交易的失败原因，在父区块状态上通过core_call重放交易，并将revert数据解码为Error(string)或Panic(uint256)
+  Params:
+  	- hash, string, 交易哈希
+
+  Returns:
+  	- *ExecutionError, 失败原因（revert、panic、out of gas或未知）
+ 	- error, 交易执行成功时返回ErrTransactionSucceeded
+
+  Call permissions: Anyone
+*/
+func (core *Core) DiagnoseTransaction(hash string) (*ExecutionError, error) {
+	return core.DiagnoseTransactionCtx(context.Background(), hash)
+}
+
+// DiagnoseTransactionCtx is like DiagnoseTransaction but honours the cancellation and deadline of ctx.
+func (core *Core) DiagnoseTransactionCtx(ctx context.Context, hash string) (*ExecutionError, error) {
+	return core.diagnose(ctx, hash, nil)
+}
+
+/*
+  DiagnoseTransaction:
+   	EN - Explains the failure of a mined transaction like Core.DiagnoseTransaction, the revert data is also decoded as the custom errors of the ABI of the contract
+ 	CN - 同Core.DiagnoseTransaction，并按合约ABI中的自定义错误解码revert数据
+  Params:
+  	- hash, string, 交易哈希
+
+  Returns:
+  	- *ExecutionError, 失败原因（revert、panic、自定义错误、out of gas或未知）
+ 	- error, 交易执行成功时返回ErrTransactionSucceeded
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) DiagnoseTransaction(hash string) (*ExecutionError, error) {
+	return contract.DiagnoseTransactionCtx(context.Background(), hash)
+}
+
+// DiagnoseTransactionCtx is like DiagnoseTransaction but honours the cancellation and deadline of ctx.
+func (contract *Contract) DiagnoseTransactionCtx(ctx context.Context, hash string) (*ExecutionError, error) {
+	return contract.super.diagnose(ctx, hash, &contract.abi)
+}
+
+// diagnose replays the failed transaction hash and decodes its revert data,
+// the custom errors are decoded with contractAbi when it isn't nil
+func (core *Core) diagnose(ctx context.Context, hash string, contractAbi *Abi.ABI) (*ExecutionError, error) {
+	receipt, err := core.GetTransactionReceiptCtx(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status {
+		return nil, ErrTransactionSucceeded
+	}
+	tx, err := core.GetTransactionByHashCtx(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	failure := &ExecutionError{Hash: hash, GasUsed: receipt.GasUsed, GasLimit: tx.Gas}
+
+	// the transaction is replayed on the state before its block, the
+	// transactions before it in the block are ignored
+	parent := block.EARLIEST
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		parent = block.NUMBER(new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	}
+	replay := &dto.TransactionParameters{
+		ChainId:   tx.ChainId,
+		Sender:    tx.Sender,
+		Recipient: tx.Recipient,
+		GasLimit:  tx.Gas,
+		GasPrice:  tx.GasPrice,
+		Amount:    tx.Amount,
+		Payload:   types.ComplexString(tx.Payload),
+	}
+
+	var reverted bool
+	res, err := core.CallAtBlockCtx(ctx, replay, parent)
+	if err != nil {
+		var rpcErr *providers.RPCError
+		if !errors.As(err, &rpcErr) {
+			return nil, err
+		}
+		reverted = errors.Is(rpcErr, providers.ErrExecutionReverted)
+		if data, ok := rpcErr.Data.(string); ok {
+			failure.Data, _ = hexutil.Decode(data)
+		}
+		failure.Reason = rpcErr.Message
+	} else if output, err := res.ToString(); err == nil {
+		// some nodes return the revert data as the output of the call
+		if data, err := hexutil.Decode(output); err == nil && len(data)%32 == 4 {
+			failure.Data = data
+		}
+	}
+
+	if decodeFailure(failure, contractAbi) {
+		return failure, nil
+	}
+	switch {
+	case failure.GasLimit > 0 && failure.GasUsed >= failure.GasLimit:
+		failure.Kind = FailureOutOfGas
+		failure.Reason = ""
+	case reverted:
+		failure.Kind = FailureRevert
+		failure.Reason = strings.TrimPrefix(strings.TrimPrefix(failure.Reason, "execution reverted"), ": ")
+	}
+	return failure, nil
+}
+
+// decodeFailure decodes the revert data of failure as Error(string),
+// Panic(uint256) or a custom error of contractAbi, it reports whether it's
+// decoded
+func decodeFailure(failure *ExecutionError, contractAbi *Abi.ABI) bool {
+	if len(failure.Data) < 4 {
+		return false
+	}
+	if reason, err := Abi.UnpackRevert(failure.Data); err == nil {
+		failure.Kind, failure.Reason = FailureRevert, reason
+		return true
+	}
+	if code, err := Abi.UnpackPanic(failure.Data); err == nil {
+		failure.Kind, failure.PanicCode = FailurePanic, code
+		failure.Reason = "unknown panic code"
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				failure.Reason = reason
+			}
+		}
+		return true
+	}
+	if contractAbi == nil {
+		return false
+	}
+	var selector [4]byte
+	copy(selector[:], failure.Data)
+	custom, err := contractAbi.ErrorByID(selector)
+	if err != nil {
+		return false
+	}
+	args, err := custom.Unpack(failure.Data)
+	if err != nil {
+		return false
+	}
+	failure.Kind, failure.Reason = FailureCustomError, ""
+	failure.ErrorName, failure.ErrorArgs = custom.Name, args
+	return true
+}
@@ -0,0 +1,250 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	Abi "github.com/tchain/go-tchain-sdk/abi"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils"
+)
+
+// Event is a log of an event of a contract with its decoded arguments
+type Event struct {
+	Name string                 // the name of the event in the ABI
+	Args map[string]interface{} // the arguments by name, the addresses are utils.Address and the indexed dynamic types their hash
+	Log  dto.TransactionLogs    // the raw log
+}
+
+/*
+  FilterEvents:
+   	EN - Returns the past logs of an event matching query and the values of its indexed arguments, decoded with the ABI of the contract
+ 	CN - 返回符合query条件及索引参数取值的历史事件日志，并按合约ABI解码
+  Params:
+  	- eventName, string, 事件名
+  	- query, dto.FilterQuery, 日志过滤条件（区块范围或区块哈希及合约地址，默认为合约绑定的地址），其中的Topics由事件及索引参数生成
+  	- indexedArgs, ...[]interface{}, 按顺序排列的每个索引参数的可选值，nil表示任意值，地址可为did:bid字符串或utils.Address
+
+  Returns:
+  	- []*Event, 解码后的事件
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) FilterEvents(eventName string, query dto.FilterQuery, indexedArgs ...[]interface{}) ([]*Event, error) {
+	return contract.FilterEventsCtx(context.Background(), eventName, query, indexedArgs...)
+}
+
+// FilterEventsCtx is like FilterEvents but honours the cancellation and deadline of ctx.
+func (contract *Contract) FilterEventsCtx(ctx context.Context, eventName string, query dto.FilterQuery, indexedArgs ...[]interface{}) ([]*Event, error) {
+	event, ok := contract.abi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event '%s' not found", eventName)
+	}
+	topics, err := eventTopics(event, indexedArgs)
+	if err != nil {
+		return nil, err
+	}
+	query.Topics = topics
+	if len(query.Addresses) == 0 && contract.address != "" {
+		query.Addresses = []string{contract.address}
+	}
+
+	logs, err := contract.super.GetLogsCtx(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, len(logs))
+	for i, log := range logs {
+		if events[i], err = decodeEvent(event, log); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+/*
+  WatchEvents:
+   	EN - Subscribes to the new logs of an event matching query and the values of its indexed arguments, each decoded event is sent to ch until ctx is done or the subscription fails, the subscription is then unsubscribed
+ 	CN - 订阅符合query条件及索引参数取值的新事件日志，解码后发送到ch，直到ctx结束或订阅失败后取消订阅
+  Params:
+  	- ctx, context.Context, 订阅的生命周期
+  	- eventName, string, 事件名
+  	- query, dto.FilterQuery, 日志过滤条件（合约地址，默认为合约绑定的地址），其中的Topics由事件及索引参数生成
+  	- ch, chan<- *Event, 接收事件的通道
+  	- indexedArgs, ...[]interface{}, 按顺序排列的每个索引参数的可选值，nil表示任意值，地址可为did:bid字符串或utils.Address
+
+  Returns:
+  	- *providers.Subscription, 订阅对象，通过Err()获取订阅错误，通过Unsubscribe()取消订阅
+ 	- error, provider不支持订阅（如HTTP）时返回providers.ErrNotificationsUnsupported
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) WatchEvents(ctx context.Context, eventName string, query dto.FilterQuery, ch chan<- *Event, indexedArgs ...[]interface{}) (*providers.Subscription, error) {
+	event, ok := contract.abi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event '%s' not found", eventName)
+	}
+	topics, err := eventTopics(event, indexedArgs)
+	if err != nil {
+		return nil, err
+	}
+	query.Topics = topics
+	if len(query.Addresses) == 0 && contract.address != "" {
+		query.Addresses = []string{contract.address}
+	}
+
+	logs := make(chan *dto.TransactionLogs, 128)
+	sub, err := contract.super.SubscribeLogs(ctx, query, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// the null notifications and the logs matching the topics of
+				// the event but not its arguments are malformed and dropped
+				if log == nil {
+					continue
+				}
+				decoded, err := decodeEvent(event, *log)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- decoded:
+				case <-sub.Done():
+					return
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Done():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+/*
+  UnpackEvent:
+   	EN - Decodes a log of an event of the contract, the event is found by the first topic of the log
+ 	CN - 按合约ABI解码事件日志，事件由日志的第一个topic确定
+  Params:
+  	- log, dto.TransactionLogs, 事件日志
+
+  Returns:
+  	- *Event, 解码后的事件
+ 	- error, 日志不属于合约的非匿名事件时返回错误
+
+  Call permissions: Anyone
+*/
+func (contract *Contract) UnpackEvent(log dto.TransactionLogs) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, errors.New("log without topics")
+	}
+	event, err := contract.abi.EventByID(utils.HexToHash(log.Topics[0]))
+	if err != nil {
+		return nil, err
+	}
+	return decodeEvent(*event, log)
+}
+
+// eventTopics returns the topics of the logs of event whose indexed
+// arguments take one of the values of indexedArgs, the addresses can be in
+// did:bid form
+func eventTopics(event Abi.Event, indexedArgs [][]interface{}) ([][]string, error) {
+	var indexed Abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexedArgs) > len(indexed) {
+		return nil, fmt.Errorf("event '%s' has %d indexed arguments, got %d", event.Name, len(indexed), len(indexedArgs))
+	}
+
+	query := make([][]interface{}, 0, len(indexedArgs)+1)
+	if !event.Anonymous {
+		query = append(query, []interface{}{event.ID})
+	}
+	for i, values := range indexedArgs {
+		rule := make([]interface{}, len(values))
+		for j, value := range values {
+			// a string would be hashed like a string argument
+			if did, ok := value.(string); ok && indexed[i].Type.T == Abi.AddressTy {
+				address := utils.StringToAddress(did)
+				if address == (utils.Address{}) {
+					return nil, fmt.Errorf("invalid address %q of argument '%s'", did, indexed[i].Name)
+				}
+				value = address
+			}
+			rule[j] = value
+		}
+		query = append(query, rule)
+	}
+
+	hashes, err := Abi.MakeTopics(query...)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([][]string, len(hashes))
+	for i, rule := range hashes {
+		for _, hash := range rule {
+			topics[i] = append(topics[i], hash.Hex())
+		}
+	}
+	return topics, nil
+}
+
+// decodeEvent decodes the arguments of event from log, the indexed ones from
+// its topics and the others from its data
+func decodeEvent(event Abi.Event, log dto.TransactionLogs) (*Event, error) {
+	topics := make([]utils.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = utils.HexToHash(topic)
+	}
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return nil, fmt.Errorf("log isn't an event '%s'", event.Name)
+		}
+		topics = topics[1:]
+	}
+
+	decoded := &Event{Name: event.Name, Args: make(map[string]interface{}), Log: log}
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(decoded.Args, utils.FromHex(log.Data)); err != nil {
+		return nil, err
+	}
+
+	var indexed Abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := Abi.ParseTopicsIntoMap(decoded.Args, indexed, topics); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
@@ -0,0 +1,188 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+
+	"github.com/tchain/go-tchain-sdk/dto"
+)
+
+/*
+  GetLogs:
+   	EN - Returns the logs matching query
+ 	CN - 返回符合query条件的日志
+  Params:
+  	- query, dto.FilterQuery, 日志过滤条件（区块范围或区块哈希、合约地址及topics）
+
+  Returns:
+  	- []dto.TransactionLogs, 日志列表
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetLogs(query dto.FilterQuery) ([]dto.TransactionLogs, error) {
+	return core.GetLogsCtx(context.Background(), query)
+}
+
+// GetLogsCtx is like GetLogs but honours the cancellation and deadline of ctx.
+func (core *Core) GetLogsCtx(ctx context.Context, query dto.FilterQuery) ([]dto.TransactionLogs, error) {
+
+	arg, err := query.ToFilterArg()
+	if err != nil {
+		return nil, err
+	}
+
+	pointer := &dto.CoreRequestResult{}
+
+	err = core.provider.SendRequestContext(ctx, pointer, "core_getLogs", []interface{}{arg})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToTransactionLogs()
+}
+
+/*
+  NewFilter:
+   	EN - Creates a filter on the node to be notified of the new logs matching query, the logs are polled with GetFilterChanges
+ 	CN - 在节点上创建日志过滤器，通过GetFilterChanges获取符合query条件的新日志
+  Params:
+  	- query, dto.FilterQuery, 日志过滤条件（区块范围或区块哈希、合约地址及topics）
+
+  Returns:
+  	- string, 过滤器ID
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) NewFilter(query dto.FilterQuery) (string, error) {
+	return core.NewFilterCtx(context.Background(), query)
+}
+
+// NewFilterCtx is like NewFilter but honours the cancellation and deadline of ctx.
+func (core *Core) NewFilterCtx(ctx context.Context, query dto.FilterQuery) (string, error) {
+
+	arg, err := query.ToFilterArg()
+	if err != nil {
+		return "", err
+	}
+
+	pointer := &dto.CoreRequestResult{}
+
+	err = core.provider.SendRequestContext(ctx, pointer, "core_newFilter", []interface{}{arg})
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+}
+
+/*
+  NewBlockFilter:
+   	EN - Creates a filter on the node to be notified of the new blocks, their hashes are polled with GetFilterChanges
+ 	CN - 在节点上创建区块过滤器，通过GetFilterChanges获取新区块的哈希
+  Params:
+  	- None
+
+  Returns:
+  	- string, 过滤器ID
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) NewBlockFilter() (string, error) {
+	return core.NewBlockFilterCtx(context.Background())
+}
+
+// NewBlockFilterCtx is like NewBlockFilter but honours the cancellation and deadline of ctx.
+func (core *Core) NewBlockFilterCtx(ctx context.Context) (string, error) {
+
+	pointer := &dto.CoreRequestResult{}
+
+	err := core.provider.SendRequestContext(ctx, pointer, "core_newBlockFilter", nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+}
+
+/*
+  GetFilterChanges:
+   	EN - Returns the changes of a filter since it was last polled, the filters which are not polled expire on the node
+ 	CN - 返回过滤器自上次查询以来的变化，长时间未查询的过滤器会在节点上过期
+  Params:
+  	- filterID, string, NewFilter或NewBlockFilter返回的过滤器ID
+
+  Returns:
+  	- *dto.FilterChanges
+	  - Logs   []dto.TransactionLogs 日志过滤器的新日志
+	  - Hashes []string              区块过滤器的新区块哈希
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) GetFilterChanges(filterID string) (*dto.FilterChanges, error) {
+	return core.GetFilterChangesCtx(context.Background(), filterID)
+}
+
+// GetFilterChangesCtx is like GetFilterChanges but honours the cancellation and deadline of ctx.
+func (core *Core) GetFilterChangesCtx(ctx context.Context, filterID string) (*dto.FilterChanges, error) {
+
+	pointer := &dto.CoreRequestResult{}
+
+	err := core.provider.SendRequestContext(ctx, pointer, "core_getFilterChanges", []string{filterID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToFilterChanges()
+}
+
+/*
+  UninstallFilter:
+   	EN - Removes a filter from the node
+ 	CN - 删除节点上的过滤器
+  Params:
+  	- filterID, string, NewFilter或NewBlockFilter返回的过滤器ID
+
+  Returns:
+  	- bool, 过滤器存在并被删除时为true
+ 	- error
+
+  Call permissions: Anyone
+*/
+func (core *Core) UninstallFilter(filterID string) (bool, error) {
+	return core.UninstallFilterCtx(context.Background(), filterID)
+}
+
+// UninstallFilterCtx is like UninstallFilter but honours the cancellation and deadline of ctx.
+func (core *Core) UninstallFilterCtx(ctx context.Context, filterID string) (bool, error) {
+
+	pointer := &dto.CoreRequestResult{}
+
+	err := core.provider.SendRequestContext(ctx, pointer, "core_uninstallFilter", []string{filterID})
+
+	if err != nil {
+		return false, err
+	}
+
+	return pointer.ToBoolean()
+}
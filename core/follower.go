@@ -0,0 +1,478 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+)
+
+const (
+	defaultFollowerConcurrency = 4
+	defaultRollbackDepth       = 128
+)
+
+// ErrRollbackTooDeep is returned by ChainFollower.Run when the chain changed
+// below the blocks it remembers, FollowerOptions.MaxRollbackDepth
+var ErrRollbackTooDeep = errors.New("rollback deeper than the remembered blocks")
+
+// Checkpoint is the last block processed by a ChainFollower
+type Checkpoint struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// CheckpointStore persists the checkpoints of a ChainFollower, which resumes
+// after the saved checkpoint when it's run again
+type CheckpointStore interface {
+	// Load returns the last checkpoint saved, nil when there's none
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Save replaces the checkpoint
+	Save(ctx context.Context, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory
+type MemoryCheckpointStore struct {
+	lock       sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// NewMemoryCheckpointStore creates an empty MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{}
+}
+
+func (store *MemoryCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if store.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *store.checkpoint
+	return &checkpoint, nil
+}
+
+func (store *MemoryCheckpointStore) Save(ctx context.Context, checkpoint Checkpoint) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.checkpoint = &checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a JSON file, which is replaced
+// atomically
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore writing to path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (store *FileCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := new(Checkpoint)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (store *FileCheckpointStore) Save(ctx context.Context, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), store.path)
+}
+
+// FollowerEventType is the type of a FollowerEvent
+type FollowerEventType int
+
+const (
+	// FollowerBlock - a new block of the chain
+	FollowerBlock FollowerEventType = iota
+	// FollowerRollback - blocks delivered before left the chain
+	FollowerRollback
+)
+
+// FollowerEvent is delivered by a ChainFollower to its handler
+type FollowerEvent struct {
+	Type FollowerEventType
+	// Block is the new block of a FollowerBlock event
+	Block *dto.BlockWithTransactions
+	// Receipts are the receipts of the transactions of Block, in the same
+	// order, when FollowerOptions.Receipts is set
+	Receipts []*dto.TransactionReceipt
+	// Removed are the blocks which left the chain on a FollowerRollback
+	// event, the highest first
+	Removed []Checkpoint
+	// Checkpoint is the last block of the chain after the event, the new
+	// block or the common ancestor of the rollback. It's saved once the
+	// handler returns.
+	Checkpoint Checkpoint
+}
+
+// FollowerHandler processes the events of a ChainFollower, an error stops it
+type FollowerHandler func(ctx context.Context, event *FollowerEvent) error
+
+// FollowerOptions configures a ChainFollower, the zero values take the defaults
+type FollowerOptions struct {
+	// From is the first block, when the store has no checkpoint
+	From uint64
+	// To is the last block, nil follows the head until the context ends
+	To *big.Int
+	// Concurrency is the number of blocks fetched in parallel, 4 by default
+	Concurrency int
+	// Confirmations is the distance to the head of the blocks delivered
+	Confirmations uint64
+	// Receipts fetches the receipts of the transactions with the blocks
+	Receipts bool
+	// PollInterval is the interval between two reads of the head, 1 second by default
+	PollInterval time.Duration
+	// Store persists the checkpoints, they're kept in memory when nil
+	Store CheckpointStore
+	// MaxRollbackDepth is the number of blocks remembered to find the common
+	// ancestor of a rollback, 128 by default
+	MaxRollbackDepth int
+}
+
+// ChainFollower delivers the blocks of the chain in order, fetching them
+// concurrently, and reports the rollbacks when the chain of the parent
+// hashes breaks
+type ChainFollower struct {
+	core *Core
+	opts FollowerOptions
+}
+
+/*
+  NewChainFollower:
+   	EN - Creates a follower of the chain from opts.From or the checkpoint of opts.Store
+ 	CN - 创建区块跟踪器，从opts.From或opts.Store中保存的检查点开始按顺序获取区块
+  Params:
+  	- opts, *FollowerOptions, 为nil时使用默认值
+	  - From              uint64           起始区块号，存储中没有检查点时使用
+	  - To                *big.Int         结束区块号，为nil时持续跟踪最新区块
+	  - Concurrency       int              并发获取的区块数，默认4
+	  - Confirmations     uint64           与最新区块的距离（确认数）
+	  - Receipts          bool             是否同时获取交易收据
+	  - PollInterval      time.Duration    查询最新区块的间隔，默认1秒
+	  - Store             CheckpointStore  检查点存储，为nil时保存在内存中
+	  - MaxRollbackDepth  int              回滚时查找共同祖先的最大深度，默认128
+
+  Returns:
+  	- *ChainFollower
+
+  Call permissions: Anyone
+*/
+func (core *Core) NewChainFollower(opts *FollowerOptions) *ChainFollower {
+	follower := &ChainFollower{core: core}
+	if opts != nil {
+		follower.opts = *opts
+	}
+	if follower.opts.Concurrency <= 0 {
+		follower.opts.Concurrency = defaultFollowerConcurrency
+	}
+	if follower.opts.PollInterval <= 0 {
+		follower.opts.PollInterval = defaultPollInterval
+	}
+	if follower.opts.Store == nil {
+		follower.opts.Store = NewMemoryCheckpointStore()
+	}
+	if follower.opts.MaxRollbackDepth <= 0 {
+		follower.opts.MaxRollbackDepth = defaultRollbackDepth
+	}
+	return follower
+}
+
+// Run delivers the events to handler until To is reached, ctx ends or an
+// error occurs. The checkpoint is saved after each event, so the blocks are
+// delivered at least once across the runs.
+func (follower *ChainFollower) Run(ctx context.Context, handler FollowerHandler) error {
+	checkpoint, err := follower.opts.Store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	chain := newFollowedChain(follower.opts.MaxRollbackDepth)
+	next := follower.opts.From
+	if checkpoint != nil {
+		chain.add(*checkpoint)
+		next = checkpoint.Number + 1
+	}
+
+	// the blocks are fetched in batches delivered in order
+	batch := uint64(follower.opts.Concurrency * 4)
+
+	for {
+		if follower.opts.To != nil && new(big.Int).SetUint64(next).Cmp(follower.opts.To) > 0 {
+			return nil
+		}
+
+		target, ok, err := follower.target(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok || next > target {
+			select {
+			case <-time.After(follower.opts.PollInterval):
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		end := target
+		if end-next >= batch {
+			end = next + batch - 1
+		}
+
+		events, err := follower.fetch(ctx, next, end)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			number := event.Block.Number.Uint64()
+			if parent, ok := chain.hash(number - 1); number > 0 && ok && parent != event.Block.ParentHash {
+				rollback, err := follower.rollback(ctx, chain, number-1)
+				if err != nil {
+					return err
+				}
+				if len(rollback.Removed) > 0 {
+					if err := follower.deliver(ctx, handler, rollback); err != nil {
+						return err
+					}
+				}
+				// the blocks after the ancestor are fetched again
+				next = rollback.Checkpoint.Number + 1
+				break
+			}
+
+			event.Checkpoint = Checkpoint{Number: number, Hash: event.Block.Hash}
+			if err := follower.deliver(ctx, handler, event); err != nil {
+				return err
+			}
+			chain.add(event.Checkpoint)
+			next = number + 1
+		}
+	}
+}
+
+// deliver hands event to handler and saves its checkpoint
+func (follower *ChainFollower) deliver(ctx context.Context, handler FollowerHandler, event *FollowerEvent) error {
+	if err := handler(ctx, event); err != nil {
+		return err
+	}
+	return follower.opts.Store.Save(ctx, event.Checkpoint)
+}
+
+// target returns the highest block to deliver, false when the chain is
+// shorter than the confirmations
+func (follower *ChainFollower) target(ctx context.Context) (uint64, bool, error) {
+	head, err := follower.core.GetBlockNumberCtx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if head.Uint64() < follower.opts.Confirmations {
+		return 0, false, nil
+	}
+	target := head.Uint64() - follower.opts.Confirmations
+	if follower.opts.To != nil && follower.opts.To.Uint64() < target {
+		target = follower.opts.To.Uint64()
+	}
+	return target, true, nil
+}
+
+// fetch returns the blocks from start to end, fetched by Concurrency workers
+func (follower *ChainFollower) fetch(ctx context.Context, start, end uint64) ([]*FollowerEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make([]*FollowerEvent, end-start+1)
+	numbers := make(chan uint64)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var fetchErr error
+
+	for i := 0; i < follower.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range numbers {
+				event, err := follower.fetchBlock(ctx, number)
+				if err != nil {
+					once.Do(func() {
+						fetchErr = err
+						cancel()
+					})
+					continue
+				}
+				events[number-start] = event
+			}
+		}()
+	}
+
+	for number := start; number <= end; number++ {
+		select {
+		case numbers <- number:
+		case <-ctx.Done():
+		}
+	}
+	close(numbers)
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// fetchBlock returns the block number with its receipts
+func (follower *ChainFollower) fetchBlock(ctx context.Context, number uint64) (*FollowerEvent, error) {
+	b, err := follower.core.GetBlockWithTransactionsByNumberCtx(ctx, block.NUMBER(new(big.Int).SetUint64(number)))
+	if err != nil {
+		return nil, err
+	}
+
+	event := &FollowerEvent{Type: FollowerBlock, Block: b}
+	if !follower.opts.Receipts {
+		return event, nil
+	}
+
+	// the receipts of the block are fetched with a single batch request
+	hashes := make([]string, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hashes[i] = tx.Hash
+	}
+	if len(hashes) == 0 {
+		event.Receipts = []*dto.TransactionReceipt{}
+		return event, nil
+	}
+	receipts, err := follower.core.GetTransactionReceiptsCtx(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for i, receipt := range receipts {
+		if receipt == nil {
+			return nil, fmt.Errorf("receipt of %s not found", hashes[i])
+		}
+	}
+	event.Receipts = receipts
+	return event, nil
+}
+
+// rollback walks back from the block number to the common ancestor of the
+// delivered blocks and the chain of the node
+func (follower *ChainFollower) rollback(ctx context.Context, chain *followedChain, number uint64) (*FollowerEvent, error) {
+	event := &FollowerEvent{Type: FollowerRollback}
+
+	for {
+		hash, ok := chain.hash(number)
+		if !ok {
+			return nil, ErrRollbackTooDeep
+		}
+
+		header, err := follower.core.GetHeaderByNumberCtx(ctx, block.NUMBER(new(big.Int).SetUint64(number)))
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash == hash {
+			event.Checkpoint = Checkpoint{Number: number, Hash: hash}
+			break
+		}
+
+		event.Removed = append(event.Removed, Checkpoint{Number: number, Hash: hash})
+		if number == 0 {
+			return nil, ErrRollbackTooDeep
+		}
+		number--
+	}
+
+	for _, removed := range event.Removed {
+		chain.remove(removed.Number)
+	}
+	return event, nil
+}
+
+// followedChain remembers the hashes of the last delivered blocks
+type followedChain struct {
+	depth  uint64
+	hashes map[uint64]string
+}
+
+func newFollowedChain(depth int) *followedChain {
+	return &followedChain{depth: uint64(depth), hashes: make(map[uint64]string)}
+}
+
+func (chain *followedChain) add(checkpoint Checkpoint) {
+	chain.hashes[checkpoint.Number] = checkpoint.Hash
+	if checkpoint.Number >= chain.depth {
+		delete(chain.hashes, checkpoint.Number-chain.depth)
+	}
+}
+
+func (chain *followedChain) hash(number uint64) (string, bool) {
+	hash, ok := chain.hashes[number]
+	return hash, ok
+}
+
+func (chain *followedChain) remove(number uint64) {
+	delete(chain.hashes, number)
+}
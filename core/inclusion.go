@@ -0,0 +1,323 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
+	"github.com/tchain/go-tchain-sdk/utils/trie"
+)
+
+// bloomLength is the length of the logs bloom of a receipt
+const bloomLength = 256
+
+// receiptLog is the value of a log in a receipt
+type receiptLog struct {
+	Address utils.Address
+	Topics  []utils.Hash
+	Data    []byte
+}
+
+// InclusionProof proves a transaction or a receipt is in a block, it's
+// checked against the trusted root of the block with VerifyInclusionProof
+type InclusionProof struct {
+	BlockHash   string   `json:"blockHash"`
+	BlockNumber uint64   `json:"blockNumber"`
+	Root        string   `json:"root"`
+	Index       uint     `json:"index"`
+	Value       string   `json:"value"`
+	Proof       []string `json:"proof"`
+}
+
+/*
+  EncodeReceipt:
+   	EN - Returns the RLP encoding of a receipt, its value in the receipts trie of its block
+ 	CN - 返回交易回执的RLP编码，即该回执在区块回执树中的值
+  Params:
+  	- receipt, *dto.TransactionReceipt, GetTransactionReceipt的返回值
+
+  Returns:
+  	- []byte
+ 	- error
+
+  Call permissions: Anyone
+*/
+func EncodeReceipt(receipt *dto.TransactionReceipt) ([]byte, error) {
+	status := []byte{}
+	if receipt.Status {
+		status = []byte{0x01}
+	}
+
+	bloom := make([]byte, bloomLength)
+	if receipt.LogsBloom != "" {
+		decoded, err := hexutil.Decode(receipt.LogsBloom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid logsBloom: %v", err)
+		}
+		if len(decoded) != bloomLength {
+			return nil, fmt.Errorf("invalid logsBloom of %d bytes", len(decoded))
+		}
+		bloom = decoded
+	}
+
+	logs := make([]receiptLog, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		logs[i] = receiptLog{Address: utils.StringToAddress(log.Address), Data: utils.FromHex(log.Data)}
+		if utils.Has0xPrefix(log.Address) {
+			logs[i].Address = utils.BytesToAddress(utils.FromHex(log.Address))
+		}
+		for _, topic := range log.Topics {
+			logs[i].Topics = append(logs[i].Topics, utils.HexToHash(topic))
+		}
+	}
+
+	return rlp.EncodeToBytes([]interface{}{status, receipt.CumulativeGasUsed, bloom, logs})
+}
+
+/*
+  DeriveTransactionsRoot:
+   	EN - Rebuilds the transactions trie of a block and returns its root
+ 	CN - 重建区块的交易树并返回其根
+  Params:
+  	- transactions, []dto.TransactionResponse, 区块中按顺序排列的交易
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+  	- utils.Hash, 交易树的根，应与区块头的TransactionsRoot一致
+ 	- error
+
+  Call permissions: Anyone
+*/
+func DeriveTransactionsRoot(transactions []dto.TransactionResponse, cryptoType config.CryptoType) (utils.Hash, error) {
+	t, err := transactionsTrie(transactions, cryptoType)
+	if err != nil {
+		return utils.Hash{}, err
+	}
+	return t.Hash(), nil
+}
+
+/*
+  DeriveReceiptsRoot:
+   	EN - Rebuilds the receipts trie of a block and returns its root
+ 	CN - 重建区块的回执树并返回其根
+  Params:
+  	- receipts, []*dto.TransactionReceipt, 区块中按交易顺序排列的回执
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+  	- utils.Hash, 回执树的根，应与区块头的ReceiptsRoot一致
+ 	- error
+
+  Call permissions: Anyone
+*/
+func DeriveReceiptsRoot(receipts []*dto.TransactionReceipt, cryptoType config.CryptoType) (utils.Hash, error) {
+	t, err := receiptsTrie(receipts, cryptoType)
+	if err != nil {
+		return utils.Hash{}, err
+	}
+	return t.Hash(), nil
+}
+
+/*
+  VerifyBlockRoots:
+   	EN - Checks the transactions and the receipts of a block against the roots of its header
+ 	CN - 根据区块头中的根校验区块的交易及回执
+  Params:
+  	- block, *dto.BlockWithTransactions, GetBlockWithTransactionsByNumber的返回值
+  	- receipts, []*dto.TransactionReceipt, 区块中按交易顺序排列的回执，为nil时不校验回执
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+ 	- error, 交易或回执与区块头不一致时返回ErrInvalidProof
+
+  Call permissions: Anyone
+*/
+func VerifyBlockRoots(block *dto.BlockWithTransactions, receipts []*dto.TransactionReceipt, cryptoType config.CryptoType) error {
+	root, err := DeriveTransactionsRoot(block.Transactions, cryptoType)
+	if err != nil {
+		return err
+	}
+	if root != utils.HexToHash(block.TransactionsRoot) {
+		return fmt.Errorf("%w: transactionsRoot %s, derived %s", ErrInvalidProof, block.TransactionsRoot, root.Hex())
+	}
+
+	if receipts == nil {
+		return nil
+	}
+	if len(receipts) != len(block.Transactions) {
+		return fmt.Errorf("%w: %d receipts for %d transactions", ErrInvalidProof, len(receipts), len(block.Transactions))
+	}
+	root, err = DeriveReceiptsRoot(receipts, cryptoType)
+	if err != nil {
+		return err
+	}
+	if root != utils.HexToHash(block.ReceiptsRoot) {
+		return fmt.Errorf("%w: receiptsRoot %s, derived %s", ErrInvalidProof, block.ReceiptsRoot, root.Hex())
+	}
+	return nil
+}
+
+/*
+  ProveTransaction:
+   	EN - Creates the proof a transaction is in a block, the block is checked against its header first
+ 	CN - 生成交易包含于区块中的证明，生成前先根据区块头校验区块
+  Params:
+  	- block, *dto.BlockWithTransactions, 交易所在的区块
+  	- index, uint, 交易在区块中的序号
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+  	- *InclusionProof, 可由VerifyInclusionProof根据区块头的TransactionsRoot校验
+ 	- error
+
+  Call permissions: Anyone
+*/
+func ProveTransaction(block *dto.BlockWithTransactions, index uint, cryptoType config.CryptoType) (*InclusionProof, error) {
+	if index >= uint(len(block.Transactions)) {
+		return nil, fmt.Errorf("transaction index %d out of range", index)
+	}
+	t, err := transactionsTrie(block.Transactions, cryptoType)
+	if err != nil {
+		return nil, err
+	}
+	if root := t.Hash(); root != utils.HexToHash(block.TransactionsRoot) {
+		return nil, fmt.Errorf("%w: transactionsRoot %s, derived %s", ErrInvalidProof, block.TransactionsRoot, root.Hex())
+	}
+	return newInclusionProof(t, &block.BlockHeader, block.TransactionsRoot, index)
+}
+
+/*
+  ProveReceipt:
+   	EN - Creates the proof a receipt is in a block, the receipts are checked against the header first
+ 	CN - 生成回执包含于区块中的证明，生成前先根据区块头校验回执
+  Params:
+  	- header, *dto.BlockHeader, 回执所在区块的区块头
+  	- receipts, []*dto.TransactionReceipt, 区块中按交易顺序排列的回执
+  	- index, uint, 回执在区块中的序号
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+  	- *InclusionProof, 可由VerifyInclusionProof根据区块头的ReceiptsRoot校验
+ 	- error
+
+  Call permissions: Anyone
+*/
+func ProveReceipt(header *dto.BlockHeader, receipts []*dto.TransactionReceipt, index uint, cryptoType config.CryptoType) (*InclusionProof, error) {
+	if index >= uint(len(receipts)) {
+		return nil, fmt.Errorf("receipt index %d out of range", index)
+	}
+	t, err := receiptsTrie(receipts, cryptoType)
+	if err != nil {
+		return nil, err
+	}
+	if root := t.Hash(); root != utils.HexToHash(header.ReceiptsRoot) {
+		return nil, fmt.Errorf("%w: receiptsRoot %s, derived %s", ErrInvalidProof, header.ReceiptsRoot, root.Hex())
+	}
+	return newInclusionProof(t, header, header.ReceiptsRoot, index)
+}
+
+/*
+  VerifyInclusionProof:
+   	EN - Checks an inclusion proof against the transactions or receipts root of a trusted block header
+ 	CN - 根据可信区块头的TransactionsRoot或ReceiptsRoot校验包含证明
+  Params:
+  	- trustedRoot, string, 可信区块头的TransactionsRoot或ReceiptsRoot
+  	- proof, *InclusionProof, ProveTransaction或ProveReceipt生成的证明
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+ 	- error, 证明与根或证明的值不一致时返回ErrInvalidProof
+
+  Call permissions: Anyone
+*/
+func VerifyInclusionProof(trustedRoot string, proof *InclusionProof, cryptoType config.CryptoType) error {
+	nodes, err := decodeProof(proof.Proof)
+	if err != nil {
+		return err
+	}
+	expected, err := hexutil.Decode(proof.Value)
+	if err != nil {
+		return fmt.Errorf("%w: value: %v", ErrInvalidProof, err)
+	}
+
+	key, _ := rlp.EncodeToBytes(proof.Index)
+	value, err := trie.VerifyProof(utils.HexToHash(trustedRoot), key, nodes, cryptoType)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	if value == nil {
+		return fmt.Errorf("%w: index %d isn't in the trie", ErrInvalidProof, proof.Index)
+	}
+	if !bytes.Equal(value, expected) {
+		return fmt.Errorf("%w: value of index %d doesn't match the proof", ErrInvalidProof, proof.Index)
+	}
+	return nil
+}
+
+func transactionsTrie(transactions []dto.TransactionResponse, cryptoType config.CryptoType) (*trie.Trie, error) {
+	t := trie.New(cryptoType)
+	for i := range transactions {
+		value, err := account.EncodeTransaction(&transactions[i])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", i, err)
+		}
+		key, _ := rlp.EncodeToBytes(uint(i))
+		t.Update(key, value)
+	}
+	return t, nil
+}
+
+func receiptsTrie(receipts []*dto.TransactionReceipt, cryptoType config.CryptoType) (*trie.Trie, error) {
+	t := trie.New(cryptoType)
+	for i, receipt := range receipts {
+		value, err := EncodeReceipt(receipt)
+		if err != nil {
+			return nil, fmt.Errorf("receipt %d: %v", i, err)
+		}
+		key, _ := rlp.EncodeToBytes(uint(i))
+		t.Update(key, value)
+	}
+	return t, nil
+}
+
+func newInclusionProof(t *trie.Trie, header *dto.BlockHeader, root string, index uint) (*InclusionProof, error) {
+	key, _ := rlp.EncodeToBytes(index)
+	nodes, err := t.Prove(key)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &InclusionProof{
+		BlockHash: header.Hash,
+		Root:      root,
+		Index:     index,
+		Proof:     make([]string, len(nodes)),
+	}
+	if header.Number != nil {
+		proof.BlockNumber = header.Number.Uint64()
+	}
+	for i, node := range nodes {
+		proof.Proof[i] = hexutil.Encode(node)
+	}
+	proof.Value = hexutil.Encode(t.Get(key))
+	return proof, nil
+}
@@ -0,0 +1,285 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/txpool"
+)
+
+// NonceManager hands out the nonces of the senders without asking the node
+// for every transaction. The nonces of an address are sequential and safe to
+// reserve from several goroutines, the manager resyncs with the node when a
+// transaction is rejected with a nonce too low or too high.
+type NonceManager struct {
+	core *Core
+	pool *txpool.TxPool
+
+	lock     sync.Mutex
+	accounts map[string]*nonceAccount
+}
+
+// nonceAccount is the state of the nonces of an address
+type nonceAccount struct {
+	lock   sync.Mutex
+	synced bool
+	// next is the nonce after the highest nonce handed out or known to the node
+	next uint64
+	// released are the nonces below next to hand out again first, sorted
+	released []uint64
+	// reserved are the nonces handed out and not yet committed or released
+	reserved map[uint64]struct{}
+}
+
+/*
+  NewNonceManager:
+   	EN - Creates a nonce manager, the nonces are read from the pending transaction count and the gaps are found with the content of pool
+ 	CN - 创建nonce管理器，nonce从pending交易数读取，并通过交易池内容检测nonce空缺
+  Params:
+  	- pool, *txpool.TxPool, 交易池模块，为nil时不检测nonce空缺
+
+  Returns:
+  	- *NonceManager
+
+  Call permissions: Anyone
+*/
+func (core *Core) NewNonceManager(pool *txpool.TxPool) *NonceManager {
+	return &NonceManager{
+		core:     core,
+		pool:     pool,
+		accounts: make(map[string]*nonceAccount),
+	}
+}
+
+// NonceReservation is a nonce handed out by the NonceManager. It must end
+// with Commit once the transaction is broadcast, or with Release or Fail when
+// it isn't.
+type NonceReservation struct {
+	Address string
+	Nonce   *big.Int
+
+	manager *NonceManager
+	once    sync.Once
+}
+
+// Next reserves the next nonce of address, the nonces released before are
+// handed out again first. The nonce is read from the node on the first call
+// for the address.
+func (manager *NonceManager) Next(ctx context.Context, address string) (*NonceReservation, error) {
+	account := manager.account(address)
+
+	account.lock.Lock()
+	defer account.lock.Unlock()
+
+	if !account.synced {
+		if err := manager.resync(ctx, address, account); err != nil {
+			return nil, err
+		}
+	}
+
+	var nonce uint64
+	if len(account.released) > 0 {
+		nonce = account.released[0]
+		account.released = account.released[1:]
+	} else {
+		nonce = account.next
+		account.next++
+	}
+	account.reserved[nonce] = struct{}{}
+
+	return &NonceReservation{Address: address, Nonce: new(big.Int).SetUint64(nonce), manager: manager}, nil
+}
+
+// Resync reads the nonce of address from the node again, the nonces below
+// the pending transaction count which are neither in the pool nor reserved
+// are handed out first to fill the gaps
+func (manager *NonceManager) Resync(ctx context.Context, address string) error {
+	account := manager.account(address)
+
+	account.lock.Lock()
+	defer account.lock.Unlock()
+
+	return manager.resync(ctx, address, account)
+}
+
+// Reset forgets the nonces of address, the next reservation reads them from
+// the node. The reservations in progress are ignored when they end.
+func (manager *NonceManager) Reset(address string) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	delete(manager.accounts, address)
+}
+
+// Commit records that the transaction with the nonce was broadcast
+func (reservation *NonceReservation) Commit() {
+	reservation.end(func(account *nonceAccount, nonce uint64) {})
+}
+
+// Release gives back the nonce of a transaction which was never broadcast,
+// it's handed out again by the next reservation
+func (reservation *NonceReservation) Release() {
+	reservation.end(func(account *nonceAccount, nonce uint64) {
+		account.release(nonce)
+	})
+}
+
+// Fail ends the reservation with the error returned when broadcasting the
+// transaction. The nonce is resynced from the node on a nonce too low or too
+// high error, and on a transport error or a timeout as the transaction may
+// have been broadcast all the same, its nonce is kept in both cases. The nonce
+// is released on the other errors, as the transaction wasn't accepted.
+func (reservation *NonceReservation) Fail(ctx context.Context, err error) error {
+	if !errors.Is(err, providers.ErrNonceTooLow) && !errors.Is(err, providers.ErrNonceTooHigh) && !uncertainSend(err) {
+		reservation.Release()
+		return nil
+	}
+
+	reservation.Commit()
+	return reservation.manager.Resync(ctx, reservation.Address)
+}
+
+// uncertainSend reports whether the transaction may have reached the node
+// although broadcasting it returned err
+func uncertainSend(err error) bool {
+	var transportErr *providers.TransportError
+	return errors.As(err, &transportErr) ||
+		errors.Is(err, providers.ErrTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled)
+}
+
+func (reservation *NonceReservation) end(fn func(account *nonceAccount, nonce uint64)) {
+	reservation.once.Do(func() {
+		account := reservation.manager.account(reservation.Address)
+
+		account.lock.Lock()
+		defer account.lock.Unlock()
+
+		nonce := reservation.Nonce.Uint64()
+		if _, ok := account.reserved[nonce]; !ok {
+			// the account was reset
+			return
+		}
+		delete(account.reserved, nonce)
+		fn(account, nonce)
+	})
+}
+
+func (manager *NonceManager) account(address string) *nonceAccount {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	account, ok := manager.accounts[address]
+	if !ok {
+		account = &nonceAccount{reserved: make(map[uint64]struct{})}
+		manager.accounts[address] = account
+	}
+	return account
+}
+
+// resync reads the nonces of address from the node, account must be locked
+func (manager *NonceManager) resync(ctx context.Context, address string, account *nonceAccount) error {
+	pending, err := manager.core.GetTransactionCountCtx(ctx, address, block.PENDING)
+	if err != nil {
+		return err
+	}
+	next := pending.Uint64()
+
+	// without the pool only the nonces from the pending count are checked,
+	// the ones below are executable transactions known to the node
+	from := next
+	var known map[uint64]struct{}
+	if manager.pool != nil {
+		latest, err := manager.core.GetTransactionCountCtx(ctx, address, block.LATEST)
+		if err != nil {
+			return err
+		}
+		from = latest.Uint64()
+
+		known, err = manager.poolNonces(ctx, address)
+		if err != nil {
+			return err
+		}
+		for nonce := range known {
+			if nonce >= next {
+				next = nonce + 1
+			}
+		}
+	}
+
+	for nonce := range account.reserved {
+		if nonce >= next {
+			next = nonce + 1
+		}
+	}
+
+	// the gaps are neither in the pool nor reserved, they're handed out first
+	var released []uint64
+	for nonce := from; nonce < next; nonce++ {
+		if _, ok := known[nonce]; ok {
+			continue
+		}
+		if _, ok := account.reserved[nonce]; ok {
+			continue
+		}
+		released = append(released, nonce)
+	}
+
+	account.next = next
+	account.released = released
+	account.synced = true
+	return nil
+}
+
+// poolNonces returns the nonces of the transactions of address in the pool,
+// pending and queued
+func (manager *NonceManager) poolNonces(ctx context.Context, address string) (map[uint64]struct{}, error) {
+	content, err := manager.pool.ContentCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces := make(map[uint64]struct{})
+	for _, senders := range content {
+		for nonce := range senders[address] {
+			n, err := strconv.ParseUint(nonce, 10, 64)
+			if err != nil {
+				return nil, errors.New("invalid nonce " + nonce + " in the transaction pool")
+			}
+			nonces[n] = struct{}{}
+		}
+	}
+	return nonces, nil
+}
+
+// release adds nonce to the nonces to hand out again, in order
+func (account *nonceAccount) release(nonce uint64) {
+	i := sort.Search(len(account.released), func(i int) bool { return account.released[i] >= nonce })
+	if i < len(account.released) && account.released[i] == nonce {
+		return
+	}
+	account.released = append(account.released, 0)
+	copy(account.released[i+1:], account.released[i:])
+	account.released[i] = nonce
+}
@@ -0,0 +1,169 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
+	"github.com/tchain/go-tchain-sdk/utils/trie"
+)
+
+// ErrInvalidProof is returned when a proof doesn't match the values it proves
+var ErrInvalidProof = errors.New("invalid proof")
+
+// stateAccount is the value of an account in the state trie
+type stateAccount struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     utils.Hash
+	CodeHash []byte
+	Rest     []rlp.RawValue `rlp:"tail"`
+}
+
+/*
+  VerifyAccountProof:
+   	EN - Verifies the account proof and the storage proofs returned by GetProof against the state root of a trusted block
+ 	CN - 根据可信区块的状态根验证GetProof返回的账户证明及存储证明
+  Params:
+  	- stateRoot, string, 可信区块头的StateRoot
+  	- result, *dto.AccountResult, GetProof的返回值
+  	- cryptoType, config.CryptoType, 链使用的哈希算法，config.SM2为SM3，config.SECP256K1为Keccak256
+
+  Returns:
+ 	- error, 证明与余额、nonce、codeHash、storageHash或存储值不一致时返回ErrInvalidProof
+
+  Call permissions: Anyone
+*/
+func VerifyAccountProof(stateRoot string, result *dto.AccountResult, cryptoType config.CryptoType) error {
+	proof, err := decodeProof(result.AccountProof)
+	if err != nil {
+		return err
+	}
+
+	key := crypto.Keccak256(cryptoType, result.Address.Bytes())
+	value, err := trie.VerifyProof(utils.HexToHash(stateRoot), key, proof, cryptoType)
+	if err != nil {
+		return fmt.Errorf("%w: account: %v", ErrInvalidProof, err)
+	}
+
+	// a missing account is empty
+	account := stateAccount{
+		Balance:  new(big.Int),
+		Root:     trie.EmptyRoot(cryptoType),
+		CodeHash: crypto.Keccak256(cryptoType),
+	}
+	if value != nil {
+		if err := rlp.DecodeBytes(value, &account); err != nil {
+			return fmt.Errorf("%w: account: %v", ErrInvalidProof, err)
+		}
+	}
+
+	var balance *big.Int
+	if result.Balance != nil {
+		balance = result.Balance.ToInt()
+	} else {
+		balance = new(big.Int)
+	}
+
+	switch {
+	case account.Nonce != uint64(result.Nonce):
+		return fmt.Errorf("%w: nonce %d, proven %d", ErrInvalidProof, result.Nonce, account.Nonce)
+	case account.Balance.Cmp(balance) != 0:
+		return fmt.Errorf("%w: balance %s, proven %s", ErrInvalidProof, balance, account.Balance)
+	case !bytes.Equal(account.CodeHash, result.CodeHash.Bytes()):
+		return fmt.Errorf("%w: codeHash %s, proven %x", ErrInvalidProof, result.CodeHash.Hex(), account.CodeHash)
+	case account.Root != result.StorageHash:
+		return fmt.Errorf("%w: storageHash %s, proven %s", ErrInvalidProof, result.StorageHash.Hex(), account.Root.Hex())
+	}
+
+	for _, storage := range result.StorageProof {
+		if err := VerifyStorageProof(result.StorageHash.Hex(), storage, cryptoType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+  VerifyStorageProof:
+   	EN - Verifies the proof of a storage slot against the storage root of its account, which is verified by VerifyAccountProof
+ 	CN - 根据账户的存储根验证单个存储位置的证明，存储根由VerifyAccountProof验证
+  Params:
+  	- storageHash, string, 账户的StorageHash
+  	- storage, dto.StorageResult, GetProof返回的存储证明
+  	- cryptoType, config.CryptoType, 链使用的哈希算法
+
+  Returns:
+ 	- error, 证明与存储值不一致时返回ErrInvalidProof
+
+  Call permissions: Anyone
+*/
+func VerifyStorageProof(storageHash string, storage dto.StorageResult, cryptoType config.CryptoType) error {
+	proof, err := decodeProof(storage.Proof)
+	if err != nil {
+		return err
+	}
+
+	slot := utils.FromHex(storage.Key)
+	if len(slot) > utils.HashLength {
+		return fmt.Errorf("invalid storage key %s", storage.Key)
+	}
+	key := crypto.Keccak256(cryptoType, utils.LeftPadBytes(slot, utils.HashLength))
+
+	value, err := trie.VerifyProof(utils.HexToHash(storageHash), key, proof, cryptoType)
+	if err != nil {
+		return fmt.Errorf("%w: storage %s: %v", ErrInvalidProof, storage.Key, err)
+	}
+
+	// the slots are stored as RLP strings, the zero slots are removed
+	proven := new(big.Int)
+	if value != nil {
+		content, _, err := rlp.SplitString(value)
+		if err != nil {
+			return fmt.Errorf("%w: storage %s: %v", ErrInvalidProof, storage.Key, err)
+		}
+		proven.SetBytes(content)
+	}
+
+	expected := new(big.Int)
+	if storage.Value != nil {
+		expected = storage.Value.ToInt()
+	}
+	if proven.Cmp(expected) != 0 {
+		return fmt.Errorf("%w: storage %s value %s, proven %s", ErrInvalidProof, storage.Key, expected, proven)
+	}
+	return nil
+}
+
+func decodeProof(proof []string) ([][]byte, error) {
+	nodes := make([][]byte, len(proof))
+	for i, node := range proof {
+		decoded, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+		}
+		nodes[i] = decoded
+	}
+	return nodes, nil
+}
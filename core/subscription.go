@@ -0,0 +1,94 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package core
+
+import (
+	"context"
+
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+)
+
+// subscriptionNamespace is the namespace of the core_subscribe method
+const subscriptionNamespace = "core"
+
+/*
+  SubscribeNewHeads:
+   	EN - Subscribes to the headers of the new blocks added to the chain, each header is sent to ch
+ 	CN - 订阅链上新增区块的区块头，每个区块头都会发送到ch
+  Params:
+  	- ctx, context.Context, 仅用于订阅请求本身
+  	- ch, chan<- *dto.BlockHeader, 接收区块头的通道
+
+  Returns:
+  	- *providers.Subscription, 订阅对象，通过Err()获取订阅错误，通过Unsubscribe()取消订阅
+ 	- error, provider不支持订阅（如HTTP）时返回providers.ErrNotificationsUnsupported
+
+  Call permissions: Anyone
+*/
+func (core *Core) SubscribeNewHeads(ctx context.Context, ch chan<- *dto.BlockHeader) (*providers.Subscription, error) {
+	return core.subscribe(ctx, ch, "newHeads")
+}
+
+/*
+  SubscribeLogs:
+   	EN - Subscribes to the logs of the new blocks matching query, each log is sent to ch
+ 	CN - 订阅新区块中符合query条件的日志，每条日志都会发送到ch
+  Params:
+  	- ctx, context.Context, 仅用于订阅请求本身
+  	- query, dto.FilterQuery, 日志过滤条件（合约地址及topics）
+  	- ch, chan<- *dto.TransactionLogs, 接收日志的通道
+
+  Returns:
+  	- *providers.Subscription, 订阅对象，通过Err()获取订阅错误，通过Unsubscribe()取消订阅
+ 	- error, provider不支持订阅（如HTTP）时返回providers.ErrNotificationsUnsupported
+
+  Call permissions: Anyone
+*/
+func (core *Core) SubscribeLogs(ctx context.Context, query dto.FilterQuery, ch chan<- *dto.TransactionLogs) (*providers.Subscription, error) {
+	arg, err := query.ToFilterArg()
+	if err != nil {
+		return nil, err
+	}
+
+	return core.subscribe(ctx, ch, "logs", arg)
+}
+
+/*
+  SubscribeNewPendingTransactions:
+   	EN - Subscribes to the hashes of the transactions added to the transaction pool, each hash is sent to ch
+ 	CN - 订阅新加入交易池的交易，每个交易哈希都会发送到ch
+  Params:
+  	- ctx, context.Context, 仅用于订阅请求本身
+  	- ch, chan<- string, 接收交易哈希的通道
+
+  Returns:
+  	- *providers.Subscription, 订阅对象，通过Err()获取订阅错误，通过Unsubscribe()取消订阅
+ 	- error, provider不支持订阅（如HTTP）时返回providers.ErrNotificationsUnsupported
+
+  Call permissions: Anyone
+*/
+func (core *Core) SubscribeNewPendingTransactions(ctx context.Context, ch chan<- string) (*providers.Subscription, error) {
+	return core.subscribe(ctx, ch, "newPendingTransactions")
+}
+
+func (core *Core) subscribe(ctx context.Context, channel interface{}, args ...interface{}) (*providers.Subscription, error) {
+	provider, ok := core.provider.(providers.SubscriptionProvider)
+	if !ok {
+		return nil, providers.ErrNotificationsUnsupported
+	}
+
+	return provider.Subscribe(ctx, subscriptionNamespace, channel, args...)
+}
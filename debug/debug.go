@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
 )
@@ -32,11 +33,16 @@ func NewDebug(provider providers.ProviderInterface) *Debug {
   Call permissions: Anyone
 */
 func (debug *Debug) DumpBlock(blockNumber string) (*dto.Dump, error) {
+	return debug.DumpBlockCtx(context.Background(), blockNumber)
+}
+
+// DumpBlockCtx is like DumpBlock but honours the cancellation and deadline of ctx.
+func (debug *Debug) DumpBlockCtx(ctx context.Context, blockNumber string) (*dto.Dump, error) {
 	params := make([]string, 1)
 	params[0] = blockNumber
 
 	pointer := &dto.DebugRequestResult{}
-	err := debug.provider.SendRequest(pointer, "debug_dumpBlock", params)
+	err := debug.provider.SendRequestContext(ctx, pointer, "debug_dumpBlock", params)
 	if err != nil {
 		return nil, err
 	}
@@ -59,11 +65,16 @@ func (debug *Debug) DumpBlock(blockNumber string) (*dto.Dump, error) {
   Call permissions: Anyone
 */
 func (debug *Debug) GetBlockRlp(number uint64) (string, error) {
+	return debug.GetBlockRlpCtx(context.Background(), number)
+}
+
+// GetBlockRlpCtx is like GetBlockRlp but honours the cancellation and deadline of ctx.
+func (debug *Debug) GetBlockRlpCtx(ctx context.Context, number uint64) (string, error) {
 	params := make([]uint64, 1)
 	params[0] = number
 
 	pointer := &dto.DebugRequestResult{}
-	err := debug.provider.SendRequest(pointer, "debug_getBlockRlp", params)
+	err := debug.provider.SendRequestContext(ctx, pointer, "debug_getBlockRlp", params)
 	if err != nil {
 		return "", err
 	}
@@ -86,11 +97,16 @@ func (debug *Debug) GetBlockRlp(number uint64) (string, error) {
   Call permissions: Anyone
 */
 func (debug *Debug) PrintBlock(number uint64) (string, error) {
+	return debug.PrintBlockCtx(context.Background(), number)
+}
+
+// PrintBlockCtx is like PrintBlock but honours the cancellation and deadline of ctx.
+func (debug *Debug) PrintBlockCtx(ctx context.Context, number uint64) (string, error) {
 	params := make([]uint64, 1)
 	params[0] = number
 
 	pointer := &dto.DebugRequestResult{}
-	err := debug.provider.SendRequest(pointer, "debug_printBlock", params)
+	err := debug.provider.SendRequestContext(ctx, pointer, "debug_printBlock", params)
 	if err != nil {
 		return "", err
 	}
@@ -20,6 +20,10 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
+
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 )
 
 type BlockDetails struct {
@@ -136,3 +140,131 @@ func (b *BlockNoDetails) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// BlockHeader is the header of a block, as sent by the newHeads subscription
+// or returned by Core.GetHeaderByNumber
+type BlockHeader struct {
+	Number           *big.Int `json:"number"`
+	Hash             string   `json:"hash"`
+	ParentHash       string   `json:"parentHash"`
+	LogsBloom        string   `json:"logsBloom"`
+	StateRoot        string   `json:"stateRoot"`
+	Generator        string   `json:"generator"`
+	Regulatory       string   `json:"regulatory"`
+	ExtraData        string   `json:"extraData"`
+	Timestamp        uint64   `json:"timestamp"`
+	TransactionsRoot string   `json:"transactionsRoot"`
+	ReceiptsRoot     string   `json:"receiptsRoot"`
+
+	// Istanbul is the consensus data decoded from ExtraData: the validators,
+	// the seal of the generator and the committed seals. It's nil when
+	// ExtraData doesn't hold it, e.g. in the genesis block.
+	Istanbul *utils.IstanbulExtra `json:"-"`
+}
+
+func (h *BlockHeader) UnmarshalJSON(data []byte) error {
+	type Alias BlockHeader
+	temp := &struct {
+		Number    string `json:"number"`
+		Timestamp string `json:"timestamp"`
+		*Alias
+	}{
+		Alias: (*Alias)(h),
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	num, success := big.NewInt(0).SetString(strings.TrimPrefix(temp.Number, "0x"), 16)
+
+	if !success {
+		return errors.New(fmt.Sprintf("Error converting %s to bigInt", temp.Number))
+	}
+
+	timestamp, err := strconv.ParseUint(temp.Timestamp, 0, 64)
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error converting %s to uint64", temp.Timestamp))
+	}
+
+	h.Number = num
+	h.Timestamp = timestamp
+	h.Istanbul = nil
+
+	if extra, err := hexutil.Decode(h.ExtraData); err == nil {
+		if istanbul, err := utils.ExtractIstanbulExtra(extra); err == nil {
+			h.Istanbul = istanbul
+		}
+	}
+
+	return nil
+}
+
+// Block is a block with the hashes of its transactions
+type Block struct {
+	BlockHeader
+	Size         uint64   `json:"size"`
+	Transactions []string `json:"transactions"`
+}
+
+func (b *Block) UnmarshalJSON(data []byte) error {
+	var header BlockHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	temp := &struct {
+		Size         string   `json:"size"`
+		Transactions []string `json:"transactions"`
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+
+	size, err := strconv.ParseUint(temp.Size, 0, 64)
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error converting %s to uint64", temp.Size))
+	}
+
+	b.BlockHeader = header
+	b.Size = size
+	b.Transactions = temp.Transactions
+
+	return nil
+}
+
+// BlockWithTransactions is a block with its full transactions
+type BlockWithTransactions struct {
+	BlockHeader
+	Size         uint64                `json:"size"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+func (b *BlockWithTransactions) UnmarshalJSON(data []byte) error {
+	var header BlockHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	temp := &struct {
+		Size         string                `json:"size"`
+		Transactions []TransactionResponse `json:"transactions"`
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+
+	size, err := strconv.ParseUint(temp.Size, 0, 64)
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error converting %s to uint64", temp.Size))
+	}
+
+	b.BlockHeader = header
+	b.Size = size
+	b.Transactions = temp.Transactions
+
+	return nil
+}
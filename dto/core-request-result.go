@@ -5,6 +5,7 @@ import (
 	"github.com/tchain/go-tchain-sdk/utils"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"math/big"
+	"strings"
 )
 
 type CoreRequestResult struct {
@@ -249,8 +250,6 @@ func (pointer *CoreRequestResult) ToProof() (*AccountResult, error) {
 
 	var data []byte
 	var err error
-	value0 := pointer.Result
-	println(value0)
 	if value, ok := pointer.Result.(string); ok {
 		// come from websock
 		data = []byte(value)
@@ -275,3 +274,105 @@ func (pointer *CoreRequestResult) ToProof() (*AccountResult, error) {
 	return accountResult, err
 
 }
+
+func (pointer *CoreRequestResult) ToTransactionLogs() ([]TransactionLogs, error) {
+
+	data, err := pointer.resultJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []TransactionLogs
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// ToFilterChanges decodes the result of core_getFilterChanges, which holds
+// either logs or hashes according to the kind of filter
+func (pointer *CoreRequestResult) ToFilterChanges() (*FilterChanges, error) {
+
+	data, err := pointer.resultJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []json.RawMessage
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+
+	changes := &FilterChanges{}
+	if len(results) == 0 {
+		return changes, nil
+	}
+
+	if strings.HasPrefix(string(results[0]), "\"") {
+		err = json.Unmarshal(data, &changes.Hashes)
+	} else {
+		err = json.Unmarshal(data, &changes.Logs)
+	}
+
+	return changes, err
+}
+
+func (pointer *CoreRequestResult) ToBlockHeader() (*BlockHeader, error) {
+
+	data, err := pointer.resultJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	header := &BlockHeader{}
+	err = json.Unmarshal(data, header)
+
+	return header, err
+}
+
+func (pointer *CoreRequestResult) ToBlockWithHashes() (*Block, error) {
+
+	data, err := pointer.resultJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	block := &Block{}
+	err = json.Unmarshal(data, block)
+
+	return block, err
+}
+
+func (pointer *CoreRequestResult) ToBlockWithTransactions() (*BlockWithTransactions, error) {
+
+	data, err := pointer.resultJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	block := &BlockWithTransactions{}
+	err = json.Unmarshal(data, block)
+
+	return block, err
+}
+
+// resultJSON returns the JSON encoding of the result
+func (pointer *CoreRequestResult) resultJSON() ([]byte, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	if value, ok := pointer.Result.(string); ok {
+		// come from websock
+		return []byte(value), nil
+	}
+
+	// come from http
+	data, err := json.Marshal(pointer.Result)
+	if err != nil {
+		return nil, UNPARSEABLEINTERFACE
+	}
+	return data, nil
+}
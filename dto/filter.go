@@ -0,0 +1,92 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package dto
+
+import "errors"
+
+// FilterQuery selects logs, by core_getLogs, core_newFilter or a log subscription
+type FilterQuery struct {
+	// BlockHash restricts the logs to a single block, it can't be used with
+	// FromBlock or ToBlock
+	BlockHash string
+
+	// FromBlock and ToBlock restrict the logs to a range of blocks, e.g.
+	// block.NUMBER(n) or block.LATEST, the node takes latest when empty.
+	// They don't apply to the subscriptions, which only get the new logs.
+	FromBlock string
+	ToBlock   string
+
+	// Addresses restricts the logs to the ones emitted by these contracts,
+	// in did:bid form
+	Addresses []string
+
+	// Topics restricts the logs by position, a nil or empty entry matches any
+	// topic at that position, otherwise any of its values must match. e.g.
+	//  {} or nil          matches any topic list
+	//  {{A}}              matches topic A in first position
+	//  {{}, {B}}          matches any topic in first position AND B in second position
+	//  {{A, B}, {C, D}}   matches topic (A OR B) in first position AND (C OR D) in second position
+	Topics [][]string
+}
+
+// ToFilterArg converts the query to the JSON-RPC filter object
+func (q FilterQuery) ToFilterArg() (map[string]interface{}, error) {
+	arg := map[string]interface{}{}
+
+	if q.BlockHash != "" {
+		if q.FromBlock != "" || q.ToBlock != "" {
+			return nil, errors.New("cannot specify both BlockHash and FromBlock/ToBlock")
+		}
+		arg["blockHash"] = q.BlockHash
+	} else {
+		if q.FromBlock != "" {
+			arg["fromBlock"] = q.FromBlock
+		}
+		if q.ToBlock != "" {
+			arg["toBlock"] = q.ToBlock
+		}
+	}
+
+	if len(q.Addresses) == 1 {
+		arg["address"] = q.Addresses[0]
+	} else if len(q.Addresses) > 1 {
+		arg["address"] = q.Addresses
+	}
+
+	if len(q.Topics) > 0 {
+		topics := make([]interface{}, len(q.Topics))
+		for i, position := range q.Topics {
+			switch len(position) {
+			case 0:
+				topics[i] = nil
+			case 1:
+				topics[i] = position[0]
+			default:
+				topics[i] = position
+			}
+		}
+		arg["topics"] = topics
+	}
+
+	return arg, nil
+}
+
+// FilterChanges are the changes of a filter since it was last polled: the
+// logs of a filter of core_newFilter, or the hashes of the blocks of a filter
+// of core_newBlockFilter
+type FilterChanges struct {
+	Logs   []TransactionLogs
+	Hashes []string
+}
@@ -41,6 +41,12 @@ type Error struct {
 	Data    interface{} `json:"data"`
 }
 
+// Error implements the error interface, the providers return the error of the
+// response as a *providers.RPCError before it reaches the result
+func (e *Error) Error() string {
+	return e.Message
+}
+
 func (pointer *RequestResult) ToStringArray() ([]string, error) {
 
 	if err := pointer.checkResponse(); err != nil {
@@ -72,21 +78,16 @@ func (pointer *RequestResult) ToStringArray() ([]string, error) {
 
 	stringArray := make([]string, len(resultLi))
 	for i, v := range resultLi {
-		var data []byte
-		var err error
 		if value, ok := v.(string); ok {
-			//come from websock
-			data = []byte(value)
-		} else {
-			//come from http
-			result := v.(map[string]interface{})
-
-			if len(result) == 0 {
-				return nil, EMPTYRESPONSE
-			}
-			data, err = json.Marshal(result)
+			stringArray[i] = value
+			continue
 		}
 
+		result, ok := v.(map[string]interface{})
+		if !ok || len(result) == 0 {
+			return nil, EMPTYRESPONSE
+		}
+		data, err := json.Marshal(result)
 		if err != nil {
 			return nil, UNPARSEABLEINTERFACE
 		}
@@ -249,7 +250,7 @@ func (pointer *RequestResult) ToTransactionReceipt() (*TransactionReceipt, error
 func (pointer *RequestResult) checkResponse() error {
 
 	if pointer.Error != nil {
-		return errors.New(pointer.Error.Message)
+		return pointer.Error
 	}
 
 	if pointer.Result == nil {
@@ -262,6 +263,10 @@ func (pointer *RequestResult) checkResponse() error {
 		}
 
 		if rvalue, err := base64.StdEncoding.DecodeString(value); err == nil {
+			// a null result, e.g. of a receipt not found, over websocket
+			if string(rvalue) == "null" {
+				return EMPTYRESPONSE
+			}
 			pointer.Result = string(rvalue)
 		}
 	}
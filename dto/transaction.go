@@ -22,6 +22,7 @@ import (
 	"github.com/tchain/go-tchain-sdk/utils/types"
 	"math/big"
 	"strconv"
+	"strings"
 )
 
 // TransactionParameters GO transaction to make more easy controll the parameters
@@ -355,25 +356,23 @@ func (r *TransactionLogs) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	blockNumLog, success := big.NewInt(0).SetString(log.BlockNumber[2:], 16)
-
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to BigInt", log.BlockNumber))
+	// the logs of the pending blocks have no block number nor index
+	blockNumLog, err := hexToBigInt(log.BlockNumber)
+	if err != nil {
+		return err
 	}
 
 	if len(log.TransactionIndex) == 0 {
 		log.TransactionIndex = "0x0"
 	}
-	txIndexLogs, success := big.NewInt(0).SetString(log.TransactionIndex[2:], 16)
-
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to BigInt", log.TransactionIndex))
+	txIndexLogs, err := hexToBigInt(log.TransactionIndex)
+	if err != nil {
+		return err
 	}
 
-	logIndex, success := big.NewInt(0).SetString(log.LogIndex[2:], 16)
-
-	if !success {
-		return errors.New(fmt.Sprintf("Error converting %s to BigInt", log.LogIndex))
+	logIndex, err := hexToBigInt(log.LogIndex)
+	if err != nil {
+		return err
 	}
 
 	r.BlockNumber = blockNumLog
@@ -383,6 +382,19 @@ func (r *TransactionLogs) UnmarshalJSON(data []byte) error {
 
 }
 
+// hexToBigInt converts a 0x prefixed hex number, it returns nil for an empty string
+func hexToBigInt(hex string) (*big.Int, error) {
+	if hex == "" {
+		return nil, nil
+	}
+
+	value, success := big.NewInt(0).SetString(strings.TrimPrefix(hex, "0x"), 16)
+	if !success {
+		return nil, errors.New(fmt.Sprintf("Error converting %s to BigInt", hex))
+	}
+	return value, nil
+}
+
 type CandidateResponse struct {
 	Owner           string `json:"owner"`           // 候选人地址
 	Name            string `json:"name"`            // 候选人名称
@@ -15,6 +15,7 @@
 package gb
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
 )
@@ -32,19 +33,29 @@ func NewGB(provider providers.ProviderInterface) *GB {
 }
 
 func (gb *GB) Start() error {
+	return gb.StartCtx(context.Background())
+}
+
+// StartCtx is like Start but honours the cancellation and deadline of ctx.
+func (gb *GB) StartCtx(ctx context.Context) error {
 
 	pointer := &dto.RequestResult{}
 
-	err := gb.provider.SendRequest(pointer, "gb_start", nil)
+	err := gb.provider.SendRequestContext(ctx, pointer, "gb_start", nil)
 
 	return err
 }
 
 func (gb *GB) Stop() error {
+	return gb.StopCtx(context.Background())
+}
+
+// StopCtx is like Stop but honours the cancellation and deadline of ctx.
+func (gb *GB) StopCtx(ctx context.Context) error {
 
 	pointer := &dto.RequestResult{}
 
-	err := gb.provider.SendRequest(pointer, "gb_stop", nil)
+	err := gb.provider.SendRequestContext(ctx, pointer, "gb_stop", nil)
 
 	return err
 }
@@ -15,6 +15,7 @@
 package net
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
 	"math/big"
@@ -47,10 +48,15 @@ func NewNet(provider providers.ProviderInterface) *Net {
   Call permissions: Anyone
 */
 func (net *Net) IsListening() (bool, error) {
+	return net.IsListeningCtx(context.Background())
+}
+
+// IsListeningCtx is like IsListening but honours the cancellation and deadline of ctx.
+func (net *Net) IsListeningCtx(ctx context.Context) (bool, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_listening", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_listening", nil)
 
 	if err != nil {
 		return false, err
@@ -74,10 +80,15 @@ func (net *Net) IsListening() (bool, error) {
   Call permissions: Anyone
 */
 func (net *Net) GetPeerCount() (*big.Int, error) {
+	return net.GetPeerCountCtx(context.Background())
+}
+
+// GetPeerCountCtx is like GetPeerCount but honours the cancellation and deadline of ctx.
+func (net *Net) GetPeerCountCtx(ctx context.Context) (*big.Int, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_peerCount", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_peerCount", nil)
 
 	if err != nil {
 		return nil, err
@@ -101,10 +112,15 @@ func (net *Net) GetPeerCount() (*big.Int, error) {
   Call permissions: Anyone
 */
 func (net *Net) GetVersion() (string, error) {
+	return net.GetVersionCtx(context.Background())
+}
+
+// GetVersionCtx is like GetVersion but honours the cancellation and deadline of ctx.
+func (net *Net) GetVersionCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_version", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_version", nil)
 
 	if err != nil {
 		return "", err
@@ -140,10 +156,15 @@ func (net *Net) GetVersion() (string, error) {
   Call permissions: Anyone
 */
 func (net *Net) GetPeers() ([]*dto.PeerInfo, error) {
+	return net.GetPeersCtx(context.Background())
+}
+
+// GetPeersCtx is like GetPeers but honours the cancellation and deadline of ctx.
+func (net *Net) GetPeersCtx(ctx context.Context) ([]*dto.PeerInfo, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "admin_peers", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "admin_peers", nil)
 
 	if err != nil {
 		return nil, err
@@ -166,13 +187,18 @@ func (net *Net) GetPeers() ([]*dto.PeerInfo, error) {
   Call permissions: Anyone
 */
 func (net *Net) AddPeer(url string) (bool, error) {
+	return net.AddPeerCtx(context.Background(), url)
+}
+
+// AddPeerCtx is like AddPeer but honours the cancellation and deadline of ctx.
+func (net *Net) AddPeerCtx(ctx context.Context, url string) (bool, error) {
 
 	pointer := &dto.NetRequestResult{}
 
 	params := make([]string, 1)
 	params[0] = url
 
-	err := net.provider.SendRequest(pointer, "admin_addPeer", params)
+	err := net.provider.SendRequestContext(ctx, pointer, "admin_addPeer", params)
 
 	if err != nil {
 		return false, err
@@ -195,13 +221,18 @@ func (net *Net) AddPeer(url string) (bool, error) {
   Call permissions: Anyone
 */
 func (net *Net) RemovePeer(url string) (bool, error) {
+	return net.RemovePeerCtx(context.Background(), url)
+}
+
+// RemovePeerCtx is like RemovePeer but honours the cancellation and deadline of ctx.
+func (net *Net) RemovePeerCtx(ctx context.Context, url string) (bool, error) {
 
 	pointer := &dto.NetRequestResult{}
 
 	params := make([]string, 1)
 	params[0] = url
 
-	err := net.provider.SendRequest(pointer, "admin_removePeer", params)
+	err := net.provider.SendRequestContext(ctx, pointer, "admin_removePeer", params)
 
 	if err != nil {
 		return false, err
@@ -234,10 +265,15 @@ func (net *Net) RemovePeer(url string) (bool, error) {
   Call permissions: Anyone
 */
 func (net *Net) GetNodeInfo() (*dto.NodeInfo, error) {
+	return net.GetNodeInfoCtx(context.Background())
+}
+
+// GetNodeInfoCtx is like GetNodeInfo but honours the cancellation and deadline of ctx.
+func (net *Net) GetNodeInfoCtx(ctx context.Context) (*dto.NodeInfo, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "admin_nodeInfo", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "admin_nodeInfo", nil)
 
 	if err != nil {
 		return nil, err
@@ -260,10 +296,15 @@ func (net *Net) GetNodeInfo() (*dto.NodeInfo, error) {
   Call permissions: Anyone
 */
 func (net *Net) GetDataDir() (string, error) {
+	return net.GetDataDirCtx(context.Background())
+}
+
+// GetDataDirCtx is like GetDataDir but honours the cancellation and deadline of ctx.
+func (net *Net) GetDataDirCtx(ctx context.Context) (string, error) {
 
 	pointer := &dto.NetRequestResult{}
 
-	err := net.provider.SendRequest(pointer, "admin_datadir", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "admin_datadir", nil)
 
 	if err != nil {
 		return "", err
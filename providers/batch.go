@@ -0,0 +1,119 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/tchain/go-tchain-sdk/providers/util"
+)
+
+// ErrMissingBatchResponse is set on a BatchElem when the node did not answer that element
+var ErrMissingBatchResponse = errors.New("missing response in batch")
+
+// BatchElem is a single request of a batch call
+type BatchElem struct {
+	Method string
+	Params interface{}
+	// Result receives the JSON-RPC response of the request, in the same way
+	// as v in SendRequest, e.g. a *dto.CoreRequestResult
+	Result interface{}
+	// Error is set when the node returned an error for this element, an
+	// *RPCError, in which case Result is left untouched, or when its response
+	// could not be decoded into Result. The transport errors, e.g. a
+	// *TransportError or ErrTimeout, are returned by BatchSendRequest itself
+	// and the elements are left unset.
+	Error error
+}
+
+// BatchProvider is implemented by the providers that can send several
+// requests in a single round trip
+type BatchProvider interface {
+	BatchSendRequest(elems []BatchElem) error
+	BatchSendRequestContext(ctx context.Context, elems []BatchElem) error
+}
+
+// BatchSendRequestContext sends elems as one batch when provider implements
+// BatchProvider, otherwise it falls back to one request per element, which
+// stops at the first transport error.
+func BatchSendRequestContext(ctx context.Context, provider ProviderInterface, elems []BatchElem) error {
+	if batcher, ok := provider.(BatchProvider); ok {
+		return batcher.BatchSendRequestContext(ctx, elems)
+	}
+
+	for i := range elems {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := provider.SendRequestContext(ctx, elems[i].Result, elems[i].Method, elems[i].Params)
+		if err != nil && !isElementError(err) {
+			return err
+		}
+		elems[i].Error = err
+	}
+	return nil
+}
+
+// isElementError reports whether err belongs to a single element of a batch:
+// the node answered it with an error or its response could not be decoded
+func isElementError(err error) bool {
+	var rpcError *RPCError
+	var decodeError *DecodeError
+	return errors.As(err, &rpcError) || errors.As(err, &decodeError)
+}
+
+// newBatch builds the request body of elems, the element i is sent with id i+1
+func newBatch(elems []BatchElem) util.JSONRPCBatch {
+	batch := make(util.JSONRPCBatch, len(elems))
+	for i, elem := range elems {
+		batch[i] = util.JSONRPCObject{Version: util.Version, Method: elem.Method, Params: elem.Params, ID: i + 1}
+	}
+	return batch
+}
+
+// setBatchResults dispatches the responses of a batch built by newBatch to its elements
+func setBatchResults(responses []json.RawMessage, elems []BatchElem) {
+	byID := make(map[int]json.RawMessage, len(responses))
+	for _, raw := range responses {
+		var head struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &head); err != nil {
+			continue
+		}
+		byID[head.ID] = raw
+	}
+
+	for i := range elems {
+		raw, ok := byID[i+1]
+		if !ok {
+			elems[i].Error = ErrMissingBatchResponse
+			continue
+		}
+		elems[i].Error = setBatchResult(raw, elems[i].Result)
+	}
+}
+
+// setBatchResult decodes one response of a batch into result, result is left
+// untouched when the response is an error
+func setBatchResult(raw json.RawMessage, result interface{}) error {
+	if result == nil {
+		var discard json.RawMessage
+		result = &discard
+	}
+	return decodeResponse(raw, result)
+}
@@ -0,0 +1,212 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultMinReconnectBackoff = 500 * time.Millisecond
+	defaultMaxReconnectBackoff = 30 * time.Second
+	minReconnectBackoff        = 10 * time.Millisecond
+	defaultRequestTimeout      = 5 * time.Second
+)
+
+// ConnectionState is the state of the connection of a provider to the node
+type ConnectionState int32
+
+const (
+	// StateConnected - the connection is up, requests are sent to the node
+	StateConnected ConnectionState = iota
+	// StateDisconnected - the connection dropped, the pending requests failed
+	StateDisconnected
+	// StateReconnecting - a new connection is being dialed
+	StateReconnecting
+	// StateClosed - the provider was closed or gave up reconnecting
+	StateClosed
+)
+
+func (state ConnectionState) String() string {
+	switch state {
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnectionStats is the state of the connection of a provider and its load
+type ConnectionStats struct {
+	State ConnectionState
+	// InFlight is the number of requests waiting for a response
+	InFlight int
+	// Subscriptions is the number of active subscriptions
+	Subscriptions int
+}
+
+// StatsProvider is implemented by the providers which keep a connection open
+type StatsProvider interface {
+	Stats() ConnectionStats
+}
+
+// StateCallback is called on every change of the connection state, err is the
+// cause of the change when there is one. It's run by the reader of the
+// connection, so it must not block.
+type StateCallback func(state ConnectionState, err error)
+
+// reconnectConfig sets how a stream dials again a dropped connection
+type reconnectConfig struct {
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+}
+
+// connectionConfig is the configuration of the providers which keep a
+// connection open, the WebSocket and the IPC providers
+type connectionConfig struct {
+	dialer        *websocket.Dialer
+	header        http.Header
+	reconnect     *reconnectConfig
+	onStateChange StateCallback
+
+	requestTimeout time.Duration
+	maxInFlight    int
+}
+
+func defaultConnectionConfig() *connectionConfig {
+	return &connectionConfig{
+		dialer: websocket.DefaultDialer,
+		header: http.Header{},
+		reconnect: &reconnectConfig{
+			minBackoff: defaultMinReconnectBackoff,
+			maxBackoff: defaultMaxReconnectBackoff,
+		},
+		requestTimeout: defaultRequestTimeout,
+	}
+}
+
+// ConnectionOption configures a WebSocketProvider or an IPCProvider, see
+// NewWebSocketProviderWithOptions and NewIPCProviderWithOptions
+type ConnectionOption func(config *connectionConfig)
+
+func newConnectionConfig(opts []ConnectionOption) *connectionConfig {
+	config := defaultConnectionConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// streamConfig returns the configuration of the stream which dials with redial
+func (config *connectionConfig) streamConfig(redial func(ctx context.Context) (Codec, error)) streamConfig {
+	return streamConfig{
+		redial:         redial,
+		reconnect:      config.reconnect,
+		onStateChange:  config.onStateChange,
+		requestTimeout: config.requestTimeout,
+		maxInFlight:    config.maxInFlight,
+	}
+}
+
+// WithWebSocketDialer sets the dialer used to open the WebSocket connections
+func WithWebSocketDialer(dialer *websocket.Dialer) ConnectionOption {
+	return func(config *connectionConfig) {
+		config.dialer = dialer
+	}
+}
+
+// WithWebSocketHeader sets the HTTP headers sent in the handshake of the WebSocket connections
+func WithWebSocketHeader(header http.Header) ConnectionOption {
+	return func(config *connectionConfig) {
+		config.header = header
+	}
+}
+
+// WithReconnectBackoff sets the delay before the first reconnection attempt,
+// it's doubled after every failed attempt up to max. min is at least 10ms and
+// max at least min.
+func WithReconnectBackoff(min time.Duration, max time.Duration) ConnectionOption {
+	if min < minReconnectBackoff {
+		min = minReconnectBackoff
+	}
+	if max < min {
+		max = min
+	}
+	return func(config *connectionConfig) {
+		if config.reconnect == nil {
+			config.reconnect = &reconnectConfig{}
+		}
+		config.reconnect.minBackoff = min
+		config.reconnect.maxBackoff = max
+	}
+}
+
+// WithMaxReconnectAttempts stops reconnecting after n failed attempts in a
+// row, 0 means that the provider never gives up
+func WithMaxReconnectAttempts(n int) ConnectionOption {
+	return func(config *connectionConfig) {
+		if config.reconnect == nil {
+			config.reconnect = &reconnectConfig{
+				minBackoff: defaultMinReconnectBackoff,
+				maxBackoff: defaultMaxReconnectBackoff,
+			}
+		}
+		config.reconnect.maxAttempts = n
+	}
+}
+
+// WithoutReconnect disables the reconnection, the provider is closed as soon
+// as its connection drops
+func WithoutReconnect() ConnectionOption {
+	return func(config *connectionConfig) {
+		config.reconnect = nil
+	}
+}
+
+// WithStateCallback sets the callback notified of the connection state changes
+func WithStateCallback(callback StateCallback) ConnectionOption {
+	return func(config *connectionConfig) {
+		config.onStateChange = callback
+	}
+}
+
+// WithRequestTimeout sets how long a request waits for its response when its
+// context has no deadline, 5 seconds by default for the WebSocket provider and
+// no timeout for the IPC provider. 0 disables the timeout, the deadline of the
+// context of a request always takes precedence.
+func WithRequestTimeout(timeout time.Duration) ConnectionOption {
+	return func(config *connectionConfig) {
+		config.requestTimeout = timeout
+	}
+}
+
+// WithMaxInFlight bounds the number of requests waiting for a response, the
+// next requests wait for a free slot. 0, the default, means unbounded.
+func WithMaxInFlight(n int) ConnectionOption {
+	return func(config *connectionConfig) {
+		config.maxInFlight = n
+	}
+}
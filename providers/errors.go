@@ -0,0 +1,167 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The errors reported by the node for a rejected transaction, an *RPCError
+// matches them with errors.Is according to its message
+var (
+	ErrNonceTooLow            = errors.New("nonce too low")
+	ErrNonceTooHigh           = errors.New("nonce too high")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrAlreadyKnown           = errors.New("already known")
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+	ErrIntrinsicGas           = errors.New("intrinsic gas too low")
+	ErrGasLimit               = errors.New("exceeds block gas limit")
+	ErrExecutionReverted      = errors.New("execution reverted")
+)
+
+// rpcErrorMessages are the messages of the node matched by each error above
+var rpcErrorMessages = map[error][]string{
+	ErrNonceTooLow:            {"nonce too low"},
+	ErrNonceTooHigh:           {"nonce too high"},
+	ErrInsufficientFunds:      {"insufficient funds"},
+	ErrAlreadyKnown:           {"already known", "known transaction"},
+	ErrReplacementUnderpriced: {"replacement transaction underpriced"},
+	ErrIntrinsicGas:           {"intrinsic gas too low"},
+	ErrGasLimit:               {"exceeds block gas limit"},
+	ErrExecutionReverted:      {"execution reverted"},
+}
+
+// RPCError is an error object returned by the node in a JSON-RPC response
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// ErrorObject is a jsonrpc error
+type ErrorObject = RPCError
+
+// Error implements error interface
+func (e *RPCError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("json-rpc error %d", e.Code)
+	}
+	return e.Message
+}
+
+// ErrorCode returns the JSON-RPC error code
+func (e *RPCError) ErrorCode() int {
+	return e.Code
+}
+
+// ErrorData returns the data field of the error, e.g. the revert data of a call
+func (e *RPCError) ErrorData() interface{} {
+	return e.Data
+}
+
+// Is reports whether the message of the node is the one of target, e.g.
+// errors.Is(err, ErrNonceTooLow)
+func (e *RPCError) Is(target error) bool {
+	messages, ok := rpcErrorMessages[target]
+	if !ok {
+		return false
+	}
+
+	message := strings.ToLower(e.Message)
+	for _, m := range messages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPStatusError is returned when the HTTP response has a status other than 2xx
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPStatusError) Error() string {
+	if len(e.Body) == 0 {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s", e.Status, e.Body)
+}
+
+// TransportError is returned when the request can't be sent to the node or
+// its response can't be read, the request may be retried
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return "transport error: " + e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when the response of the node is not the expected JSON
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return "invalid response: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeResponse checks the JSON-RPC response body, it returns the *RPCError
+// of the response or decodes it into v
+func decodeResponse(body []byte, v interface{}) error {
+	var head struct {
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &head); err != nil {
+		return &DecodeError{Body: body, Err: err}
+	}
+	if head.Error != nil {
+		return head.Error
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{Body: body, Err: err}
+	}
+	return nil
+}
+
+// decodeBatchResponse decodes the responses of a batch request, a node which
+// rejects the whole batch answers with a single error object instead
+func decodeBatchResponse(body []byte) ([]json.RawMessage, error) {
+	var responses []json.RawMessage
+	if err := json.Unmarshal(body, &responses); err == nil {
+		return responses, nil
+	}
+
+	var single json.RawMessage
+	if err := decodeResponse(body, &single); err != nil {
+		return nil, err
+	}
+	return nil, &DecodeError{Body: body, Err: errors.New("batch response is not an array")}
+}
@@ -0,0 +1,198 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// HeaderFunc returns headers computed for a request, e.g. a signature
+type HeaderFunc func(ctx context.Context) (http.Header, error)
+
+type httpConfig struct {
+	timeout    time.Duration
+	secure     bool
+	client     *http.Client
+	tlsConfig  *tls.Config
+	header     http.Header
+	headerFunc HeaderFunc
+	token      TokenSource
+	gzip       bool
+
+	err error
+}
+
+// tlsClientConfig returns the TLS configuration, created on the first TLS option
+func (config *httpConfig) tlsClientConfig() *tls.Config {
+	if config.tlsConfig == nil {
+		config.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	config.secure = true
+	return config.tlsConfig
+}
+
+// newHTTPClient returns the client of the configuration, with the TLS
+// configuration set on its transport
+func (config *httpConfig) newHTTPClient() (*http.Client, error) {
+	client := config.client
+	if client == nil {
+		client = &http.Client{Timeout: config.timeout}
+	}
+	if config.tlsConfig == nil {
+		return client, nil
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if client.Transport == nil {
+		transport, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return nil, errors.New("the TLS options need a client with an *http.Transport")
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = config.tlsConfig
+
+	withTLS := *client
+	withTLS.Transport = transport
+	return &withTLS, nil
+}
+
+// HTTPOption configures an HTTPProvider, see NewHTTPProviderWithOptions
+type HTTPOption func(config *httpConfig)
+
+// WithHTTPTimeout sets the time limit of a request, 10 seconds by default.
+// It's ignored with WithHTTPClient.
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(config *httpConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithHTTPClient sends the requests with client instead of a new one
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(config *httpConfig) {
+		config.client = client
+	}
+}
+
+// WithSecure sends the requests over https
+func WithSecure() HTTPOption {
+	return func(config *httpConfig) {
+		config.secure = true
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the connections, and implies WithSecure
+func WithTLSConfig(tlsConfig *tls.Config) HTTPOption {
+	return func(config *httpConfig) {
+		config.tlsConfig = tlsConfig.Clone()
+		config.secure = true
+	}
+}
+
+// WithClientCertificate presents certificate to the node, for mutual TLS
+func WithClientCertificate(certificate tls.Certificate) HTTPOption {
+	return func(config *httpConfig) {
+		tlsConfig := config.tlsClientConfig()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, certificate)
+	}
+}
+
+// WithClientCertificateFile is WithClientCertificate with a PEM encoded
+// certificate and key read from files
+func WithClientCertificateFile(certFile string, keyFile string) HTTPOption {
+	return func(config *httpConfig) {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			config.err = fmt.Errorf("can't load the client certificate: %w", err)
+			return
+		}
+		WithClientCertificate(certificate)(config)
+	}
+}
+
+// WithRootCAs sets the certificate authorities which the certificate of the
+// node is checked against, instead of the ones of the system
+func WithRootCAs(pool *x509.CertPool) HTTPOption {
+	return func(config *httpConfig) {
+		config.tlsClientConfig().RootCAs = pool
+	}
+}
+
+// WithRootCAFile adds the PEM encoded certificates of caFile to the
+// certificate authorities of the node, see WithRootCAs
+func WithRootCAFile(caFile string) HTTPOption {
+	return func(config *httpConfig) {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			config.err = fmt.Errorf("can't read the root CAs: %w", err)
+			return
+		}
+
+		tlsConfig := config.tlsClientConfig()
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+			config.err = fmt.Errorf("no certificate found in %s", caFile)
+		}
+	}
+}
+
+// WithHTTPHeader adds a header to every request
+func WithHTTPHeader(key string, value string) HTTPOption {
+	return func(config *httpConfig) {
+		config.header.Add(key, value)
+	}
+}
+
+// WithHTTPHeaderFunc adds the headers returned by f to every request, after
+// the static ones. An error of f fails the request.
+func WithHTTPHeaderFunc(f HeaderFunc) HTTPOption {
+	return func(config *httpConfig) {
+		config.headerFunc = f
+	}
+}
+
+// WithTokenSource sets the Authorization header of every request with the
+// token of source. Wrap source with NewCachedTokenSource to fetch a token only
+// when the last one expires.
+func WithTokenSource(source TokenSource) HTTPOption {
+	return func(config *httpConfig) {
+		config.token = source
+	}
+}
+
+// WithBearerToken sets the Authorization header of every request to a bearer token
+func WithBearerToken(token string) HTTPOption {
+	return WithTokenSource(StaticTokenSource(token))
+}
+
+// WithGzip compresses the bodies of the requests and asks for compressed responses
+func WithGzip() HTTPOption {
+	return func(config *httpConfig) {
+		config.gzip = true
+	}
+}
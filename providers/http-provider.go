@@ -15,14 +15,17 @@
 package providers
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"strings"
 	"time"
 
-	"encoding/json"
-
 	"github.com/tchain/go-tchain-sdk/providers/util"
 )
 
@@ -31,6 +34,11 @@ type HTTPProvider struct {
 	timeout int32
 	secure  bool
 	client  *http.Client
+
+	header     http.Header
+	headerFunc HeaderFunc
+	token      TokenSource
+	gzip       bool
 }
 
 func NewHTTPProvider(address string, timeout int32, secure bool) *HTTPProvider {
@@ -49,43 +57,202 @@ func NewHTTPProviderWithClient(address string, timeout int32, secure bool, clien
 	return provider
 }
 
+// NewHTTPProviderWithOptions returns a provider of the node at address, a
+// host:port, configured with opts: headers, authentication, TLS and compression
+func NewHTTPProviderWithOptions(address string, opts ...HTTPOption) (*HTTPProvider, error) {
+	config := &httpConfig{
+		timeout: defaultHTTPTimeout,
+		header:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.err != nil {
+		return nil, config.err
+	}
+
+	client, err := config.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewHTTPProviderWithClient(address, int32(client.Timeout/time.Second), config.secure, client)
+	provider.header = config.header
+	provider.headerFunc = config.headerFunc
+	provider.token = config.token
+	provider.gzip = config.gzip
+
+	return provider, nil
+}
+
 func (provider HTTPProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+func (provider HTTPProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
 
 	bodyString := util.JSONRPCObject{Version: util.Version, Method: method, Params: params, ID: rand.Intn(100)}
 
+	bodyBytes, err := provider.post(ctx, bodyString.AsJsonString())
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(bodyBytes, v)
+
+}
+
+func (provider HTTPProvider) BatchSendRequest(elems []BatchElem) error {
+	return provider.BatchSendRequestContext(context.Background(), elems)
+}
+
+// BatchSendRequestContext sends all elems in a single HTTP request
+func (provider HTTPProvider) BatchSendRequestContext(ctx context.Context, elems []BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	bodyBytes, err := provider.post(ctx, newBatch(elems).AsJsonString())
+	if err != nil {
+		return err
+	}
+
+	responses, err := decodeBatchResponse(bodyBytes)
+	if err != nil {
+		return err
+	}
+
+	setBatchResults(responses, elems)
+	return nil
+}
+
+func (provider HTTPProvider) post(ctx context.Context, bodyString string) ([]byte, error) {
+
+	bodyBytes, token, err := provider.do(ctx, bodyString)
+
+	// the cached token was revoked before its expiry, try once with a new one
+	if statusError, ok := err.(*HTTPStatusError); ok && statusError.StatusCode == http.StatusUnauthorized {
+		if cached, ok := provider.token.(*cachedTokenSource); ok && token != nil {
+			cached.invalidate(token)
+			bodyBytes, _, err = provider.do(ctx, bodyString)
+		}
+	}
+
+	return bodyBytes, err
+}
+
+// do sends a request, it returns the token it was authorized with
+func (provider HTTPProvider) do(ctx context.Context, bodyString string) ([]byte, *Token, error) {
+
 	prefix := "http://"
 	if provider.secure {
 		prefix = "https://"
 	}
 
-	body := strings.NewReader(bodyString.AsJsonString())
-	req, err := http.NewRequest("POST", prefix+provider.address, body)
+	var body io.Reader = strings.NewReader(bodyString)
+	if provider.gzip {
+		compressed, err := gzipBody(bodyString)
+		if err != nil {
+			return nil, nil, err
+		}
+		body = compressed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", prefix+provider.address, body)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
+	if provider.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	token, err := provider.setHeaders(ctx, req.Header)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	resp, err := provider.client.Do(req)
 
 	if err != nil {
-		return err
+		return nil, token, &TransportError{Err: err}
 	}
 
 	defer resp.Body.Close()
 
-	var bodyBytes []byte
+	bodyBytes, err := readBody(resp)
+	if err != nil {
+		return nil, token, &TransportError{Err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, token, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: bodyBytes}
+	}
+
+	return bodyBytes, token, nil
+}
+
+// setHeaders sets the configured headers, the Authorization header and the
+// headers of ctx, in this order
+func (provider HTTPProvider) setHeaders(ctx context.Context, header http.Header) (*Token, error) {
+	for key, values := range provider.header {
+		header[key] = values
+	}
+
+	if provider.headerFunc != nil {
+		dynamic, err := provider.headerFunc(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't compute the headers: %w", err)
+		}
+		for key, values := range dynamic {
+			header[key] = values
+		}
+	}
 
-	if resp.StatusCode == 200 {
-		bodyBytes, err = ioutil.ReadAll(resp.Body)
+	var token *Token
+	if provider.token != nil {
+		var err error
+		token, err = provider.token.Token(ctx)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("can't get the token: %w", err)
 		}
+		header.Set("Authorization", token.Type()+" "+token.AccessToken)
 	}
 
-	return json.Unmarshal(bodyBytes, v)
+	for key, values := range headerFromContext(ctx) {
+		header[key] = values
+	}
 
+	return token, nil
+}
+
+func gzipBody(bodyString string) (io.Reader, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(bodyString)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return &buffer, nil
+}
+
+// readBody reads the body of resp, decompressed when it's gzip encoded
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
 }
 
 func (provider HTTPProvider) Close() error { return nil }
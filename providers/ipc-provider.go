@@ -15,54 +15,258 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
-	"math/rand"
 	"net"
 	"path/filepath"
-
-	"log"
-
-	"github.com/tchain/go-tchain-sdk/providers/util"
+	"sync"
 )
 
+// IPCProvider keeps a single connection to the unix socket of the node, the
+// concurrent requests are multiplexed on it and matched by their id
 type IPCProvider struct {
 	endpoint string
+	config   *connectionConfig
+	conn     *ipcConnection
 }
 
+type ipcConnection struct {
+	lock   sync.Mutex
+	stream *stream
+	closed bool
+	// dialing is the dial in progress
+	dialing *ipcDial
+}
+
+// ipcDial is a dial of the socket shared by the concurrent requests
+type ipcDial struct {
+	done chan struct{}
+	// err is the error of the dial returned to the waiting requests, it's
+	// set before done is closed
+	err error
+}
+
+// NewIPCProvider returns a provider for the socket at endpoint, the
+// connection is opened by the first request. The requests have no timeout
+// besides the deadline of their context.
 func NewIPCProvider(endpoint string) *IPCProvider {
 	provider := new(IPCProvider)
 	provider.endpoint, _ = filepath.Abs(endpoint)
+	provider.config = newIPCConnectionConfig(nil)
+	provider.conn = new(ipcConnection)
 	return provider
 }
 
-func (provider IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+// NewIPCProviderWithOptions connects to the socket at endpoint. Unless
+// WithoutReconnect is given, the connection is dialed again when the node
+// restarts and the active subscriptions are created again. Unlike the
+// WebSocket provider, the requests have no default timeout, see
+// WithRequestTimeout.
+func NewIPCProviderWithOptions(endpoint string, opts ...ConnectionOption) (*IPCProvider, error) {
+	provider := new(IPCProvider)
+	provider.endpoint, _ = filepath.Abs(endpoint)
+	provider.config = newIPCConnectionConfig(opts)
+	provider.conn = new(ipcConnection)
 
-	bodyString := util.JSONRPCObject{Version: util.Version, Method: method, Params: params, ID: rand.Intn(100)}
+	if _, err := provider.stream(context.Background()); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
 
-	client, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: provider.endpoint, Net: "unix"})
+func (provider IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
 
+func (provider IPCProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	s, err := provider.stream(ctx)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
-	defer client.Close()
+	return s.CallInto(ctx, v, method, params)
+}
 
-	encoder := json.NewEncoder(client)
-	decoder := json.NewDecoder(client)
+func (provider IPCProvider) BatchSendRequest(elems []BatchElem) error {
+	return provider.BatchSendRequestContext(context.Background(), elems)
+}
 
-	if err := encoder.Encode(bodyString); err != nil {
-		log.Println(err)
+// BatchSendRequestContext sends all elems in a single message over the socket
+func (provider IPCProvider) BatchSendRequestContext(ctx context.Context, elems []BatchElem) error {
+	s, err := provider.stream(ctx)
+	if err != nil {
 		return err
 	}
 
-	if err := decoder.Decode(v); err != nil {
-		log.Println(err)
-		return err
+	return s.BatchCallContext(ctx, elems)
+}
+
+// Subscribe creates a subscription in the given namespace, e.g. "core", and
+// delivers its notifications to channel, see SubscriptionProvider
+func (provider IPCProvider) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error) {
+	s, err := provider.stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Subscribe(ctx, namespace, channel, args...)
+}
+
+// State returns the current state of the connection, StateDisconnected
+// before the first request
+func (provider IPCProvider) State() ConnectionState {
+	provider.conn.lock.Lock()
+	defer provider.conn.lock.Unlock()
+
+	if provider.conn.closed {
+		return StateClosed
 	}
+	if provider.conn.stream == nil {
+		return StateDisconnected
+	}
+	return provider.conn.stream.State()
+}
+
+// Stats returns the state of the connection and its load
+func (provider IPCProvider) Stats() ConnectionStats {
+	provider.conn.lock.Lock()
+	s := provider.conn.stream
+	provider.conn.lock.Unlock()
+
+	if s == nil {
+		return ConnectionStats{State: provider.State()}
+	}
+	stats := s.stats()
+	stats.State = provider.State()
+	return stats
+}
 
+func (provider IPCProvider) Close() error {
+	provider.conn.lock.Lock()
+	defer provider.conn.lock.Unlock()
+
+	provider.conn.closed = true
+	if provider.conn.stream != nil {
+		return provider.conn.stream.Close()
+	}
 	return nil
+}
+
+// newIPCConnectionConfig is the configuration of the IPC providers, the
+// requests over the socket have no timeout unless WithRequestTimeout is given
+func newIPCConnectionConfig(opts []ConnectionOption) *connectionConfig {
+	return newConnectionConfig(append([]ConnectionOption{WithRequestTimeout(0)}, opts...))
+}
 
+// stream returns the connection to the node, it's opened again when the
+// previous one gave up reconnecting. The socket is dialed by a single request
+// without holding the lock, the other requests wait for the dial and share
+// its error.
+func (provider IPCProvider) stream(ctx context.Context) (*stream, error) {
+	for {
+		s, dialing, owner, err := provider.conn.current()
+		if s != nil || err != nil {
+			return s, err
+		}
+		if owner {
+			return provider.connect(ctx, dialing)
+		}
+
+		select {
+		case <-dialing.done:
+			if dialing.err != nil {
+				return nil, dialing.err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 }
 
-func (provider IPCProvider) Close() error { return nil }
+// connect dials the socket for the requests waiting for dialing
+func (provider IPCProvider) connect(ctx context.Context, dialing *ipcDial) (*stream, error) {
+	codec, err := provider.dial(ctx)
+
+	conn := provider.conn
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	defer close(dialing.done)
+	conn.dialing = nil
+
+	if err != nil {
+		err = &TransportError{Err: err}
+		// the waiting requests dial again when the context of this one ended
+		if ctx.Err() == nil {
+			dialing.err = err
+		}
+		return nil, err
+	}
+	if conn.closed {
+		codec.Close()
+		dialing.err = ErrProviderClosed
+		return nil, ErrProviderClosed
+	}
+	conn.stream = newStream(codec, provider.config.streamConfig(provider.dial))
+	return conn.stream, nil
+}
+
+// current returns the open stream of the connection, or the dial in progress.
+// When there is neither, a dial is started and owner is true, the caller
+// dials and the next callers wait for it.
+func (conn *ipcConnection) current() (s *stream, dialing *ipcDial, owner bool, err error) {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	if conn.closed {
+		return nil, nil, false, ErrProviderClosed
+	}
+	if conn.stream != nil && conn.stream.State() != StateClosed {
+		return conn.stream, nil, false, nil
+	}
+	if conn.dialing != nil {
+		return nil, conn.dialing, false, nil
+	}
+	conn.dialing = &ipcDial{done: make(chan struct{})}
+	return nil, conn.dialing, true, nil
+}
+
+func (provider IPCProvider) dial(ctx context.Context) (Codec, error) {
+	var dialer net.Dialer
+	client, err := dialer.DialContext(ctx, "unix", provider.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipcCodec{
+		conn:    client,
+		decoder: json.NewDecoder(client),
+	}, nil
+}
+
+// ipcCodec reads and writes the JSON messages of the socket
+type ipcCodec struct {
+	conn    net.Conn
+	decoder *json.Decoder
+
+	writeLock sync.Mutex
+}
+
+func (c *ipcCodec) Close() error {
+	return c.conn.Close()
+}
+
+func (c *ipcCodec) Write(b []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_, err := c.conn.Write(append(b, '\n'))
+	return err
+}
+
+func (c *ipcCodec) Read(b []byte) ([]byte, error) {
+	var msg json.RawMessage
+	if err := c.decoder.Decode(&msg); err != nil {
+		return nil, err
+	}
+	return append(b, msg...), nil
+}
@@ -0,0 +1,286 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histogram,
+// the default buckets of Prometheus
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MetricsCollector receives the measures of the Metrics middleware, it's
+// implemented by RPCMetrics or by an adapter to a metrics library
+type MetricsCollector interface {
+	ObserveRequest(endpoint string, method string, duration time.Duration, err error)
+}
+
+// Metrics measures the requests sent to endpoint, a label which tells the
+// providers of a MultiProvider apart
+func Metrics(collector MetricsCollector, endpoint string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			start := time.Now()
+			err := next(ctx, v, method, params)
+			collector.ObserveRequest(endpoint, method, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// ErrorType classifies err for the metrics: rpc, http, transport, decode,
+// timeout, canceled or other
+func ErrorType(err error) string {
+	var rpcError *RPCError
+	var statusError *HTTPStatusError
+	var transportError *TransportError
+	var decodeError *DecodeError
+
+	switch {
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &rpcError):
+		return "rpc"
+	case errors.As(err, &statusError):
+		return "http"
+	case errors.As(err, &transportError):
+		return "transport"
+	case errors.As(err, &decodeError):
+		return "decode"
+	}
+	return "other"
+}
+
+type requestKey struct {
+	endpoint string
+	method   string
+}
+
+type requestMetrics struct {
+	count   uint64
+	errors  map[string]uint64
+	buckets []uint64
+	sum     float64
+}
+
+// RPCMetrics aggregates the measures of the RPC traffic and exposes them in
+// the text format of Prometheus:
+//
+//	<namespace>_rpc_requests_total{endpoint,method}
+//	<namespace>_rpc_errors_total{endpoint,method,type}
+//	<namespace>_rpc_request_duration_seconds{endpoint,method}
+//	<namespace>_rpc_in_flight{endpoint}
+//	<namespace>_subscriptions{endpoint}
+type RPCMetrics struct {
+	namespace string
+	buckets   []float64
+
+	lock        sync.Mutex
+	requests    map[requestKey]*requestMetrics
+	connections map[string]StatsProvider
+}
+
+// NewRPCMetrics returns an empty set of metrics whose names start with
+// namespace, the latency histogram has DefaultBuckets when buckets is nil
+func NewRPCMetrics(namespace string, buckets []float64) *RPCMetrics {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &RPCMetrics{
+		namespace:   namespace,
+		buckets:     buckets,
+		requests:    make(map[requestKey]*requestMetrics),
+		connections: make(map[string]StatsProvider),
+	}
+}
+
+// ObserveRequest records a request of method sent to endpoint
+func (metrics *RPCMetrics) ObserveRequest(endpoint string, method string, duration time.Duration, err error) {
+	metrics.lock.Lock()
+	defer metrics.lock.Unlock()
+
+	key := requestKey{endpoint, method}
+	request, ok := metrics.requests[key]
+	if !ok {
+		request = &requestMetrics{errors: make(map[string]uint64), buckets: make([]uint64, len(metrics.buckets))}
+		metrics.requests[key] = request
+	}
+
+	seconds := duration.Seconds()
+	request.count++
+	request.sum += seconds
+	for i, bound := range metrics.buckets {
+		if seconds <= bound {
+			request.buckets[i]++
+		}
+	}
+	if err != nil {
+		request.errors[ErrorType(err)]++
+	}
+}
+
+// AddConnection reports the in-flight requests and the subscriptions of the
+// connection of provider, e.g. a WebSocketProvider, under endpoint
+func (metrics *RPCMetrics) AddConnection(endpoint string, provider StatsProvider) {
+	metrics.lock.Lock()
+	metrics.connections[endpoint] = provider
+	metrics.lock.Unlock()
+}
+
+// WriteTo writes the metrics to w in the text format of Prometheus
+func (metrics *RPCMetrics) WriteTo(w io.Writer) (int64, error) {
+	metrics.lock.Lock()
+	defer metrics.lock.Unlock()
+
+	keys := make([]requestKey, 0, len(metrics.requests))
+	for key := range metrics.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	endpoints := make([]string, 0, len(metrics.connections))
+	for endpoint := range metrics.connections {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	out := &countingWriter{w: bufio.NewWriter(w)}
+
+	name := metrics.name("rpc_requests_total")
+	out.header(name, "counter", "Number of JSON-RPC requests sent.")
+	for _, key := range keys {
+		out.sample(name, labels("endpoint", key.endpoint, "method", key.method), float64(metrics.requests[key].count))
+	}
+
+	name = metrics.name("rpc_errors_total")
+	out.header(name, "counter", "Number of failed JSON-RPC requests by type of error.")
+	for _, key := range keys {
+		request := metrics.requests[key]
+		types := make([]string, 0, len(request.errors))
+		for errorType := range request.errors {
+			types = append(types, errorType)
+		}
+		sort.Strings(types)
+		for _, errorType := range types {
+			out.sample(name, labels("endpoint", key.endpoint, "method", key.method, "type", errorType), float64(request.errors[errorType]))
+		}
+	}
+
+	name = metrics.name("rpc_request_duration_seconds")
+	out.header(name, "histogram", "Latency of the JSON-RPC requests.")
+	for _, key := range keys {
+		request := metrics.requests[key]
+		for i, bound := range metrics.buckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			out.sample(name+"_bucket", labels("endpoint", key.endpoint, "method", key.method, "le", le), float64(request.buckets[i]))
+		}
+		out.sample(name+"_bucket", labels("endpoint", key.endpoint, "method", key.method, "le", "+Inf"), float64(request.count))
+		out.sample(name+"_sum", labels("endpoint", key.endpoint, "method", key.method), request.sum)
+		out.sample(name+"_count", labels("endpoint", key.endpoint, "method", key.method), float64(request.count))
+	}
+
+	if len(endpoints) > 0 {
+		stats := make([]ConnectionStats, len(endpoints))
+		for i, endpoint := range endpoints {
+			stats[i] = metrics.connections[endpoint].Stats()
+		}
+
+		name = metrics.name("rpc_in_flight")
+		out.header(name, "gauge", "Number of JSON-RPC requests waiting for a response.")
+		for i, endpoint := range endpoints {
+			out.sample(name, labels("endpoint", endpoint), float64(stats[i].InFlight))
+		}
+
+		name = metrics.name("subscriptions")
+		out.header(name, "gauge", "Number of active subscriptions.")
+		for i, endpoint := range endpoints {
+			out.sample(name, labels("endpoint", endpoint), float64(stats[i].Subscriptions))
+		}
+	}
+
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	return out.n, out.err
+}
+
+// ServeHTTP serves the metrics to the scrapes of Prometheus
+func (metrics *RPCMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteTo(w)
+}
+
+func (metrics *RPCMetrics) name(name string) string {
+	if metrics.namespace == "" {
+		return name
+	}
+	return metrics.namespace + "_" + name
+}
+
+// labels formats the pairs of label names and values
+func labels(pairs ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+`="`+escaper.Replace(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// countingWriter keeps the first error and the number of bytes written
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (out *countingWriter) printf(format string, args ...interface{}) {
+	if out.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(out.w, format, args...)
+	out.n += int64(n)
+	out.err = err
+}
+
+func (out *countingWriter) header(name string, kind string, help string) {
+	out.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (out *countingWriter) sample(name string, labels string, value float64) {
+	out.printf("%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
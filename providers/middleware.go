@@ -0,0 +1,417 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Handler sends a request, as ProviderInterface.SendRequestContext
+type Handler func(ctx context.Context, v interface{}, method string, params interface{}) error
+
+// Middleware wraps the handler of the next middleware, or of the provider
+type Middleware func(next Handler) Handler
+
+// MiddlewareProvider sends the requests of a provider through a chain of middlewares
+type MiddlewareProvider struct {
+	provider ProviderInterface
+	handler  Handler
+}
+
+// NewMiddlewareProvider wraps provider with middlewares, the first one is
+// the outermost: it sees the request first and the response last.
+//
+// The batches are sent as sequential requests, so that every request goes
+// through the middlewares.
+func NewMiddlewareProvider(provider ProviderInterface, middlewares ...Middleware) *MiddlewareProvider {
+	handler := Handler(provider.SendRequestContext)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return &MiddlewareProvider{
+		provider: provider,
+		handler:  handler,
+	}
+}
+
+func (provider *MiddlewareProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+func (provider *MiddlewareProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.handler(ctx, v, method, params)
+}
+
+// Subscribe creates the subscription on the wrapped provider, the
+// notifications don't go through the middlewares
+func (provider *MiddlewareProvider) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error) {
+	p, ok := provider.provider.(SubscriptionProvider)
+	if !ok {
+		return nil, ErrNotificationsUnsupported
+	}
+	return p.Subscribe(ctx, namespace, channel, args...)
+}
+
+func (provider *MiddlewareProvider) Close() error {
+	return provider.provider.Close()
+}
+
+// idempotentMethods are the read methods which can be sent again safely
+var idempotentMethods = map[string]bool{
+	"core_accounts":                               true,
+	"core_blockNumber":                            true,
+	"core_call":                                   true,
+	"core_chainId":                                true,
+	"core_estimateGas":                            true,
+	"core_gasPrice":                               true,
+	"core_generating":                             true,
+	"core_generator":                              true,
+	"core_getBalance":                             true,
+	"core_getBlockByHash":                         true,
+	"core_getBlockByNumber":                       true,
+	"core_getBlockTransactionCountByHash":         true,
+	"core_getBlockTransactionCountByNumber":       true,
+	"core_getCanTrust":                            true,
+	"core_getCode":                                true,
+	"core_getLogs":                                true,
+	"core_getProof":                               true,
+	"core_getRawTransactionByBlockHashAndIndex":   true,
+	"core_getRawTransactionByBlockNumberAndIndex": true,
+	"core_getRawTransactionByHash":                true,
+	"core_getStorageAt":                           true,
+	"core_getTransactionByBlockHashAndIndex":      true,
+	"core_getTransactionByBlockNumberAndIndex":    true,
+	"core_getTransactionByHash":                   true,
+	"core_getTransactionCount":                    true,
+	"core_getTransactionReceipt":                  true,
+	"core_hashrate":                               true,
+	"core_pendingTransactions":                    true,
+	"core_protocolVersion":                        true,
+	"core_syncing":                                true,
+	"net_listening":                               true,
+	"net_peerCount":                               true,
+	"net_version":                                 true,
+	"txpool_content":                              true,
+	"txpool_inspect":                              true,
+	"txpool_status":                               true,
+	"debug_dumpBlock":                             true,
+	"debug_getBlockRlp":                           true,
+	"debug_printBlock":                            true,
+	"admin_datadir":                               true,
+	"admin_nodeInfo":                              true,
+	"admin_peers":                                 true,
+}
+
+// IsIdempotent reports whether method only reads the state of the node, so
+// that it can be retried
+func IsIdempotent(method string) bool {
+	return idempotentMethods[method]
+}
+
+// RetryPolicy configures the Retry middleware, the zero values take the defaults
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, the first one included, 3 by default
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, doubled for every next one, 100ms by default
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, 2s by default
+	MaxBackoff time.Duration
+	// Idempotent selects the methods which are retried, IsIdempotent by default
+	Idempotent func(method string) bool
+}
+
+// Retry sends again the requests of the idempotent methods which failed
+// because the node couldn't be reached or was unavailable. The errors answered
+// by the node, e.g. *RPCError, are never retried.
+func Retry(policy RetryPolicy) Middleware {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = 100 * time.Millisecond
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 2 * time.Second
+	}
+	if policy.Idempotent == nil {
+		policy.Idempotent = IsIdempotent
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			if !policy.Idempotent(method) {
+				return next(ctx, v, method, params)
+			}
+
+			backoff := policy.MinBackoff
+			for attempt := 1; ; attempt++ {
+				err := next(ctx, v, method, params)
+				if err == nil || attempt >= policy.MaxAttempts || !isEndpointFailure(err) {
+					return err
+				}
+
+				timer := time.NewTimer(backoff)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				}
+				if backoff *= 2; backoff > policy.MaxBackoff {
+					backoff = policy.MaxBackoff
+				}
+			}
+		}
+	}
+}
+
+// Logger receives the lines of the Logging middleware, *log.Logger implements it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+const redacted = "[REDACTED]"
+
+// LogConfig configures the Logging middleware
+type LogConfig struct {
+	// Logger receives the lines, the standard logger of the log package by default
+	Logger Logger
+	// RedactMethods are the methods whose params are never logged, the
+	// methods of the personal namespace are always redacted
+	RedactMethods []string
+	// RedactFields are the fields of the params objects whose values are
+	// replaced, case insensitive, "password" and "privateKey" are always redacted
+	RedactFields []string
+	// LogResults logs the responses as well as the requests
+	LogResults bool
+	// MaxLength truncates the logged params and results, 1024 bytes by default
+	MaxLength int
+}
+
+// Logging logs every request with its duration and error
+func Logging(config LogConfig) Middleware {
+	redactMethods := map[string]bool{}
+	for _, method := range config.RedactMethods {
+		redactMethods[method] = true
+	}
+	redactFields := map[string]bool{"password": true, "privatekey": true}
+	for _, field := range config.RedactFields {
+		redactFields[strings.ToLower(field)] = true
+	}
+	if config.MaxLength <= 0 {
+		config.MaxLength = 1024
+	}
+	if config.Logger == nil {
+		config.Logger = log.Default()
+	}
+
+	format := func(value interface{}) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "?"
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err == nil {
+			data, _ = json.Marshal(redact(decoded, redactFields))
+		}
+		if len(data) > config.MaxLength {
+			return string(data[:config.MaxLength]) + "..."
+		}
+		return string(data)
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			start := time.Now()
+			err := next(ctx, v, method, params)
+			duration := time.Since(start)
+
+			logged := redacted
+			if !redactMethods[method] && !strings.HasPrefix(method, "personal_") {
+				logged = format(params)
+			}
+
+			switch {
+			case err != nil:
+				config.Logger.Printf("rpc %s params=%s duration=%s error=%v", method, logged, duration, err)
+			case config.LogResults:
+				config.Logger.Printf("rpc %s params=%s duration=%s result=%s", method, logged, duration, format(v))
+			default:
+				config.Logger.Printf("rpc %s params=%s duration=%s", method, logged, duration)
+			}
+			return err
+		}
+	}
+}
+
+// redact replaces the values of the fields of the decoded JSON value
+func redact(value interface{}, fields map[string]bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if fields[strings.ToLower(key)] {
+				value[key] = redacted
+			} else {
+				value[key] = redact(field, fields)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redact(item, fields)
+		}
+	}
+	return value
+}
+
+// RateLimit bounds the rate of the requests to ratePerSecond, allowing bursts
+// of burst requests. The requests over the limit wait for their turn, or for
+// the end of their context. The requests aren't limited when ratePerSecond
+// isn't positive.
+func RateLimit(ratePerSecond float64, burst int) Middleware {
+	if !(ratePerSecond > 0) {
+		return func(next Handler) Handler { return next }
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := &tokenBucket{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			if err := limiter.wait(ctx); err != nil {
+				return err
+			}
+			return next(ctx, v, method, params)
+		}
+	}
+}
+
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, sleeping until one is available
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+	bucket.lock.Lock()
+	now := time.Now()
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+	bucket.last = now
+
+	// the token is reserved now, the caller waits until it's produced
+	bucket.tokens--
+	var delay time.Duration
+	if bucket.tokens < 0 {
+		delay = time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+	}
+	bucket.lock.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.lock.Lock()
+		bucket.tokens++
+		bucket.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+// BeforeHook runs before a request is sent, an error aborts the request
+type BeforeHook func(ctx context.Context, method string, params interface{}) error
+
+// AfterHook runs once the response of a request is decoded into v, or the request failed with err
+type AfterHook func(ctx context.Context, method string, params interface{}, v interface{}, err error)
+
+// OnMethod runs before and after the requests of method, "*" matches every
+// method. before or after can be nil.
+func OnMethod(method string, before BeforeHook, after AfterHook) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, m string, params interface{}) error {
+			if method != "*" && method != m {
+				return next(ctx, v, m, params)
+			}
+
+			if before != nil {
+				if err := before(ctx, m, params); err != nil {
+					return err
+				}
+			}
+			err := next(ctx, v, m, params)
+			if after != nil {
+				after(ctx, m, params, v, err)
+			}
+			return err
+		}
+	}
+}
+
+type headerKey struct{}
+
+// ContextWithHeader returns a copy of ctx whose requests carry header, it's
+// honoured by the HTTP provider
+func ContextWithHeader(ctx context.Context, header http.Header) context.Context {
+	if previous, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		merged := previous.Clone()
+		for key, values := range header {
+			merged[key] = values
+		}
+		header = merged
+	}
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+func headerFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+	return header
+}
+
+// Headers adds the headers returned by source to the HTTP requests, e.g. an
+// Authorization header with a token that's refreshed when it expires
+func Headers(source func(ctx context.Context) (http.Header, error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			header, err := source(ctx)
+			if err != nil {
+				return err
+			}
+			return next(ContextWithHeader(ctx, header), v, method, params)
+		}
+	}
+}
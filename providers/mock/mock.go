@@ -0,0 +1,226 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+// Package mock provides providers which answer without a node: a scripted
+// provider whose responses are registered by the tests, and a recorder which
+// captures the traffic of a real provider into a golden file to be replayed.
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/tchain/go-tchain-sdk/providers"
+)
+
+// ErrUnexpectedCall is returned for a request which matches no expectation
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+// Call is a request received by a Provider
+type Call struct {
+	Method string
+	Params json.RawMessage
+}
+
+// Expectation is a canned response of a Provider, see Provider.On
+type Expectation struct {
+	method string
+	params json.RawMessage // nil matches any params
+
+	result json.RawMessage
+	rpcErr *providers.RPCError
+	err    error
+
+	times int // 0 for unlimited
+	calls int
+}
+
+// Return sets the result of the response, it's encoded to JSON unless it's
+// already a json.RawMessage
+func (e *Expectation) Return(result interface{}) *Expectation {
+	if raw, ok := result.(json.RawMessage); ok {
+		e.result = raw
+		return e
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		panic(fmt.Sprintf("mock: can't encode the result of %s: %v", e.method, err))
+	}
+	e.result = raw
+	return e
+}
+
+// ReturnError answers the request with a JSON-RPC error object
+func (e *Expectation) ReturnError(code int, message string) *Expectation {
+	e.rpcErr = &providers.RPCError{Code: code, Message: message}
+	return e
+}
+
+// Fail makes the provider return err, e.g. a *providers.TransportError
+func (e *Expectation) Fail(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// Times limits the number of requests answered by the expectation
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once answers a single request
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) exhausted() bool {
+	return e.times > 0 && e.calls >= e.times
+}
+
+// Provider is a ProviderInterface which answers the requests with the
+// responses registered with On, in the way the HTTP provider decodes them
+type Provider struct {
+	lock         sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+// NewProvider returns a provider without any expectation
+func NewProvider() *Provider {
+	return new(Provider)
+}
+
+// On registers a response for the requests of method. Without params it
+// matches any params, otherwise the params of the request must have the same
+// JSON encoding as params. The expectations are matched in the order they were
+// registered, the exhausted ones are skipped.
+func (provider *Provider) On(method string, params ...interface{}) *Expectation {
+	e := &Expectation{method: method, result: json.RawMessage("null")}
+	if len(params) > 0 {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			panic(fmt.Sprintf("mock: can't encode the params of %s: %v", method, err))
+		}
+		e.params = raw
+	}
+
+	provider.lock.Lock()
+	provider.expectations = append(provider.expectations, e)
+	provider.lock.Unlock()
+	return e
+}
+
+// Calls returns the requests received so far
+func (provider *Provider) Calls() []Call {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	return append([]Call(nil), provider.calls...)
+}
+
+// Pending returns the methods of the expectations limited with Times which
+// were not used up
+func (provider *Provider) Pending() []string {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+
+	var pending []string
+	for _, e := range provider.expectations {
+		if e.times > 0 && e.calls < e.times {
+			pending = append(pending, e.method)
+		}
+	}
+	return pending
+}
+
+func (provider *Provider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+func (provider *Provider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	provider.lock.Lock()
+	provider.calls = append(provider.calls, Call{Method: method, Params: raw})
+	e := provider.match(method, raw)
+	if e != nil {
+		e.calls++
+	}
+	provider.lock.Unlock()
+
+	if e == nil {
+		return fmt.Errorf("%w: %s %s", ErrUnexpectedCall, method, raw)
+	}
+	if e.err != nil {
+		return e.err
+	}
+	if e.rpcErr != nil {
+		rpcErr := *e.rpcErr
+		return &rpcErr
+	}
+	return decodeResult(e.result, v)
+}
+
+func (provider *Provider) Close() error { return nil }
+
+// match returns the first expectation for method and params
+func (provider *Provider) match(method string, params json.RawMessage) *Expectation {
+	for _, e := range provider.expectations {
+		if e.method != method || e.exhausted() {
+			continue
+		}
+		if e.params == nil || jsonEqual(e.params, params) {
+			return e
+		}
+	}
+	return nil
+}
+
+// decodeResult decodes a response carrying result into v
+func decodeResult(result json.RawMessage, v interface{}) error {
+	body, err := json.Marshal(struct {
+		ID      int             `json:"id"`
+		Version string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result"`
+	}{1, "2.0", result})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &providers.DecodeError{Body: body, Err: err}
+	}
+	return nil
+}
+
+// jsonEqual reports whether a and b encode the same value, e.g. null and []
+// are different while the order of the keys of an object doesn't matter
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,157 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tchain/go-tchain-sdk/providers"
+)
+
+// Record is an exchange captured by a Recorder, one entry of a golden file
+type Record struct {
+	Method string              `json:"method"`
+	Params json.RawMessage     `json:"params"`
+	Result json.RawMessage     `json:"result,omitempty"`
+	Error  *providers.RPCError `json:"error,omitempty"`
+}
+
+// Recorder forwards the requests to a provider and records the responses,
+// the JSON-RPC errors included, to save them in a golden file
+type Recorder struct {
+	provider providers.ProviderInterface
+	golden   string
+
+	lock    sync.Mutex
+	records []Record
+}
+
+// NewRecorder records the traffic of provider, it's saved to the golden file
+// by Save or Close. The files recorded with the HTTP or the IPC provider hold
+// the results as sent by the node.
+func NewRecorder(provider providers.ProviderInterface, golden string) *Recorder {
+	return &Recorder{
+		provider: provider,
+		golden:   golden,
+	}
+}
+
+func (recorder *Recorder) SendRequest(v interface{}, method string, params interface{}) error {
+	return recorder.SendRequestContext(context.Background(), v, method, params)
+}
+
+// SendRequestContext sends the request to the recorded provider. The
+// transport errors are returned without being recorded.
+func (recorder *Recorder) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	err = recorder.provider.SendRequestContext(ctx, &response, method, params)
+
+	record := Record{Method: method, Params: rawParams, Result: response.Result}
+	if err != nil {
+		var rpcErr *providers.RPCError
+		if !errors.As(err, &rpcErr) {
+			return err
+		}
+		record.Result = nil
+		record.Error = rpcErr
+	}
+	if record.Result == nil && record.Error == nil {
+		record.Result = json.RawMessage("null")
+	}
+
+	recorder.lock.Lock()
+	recorder.records = append(recorder.records, record)
+	recorder.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return decodeResult(record.Result, v)
+}
+
+// Records returns the exchanges recorded so far
+func (recorder *Recorder) Records() []Record {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	return append([]Record(nil), recorder.records...)
+}
+
+// Save writes the records to the golden file
+func (recorder *Recorder) Save() error {
+	data, err := json.MarshalIndent(recorder.Records(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(recorder.golden), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(recorder.golden, append(data, '\n'), 0644)
+}
+
+// Close saves the golden file and closes the recorded provider
+func (recorder *Recorder) Close() error {
+	if err := recorder.Save(); err != nil {
+		return err
+	}
+	return recorder.provider.Close()
+}
+
+// NewReplayer returns a Provider which replays the golden file: every record
+// answers a single request with the same method and params, the identical
+// requests are answered in the order they were recorded
+func NewReplayer(golden string) (*Provider, error) {
+	data, err := ioutil.ReadFile(golden)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	provider := NewProvider()
+	for _, record := range records {
+		e := &Expectation{
+			method: record.Method,
+			params: record.Params,
+			result: record.Result,
+			rpcErr: record.Error,
+			times:  1,
+		}
+		if e.params == nil {
+			e.params = json.RawMessage("null")
+		}
+		if e.result == nil {
+			e.result = json.RawMessage("null")
+		}
+		provider.expectations = append(provider.expectations, e)
+	}
+	return provider, nil
+}
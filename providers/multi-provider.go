@@ -0,0 +1,418 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk/dto"
+)
+
+// Strategy selects the endpoint of a MultiProvider which serves a request
+type Strategy int
+
+const (
+	// RoundRobin spreads the requests over the healthy endpoints in turn
+	RoundRobin Strategy = iota
+	// Failover sends the requests to the first healthy endpoint, in the order
+	// they were given: a primary and its secondaries
+	Failover
+	// MostUpToDate sends the requests to the healthy endpoint with the highest
+	// block number, as seen by the last health check
+	MostUpToDate
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxFailures         = 3
+)
+
+// ErrNoEndpoint is returned by NewMultiProvider without any provider
+var ErrNoEndpoint = errors.New("no endpoint")
+
+type multiProviderConfig struct {
+	strategy            Strategy
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	maxFailures         int
+	stickyMethods       map[string]bool
+}
+
+// MultiProviderOption configures a MultiProvider, see NewMultiProvider
+type MultiProviderOption func(config *multiProviderConfig)
+
+// WithStrategy sets how the endpoint of a request is selected, RoundRobin by default
+func WithStrategy(strategy Strategy) MultiProviderOption {
+	return func(config *multiProviderConfig) {
+		config.strategy = strategy
+	}
+}
+
+// WithHealthCheck sets how often every endpoint is checked with core_blockNumber
+// and how long the check can take, 10 and 5 seconds by default. An interval of
+// 0 disables the health checks, the ejected endpoints are then never re-admitted.
+func WithHealthCheck(interval time.Duration, timeout time.Duration) MultiProviderOption {
+	return func(config *multiProviderConfig) {
+		config.healthCheckInterval = interval
+		config.healthCheckTimeout = timeout
+	}
+}
+
+// WithMaxFailures sets the number of consecutive failed requests after which
+// an endpoint is ejected until its next successful health check, 3 by default
+func WithMaxFailures(n int) MultiProviderOption {
+	return func(config *multiProviderConfig) {
+		config.maxFailures = n
+	}
+}
+
+// WithStickyMethods sets the methods which are always sent to the same
+// endpoint while it's healthy, core_sendRawTransaction and
+// core_sendTransaction by default, so that the nonces of an account are seen
+// in order by one node. Like the other writes, they only go to the next
+// endpoint when the request surely didn't reach the node: after a timeout or
+// a dropped connection the transaction may have been broadcast, the error is
+// returned instead of sending it twice.
+func WithStickyMethods(methods ...string) MultiProviderOption {
+	return func(config *multiProviderConfig) {
+		config.stickyMethods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			config.stickyMethods[method] = true
+		}
+	}
+}
+
+// EndpointStatus is the state of an endpoint of a MultiProvider
+type EndpointStatus struct {
+	Index       int
+	Healthy     bool
+	BlockNumber uint64
+	Failures    int
+}
+
+type endpoint struct {
+	index    int
+	provider ProviderInterface
+
+	healthy     int32
+	blockNumber uint64
+	failures    int32
+}
+
+func (e *endpoint) isHealthy() bool {
+	return atomic.LoadInt32(&e.healthy) == 1
+}
+
+// MultiProvider sends the requests to one of several providers of the same
+// chain, it fails over to the next endpoint when one can't be reached
+type MultiProvider struct {
+	endpoints []*endpoint
+	config    *multiProviderConfig
+
+	next   uint32
+	sticky int32
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMultiProvider returns a provider which balances the requests over
+// providers according to the options
+func NewMultiProvider(providers []ProviderInterface, opts ...MultiProviderOption) (*MultiProvider, error) {
+	if len(providers) == 0 {
+		return nil, ErrNoEndpoint
+	}
+
+	config := &multiProviderConfig{
+		strategy:            RoundRobin,
+		healthCheckInterval: defaultHealthCheckInterval,
+		healthCheckTimeout:  defaultHealthCheckTimeout,
+		maxFailures:         defaultMaxFailures,
+		stickyMethods: map[string]bool{
+			"core_sendRawTransaction": true,
+			"core_sendTransaction":    true,
+		},
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	provider := &MultiProvider{
+		config: config,
+		sticky: -1,
+		stopCh: make(chan struct{}),
+	}
+	for i, p := range providers {
+		provider.endpoints = append(provider.endpoints, &endpoint{index: i, provider: p, healthy: 1})
+	}
+
+	if config.healthCheckInterval > 0 {
+		provider.checkHealth()
+		go provider.healthLoop()
+	}
+
+	return provider, nil
+}
+
+func (provider *MultiProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+// SendRequestContext sends the request to the selected endpoint, and to the
+// next ones while the endpoints can't be reached. The errors answered by a
+// node, e.g. *RPCError, are returned as is. The writes, the methods which
+// aren't IsIdempotent, only go to the next endpoint when the request surely
+// didn't reach the node, see WithStickyMethods.
+func (provider *MultiProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.do(ctx, method, !IsIdempotent(method), func(e *endpoint) error {
+		return e.provider.SendRequestContext(ctx, v, method, params)
+	})
+}
+
+func (provider *MultiProvider) BatchSendRequest(elems []BatchElem) error {
+	return provider.BatchSendRequestContext(context.Background(), elems)
+}
+
+// BatchSendRequestContext sends the batch to a single endpoint, selected as for
+// SendRequestContext, a batch with a write is handled as a write
+func (provider *MultiProvider) BatchSendRequestContext(ctx context.Context, elems []BatchElem) error {
+	method := ""
+	write := false
+	for _, elem := range elems {
+		if provider.config.stickyMethods[elem.Method] && method == "" {
+			method = elem.Method
+		}
+		if !IsIdempotent(elem.Method) {
+			write = true
+		}
+	}
+
+	return provider.do(ctx, method, write, func(e *endpoint) error {
+		return BatchSendRequestContext(ctx, e.provider, elems)
+	})
+}
+
+// Subscribe creates the subscription on the first selected endpoint which
+// supports notifications. The subscription stays on this endpoint.
+func (provider *MultiProvider) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error) {
+	for _, e := range provider.candidates() {
+		if p, ok := e.provider.(SubscriptionProvider); ok {
+			return p.Subscribe(ctx, namespace, channel, args...)
+		}
+	}
+	return nil, ErrNotificationsUnsupported
+}
+
+// Status returns the state of every endpoint, in the order of the providers
+func (provider *MultiProvider) Status() []EndpointStatus {
+	status := make([]EndpointStatus, len(provider.endpoints))
+	for i, e := range provider.endpoints {
+		status[i] = EndpointStatus{
+			Index:       e.index,
+			Healthy:     e.isHealthy(),
+			BlockNumber: atomic.LoadUint64(&e.blockNumber),
+			Failures:    int(atomic.LoadInt32(&e.failures)),
+		}
+	}
+	return status
+}
+
+// Close stops the health checks and closes every provider
+func (provider *MultiProvider) Close() error {
+	provider.stopOnce.Do(func() {
+		close(provider.stopCh)
+	})
+
+	var err error
+	for _, e := range provider.endpoints {
+		if closeErr := e.provider.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// do runs send on the endpoints selected for method until one of them answers.
+// A write goes to the next endpoint only when it surely wasn't sent, the
+// error is returned otherwise as the node may have received it.
+func (provider *MultiProvider) do(ctx context.Context, method string, write bool, send func(e *endpoint) error) error {
+	sticky := provider.config.stickyMethods[method]
+
+	var err error
+	for _, e := range provider.order(sticky) {
+		err = send(e)
+		if err == nil {
+			provider.succeeded(e)
+			if sticky {
+				atomic.StoreInt32(&provider.sticky, int32(e.index))
+			}
+			return nil
+		}
+		if ctx.Err() != nil || !isEndpointFailure(err) {
+			return err
+		}
+		provider.failed(e)
+		if (write || sticky) && !isUnsent(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// order returns the endpoints to try for a request, the sticky endpoint
+// first when the method is sticky
+func (provider *MultiProvider) order(sticky bool) []*endpoint {
+	candidates := provider.candidates()
+	if !sticky {
+		return candidates
+	}
+
+	index := atomic.LoadInt32(&provider.sticky)
+	for i, e := range candidates {
+		if int32(e.index) == index {
+			return append([]*endpoint{e}, append(candidates[:i:i], candidates[i+1:]...)...)
+		}
+	}
+	return candidates
+}
+
+// candidates returns the healthy endpoints in the order of the strategy, or
+// all the endpoints when none of them is healthy
+func (provider *MultiProvider) candidates() []*endpoint {
+	candidates := make([]*endpoint, 0, len(provider.endpoints))
+	for _, e := range provider.endpoints {
+		if e.isHealthy() {
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = append(candidates, provider.endpoints...)
+	}
+
+	switch provider.config.strategy {
+	case RoundRobin:
+		shift := int(atomic.AddUint32(&provider.next, 1)-1) % len(candidates)
+		candidates = append(candidates[shift:], candidates[:shift]...)
+	case MostUpToDate:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return atomic.LoadUint64(&candidates[i].blockNumber) > atomic.LoadUint64(&candidates[j].blockNumber)
+		})
+	}
+	return candidates
+}
+
+func (provider *MultiProvider) succeeded(e *endpoint) {
+	atomic.StoreInt32(&e.failures, 0)
+}
+
+func (provider *MultiProvider) failed(e *endpoint) {
+	if failures := atomic.AddInt32(&e.failures, 1); provider.config.maxFailures > 0 && int(failures) >= provider.config.maxFailures {
+		atomic.StoreInt32(&e.healthy, 0)
+	}
+}
+
+func (provider *MultiProvider) healthLoop() {
+	ticker := time.NewTicker(provider.config.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			provider.checkHealth()
+		case <-provider.stopCh:
+			return
+		}
+	}
+}
+
+// checkHealth reads the block number of every endpoint, the endpoints which
+// answer are (re-)admitted and the others are ejected
+func (provider *MultiProvider) checkHealth() {
+	var wg sync.WaitGroup
+	for _, e := range provider.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), provider.config.healthCheckTimeout)
+			defer cancel()
+
+			number, err := blockNumber(ctx, e.provider)
+			if err != nil {
+				atomic.StoreInt32(&e.healthy, 0)
+				return
+			}
+			atomic.StoreUint64(&e.blockNumber, number)
+			atomic.StoreInt32(&e.failures, 0)
+			atomic.StoreInt32(&e.healthy, 1)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func blockNumber(ctx context.Context, provider ProviderInterface) (uint64, error) {
+	pointer := &dto.CoreRequestResult{}
+	if err := provider.SendRequestContext(ctx, pointer, "core_blockNumber", nil); err != nil {
+		return 0, err
+	}
+
+	number, err := pointer.ToBigInt()
+	if err != nil {
+		return 0, err
+	}
+	return number.Uint64(), nil
+}
+
+// isEndpointFailure reports whether err means that the endpoint couldn't
+// serve the request, so that another endpoint may be tried
+func isEndpointFailure(err error) bool {
+	var transportError *TransportError
+	var decodeError *DecodeError
+	var statusError *HTTPStatusError
+
+	switch {
+	case errors.As(err, &transportError), errors.As(err, &decodeError):
+		return true
+	case errors.As(err, &statusError):
+		return statusError.StatusCode >= http.StatusInternalServerError || statusError.StatusCode == http.StatusTooManyRequests
+	case errors.Is(err, ErrTimeout), errors.Is(err, ErrProviderClosed):
+		return true
+	}
+	return false
+}
+
+// isUnsent reports whether the request failing with err surely didn't reach
+// the node: the connection couldn't be opened, the provider is closed or the
+// node turned the request down
+func isUnsent(err error) bool {
+	var opError *net.OpError
+	var statusError *HTTPStatusError
+
+	switch {
+	case errors.As(err, &opError):
+		return opError.Op == "dial"
+	case errors.As(err, &statusError):
+		return statusError.StatusCode == http.StatusServiceUnavailable || statusError.StatusCode == http.StatusTooManyRequests
+	}
+	return errors.Is(err, ErrProviderClosed)
+}
@@ -14,7 +14,12 @@
 
 package providers
 
+import "context"
+
 type ProviderInterface interface {
 	SendRequest(v interface{}, method string, params interface{}) error
+	// SendRequestContext is like SendRequest but aborts the in-flight request
+	// once ctx is cancelled or its deadline expires.
+	SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error
 	Close() error
 }
@@ -0,0 +1,287 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	subscribeMethodSuffix    = "_subscribe"
+	unsubscribeMethodSuffix  = "_unsubscribe"
+	notificationMethodSuffix = "_subscription"
+
+	// maxSubscriptionQueue is the number of notifications buffered for a
+	// subscription whose channel is not read fast enough
+	maxSubscriptionQueue = 20000
+
+	// resubscribeTimeout bounds the creation of a subscription on a new connection
+	resubscribeTimeout = 30 * time.Second
+)
+
+var (
+	// ErrNotificationsUnsupported is returned when the provider can't push notifications, e.g. HTTP
+	ErrNotificationsUnsupported = errors.New("notifications not supported by the provider")
+	// ErrSubscriptionClosed is sent on Err when the connection of the subscription is closed
+	ErrSubscriptionClosed = errors.New("subscription connection closed")
+	// ErrSubscriptionQueueOverflow is sent on Err when the notifications are not consumed fast enough
+	ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+)
+
+// SubscriptionProvider is implemented by the providers that keep a connection
+// open to the node and can receive its notifications
+type SubscriptionProvider interface {
+	// Subscribe calls <namespace>_subscribe with args and sends every
+	// notification of the subscription, decoded as the element type of
+	// channel, to channel. channel must be a writable channel.
+	Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error)
+}
+
+// Subscription is an active subscription created by Subscribe
+type Subscription struct {
+	stream    *stream
+	namespace string
+	args      []interface{}
+	id        string
+	channel   reflect.Value
+
+	queue chan json.RawMessage
+	err   chan error
+	quit  chan struct{}
+	once  sync.Once
+}
+
+func newSubscription(s *stream, namespace string, channel reflect.Value, args []interface{}) *Subscription {
+	return &Subscription{
+		stream:    s,
+		namespace: namespace,
+		args:      args,
+		channel:   channel,
+		queue:     make(chan json.RawMessage, maxSubscriptionQueue),
+		err:       make(chan error, 1),
+		quit:      make(chan struct{}),
+	}
+}
+
+// ID returns the subscription id assigned by the node, it changes when the
+// subscription is created again after a reconnection
+func (sub *Subscription) ID() string {
+	sub.stream.subsLock.Lock()
+	defer sub.stream.subsLock.Unlock()
+	return sub.id
+}
+
+// Err returns the error channel of the subscription. It receives a value when
+// the subscription fails and is closed once the subscription ends.
+func (sub *Subscription) Err() <-chan error {
+	return sub.err
+}
+
+// Done returns a channel which is closed once the subscription ends, it
+// leaves the error of the subscription to the readers of Err.
+func (sub *Subscription) Done() <-chan struct{} {
+	return sub.quit
+}
+
+// Unsubscribe stops the delivery of notifications and removes the subscription
+// from the node. It can be called more than once.
+func (sub *Subscription) Unsubscribe() error {
+	id := sub.ID()
+	if !sub.close(nil) {
+		return nil
+	}
+
+	_, err := sub.stream.CallContext(context.Background(), sub.namespace+unsubscribeMethodSuffix, []string{id})
+	return err
+}
+
+// close ends the subscription, it returns false when it was already closed
+func (sub *Subscription) close(err error) bool {
+	closed := false
+	sub.once.Do(func() {
+		closed = true
+
+		sub.stream.subsLock.Lock()
+		if sub.stream.subs[sub.id] == sub {
+			delete(sub.stream.subs, sub.id)
+		}
+		close(sub.quit)
+		sub.stream.subsLock.Unlock()
+
+		if err != nil {
+			sub.err <- err
+		}
+		close(sub.err)
+	})
+	return closed
+}
+
+// register records the subscription under the id found in the response of
+// <namespace>_subscribe, replacing its previous id
+func (sub *Subscription) register(b []byte) {
+	var id string
+	if err := json.Unmarshal(b, &id); err != nil || id == "" {
+		return
+	}
+
+	s := sub.stream
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+
+	select {
+	case <-sub.quit:
+		return
+	default:
+	}
+	if s.subs[sub.id] == sub {
+		delete(s.subs, sub.id)
+	}
+	sub.id = id
+	s.subs[id] = sub
+}
+
+// deliver queues a notification, it never blocks the listener of the stream
+func (sub *Subscription) deliver(result json.RawMessage) {
+	select {
+	case sub.queue <- result:
+	case <-sub.quit:
+	default:
+		go sub.close(ErrSubscriptionQueueOverflow)
+	}
+}
+
+// forward decodes the queued notifications and sends them to the channel of the subscription
+func (sub *Subscription) forward() {
+	elemType := sub.channel.Type().Elem()
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.quit)},
+		{Dir: reflect.SelectSend, Chan: sub.channel},
+	}
+
+	for {
+		var result json.RawMessage
+		select {
+		case result = <-sub.queue:
+		case <-sub.quit:
+			return
+		}
+
+		value := reflect.New(elemType)
+		if err := json.Unmarshal(result, value.Interface()); err != nil {
+			sub.close(err)
+			return
+		}
+
+		cases[1].Send = value.Elem()
+		if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+			return
+		}
+	}
+}
+
+// Subscribe implements SubscriptionProvider for the stream
+func (s *stream) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error) {
+	chanVal := reflect.ValueOf(channel)
+	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, errors.New("subscription channel must be a writable channel")
+	}
+	if chanVal.IsNil() {
+		return nil, errors.New("subscription channel must not be nil")
+	}
+
+	sub := newSubscription(s, namespace, chanVal, args)
+
+	// the subscription is registered by the listener as soon as the response
+	// is read, so the notifications that follow it are not lost
+	if _, err := s.call(ctx, namespace+subscribeMethodSuffix, args, sub.register); err != nil {
+		sub.close(nil)
+		return nil, err
+	}
+	if sub.ID() == "" {
+		sub.close(nil)
+		return nil, errors.New("invalid subscription id")
+	}
+
+	go sub.forward()
+	return sub, nil
+}
+
+// handleNotification dispatches a <namespace>_subscription message to its subscription
+func (s *stream) handleNotification(resp Response) {
+	if !strings.HasSuffix(resp.Method, notificationMethodSuffix) {
+		return
+	}
+
+	var params struct {
+		Subscription string          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(resp.Params, &params); err != nil {
+		return
+	}
+
+	s.subsLock.Lock()
+	sub := s.subs[params.Subscription]
+	s.subsLock.Unlock()
+
+	if sub != nil {
+		sub.deliver(params.Result)
+	}
+}
+
+// closeSubscriptions ends all the subscriptions of the stream with err
+func (s *stream) closeSubscriptions(err error) {
+	s.subsLock.Lock()
+	subs := make([]*Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.subsLock.Unlock()
+
+	for _, sub := range subs {
+		sub.close(err)
+	}
+}
+
+// resubscribe creates the active subscriptions again after a reconnection,
+// the subscriptions which can't be created are closed with the error
+func (s *stream) resubscribe() {
+	s.subsLock.Lock()
+	subs := make([]*Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.subsLock.Unlock()
+
+	for _, sub := range subs {
+		ctx, cancel := context.WithTimeout(context.Background(), resubscribeTimeout)
+		_, err := s.call(ctx, sub.namespace+subscribeMethodSuffix, sub.args, sub.register)
+		cancel()
+
+		if errors.Is(err, ErrConnectionLost) {
+			// dropped again, the next reconnection takes over
+			return
+		}
+		if err != nil {
+			sub.close(err)
+		}
+	}
+}
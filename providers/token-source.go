@@ -0,0 +1,114 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultTokenExpiryDelta = 10 * time.Second
+
+// Token is a credential sent in the Authorization header of the requests
+type Token struct {
+	AccessToken string
+	// TokenType is the scheme of the Authorization header, Bearer when empty
+	TokenType string
+	// Expiry is the time the token expires at, zero when it doesn't expire
+	Expiry time.Time
+}
+
+// Type returns the scheme of the Authorization header
+func (token *Token) Type() string {
+	if token.TokenType == "" {
+		return "Bearer"
+	}
+	return token.TokenType
+}
+
+// valid reports whether the token is set and doesn't expire within delta
+func (token *Token) valid(delta time.Duration) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+	return token.Expiry.IsZero() || time.Now().Add(delta).Before(token.Expiry)
+}
+
+// TokenSource returns the token of a request, e.g. by logging in to an API
+// gateway. It's called for every request, see NewCachedTokenSource.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc is a function used as a TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource always returns the same bearer token
+func StaticTokenSource(accessToken string) TokenSource {
+	token := &Token{AccessToken: accessToken}
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return token, nil
+	})
+}
+
+// cachedTokenSource keeps the token of a source until it expires
+type cachedTokenSource struct {
+	source TokenSource
+	delta  time.Duration
+
+	lock  sync.Mutex
+	token *Token
+}
+
+// NewCachedTokenSource returns a TokenSource which calls source only when its
+// last token expires within delta, 10 seconds when delta is 0. The HTTP
+// provider also drops the cached token when the node answers 401 Unauthorized.
+func NewCachedTokenSource(source TokenSource, delta time.Duration) TokenSource {
+	if delta == 0 {
+		delta = defaultTokenExpiryDelta
+	}
+	return &cachedTokenSource{source: source, delta: delta}
+}
+
+func (source *cachedTokenSource) Token(ctx context.Context) (*Token, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.token.valid(source.delta) {
+		return source.token, nil
+	}
+
+	token, err := source.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source.token = token
+	return token, nil
+}
+
+// invalidate drops token, unless it was already replaced by a newer one
+func (source *cachedTokenSource) invalidate(token *Token) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.token == token {
+		source.token = nil
+	}
+}
@@ -0,0 +1,106 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// Attribute is a key and a value describing a span
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Span is an operation started by a Tracer
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attributes ...Attribute)
+	// End ends the span, err is the failure of the operation or nil
+	End(err error)
+}
+
+// Tracer starts the spans of the Tracing middleware, it's implemented by an
+// adapter to a tracing library such as OpenTelemetry
+type Tracer interface {
+	// Start starts a span named name, child of the span of ctx if any, and
+	// returns a copy of ctx holding the new span
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// callMethods are the methods whose params start with a transaction, whose
+// recipient is added to the span
+var callMethods = map[string]bool{
+	"core_call":        true,
+	"core_estimateGas": true,
+}
+
+// Tracing wraps every request sent to endpoint in a span named after its
+// method, with the OpenTelemetry attributes of JSON-RPC. The spans of
+// core_call and core_estimateGas also carry the recipient of the transaction,
+// e.g. a system contract.
+func Tracing(tracer Tracer, endpoint string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, v interface{}, method string, params interface{}) error {
+			attributes := []Attribute{
+				{"rpc.system", "jsonrpc"},
+				{"rpc.method", method},
+				{"server.address", endpoint},
+			}
+			if callMethods[method] {
+				if to := recipient(params); to != "" {
+					attributes = append(attributes, Attribute{"bif.transaction.recipient", to})
+				}
+			}
+
+			ctx, span := tracer.Start(ctx, method, attributes...)
+			err := next(ctx, v, method, params)
+			if err != nil {
+				attributes = []Attribute{{"error.type", ErrorType(err)}}
+				var rpcError *RPCError
+				if errors.As(err, &rpcError) {
+					attributes = append(attributes, Attribute{"rpc.jsonrpc.error_code", strconv.Itoa(rpcError.Code)})
+				}
+				span.SetAttributes(attributes...)
+			}
+			span.End(err)
+			return err
+		}
+	}
+}
+
+// recipient returns the recipient of the transaction of the params of a call
+func recipient(params interface{}) string {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+
+	var args []json.RawMessage
+	if err := json.Unmarshal(raw, &args); err != nil || len(args) == 0 {
+		return ""
+	}
+
+	var transaction struct {
+		Recipient string `json:"recipient"`
+	}
+	if err := json.Unmarshal(args[0], &transaction); err != nil {
+		return ""
+	}
+	return transaction.Recipient
+}
@@ -36,6 +36,19 @@ func (jrpc *JSONRPCObject) AsJsonString() string {
 	return string(resultBytes)
 }
 
+// JSONRPCBatch is a list of requests sent to the node in a single round trip
+type JSONRPCBatch []JSONRPCObject
+
+func (batch JSONRPCBatch) AsJsonString() string {
+	resultBytes, err := json.Marshal(batch)
+
+	if err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	return string(resultBytes)
+}
+
 var Version string
 
 func init() {
@@ -15,6 +15,7 @@
 package providers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk/dto"
@@ -22,7 +23,6 @@ import (
 
 	"encoding/json"
 	"github.com/gorilla/websocket"
-	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,27 +33,72 @@ type WebSocketProvider struct {
 	ws      *stream
 }
 
+// NewWebSocketProvider connects to address, it panics when the connection
+// can't be established, use NewWebSocketProviderWithOptions to get the error
 func NewWebSocketProvider(address string) *WebSocketProvider {
-	provider := new(WebSocketProvider)
-	ws, err := newWebsocket(address)
+	provider, err := NewWebSocketProviderWithOptions(address)
 	if err != nil {
 		panic(fmt.Sprintf("dail websocket faied: %v", err))
 	}
-	provider.address = address
-	provider.ws = ws
 	return provider
 }
 
-func (provider WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
-	value, err := provider.ws.Call(method, v, params)
-	if err != nil {
-		return err
+// NewWebSocketProviderWithOptions connects to address. Unless WithoutReconnect
+// is given, a dropped connection is dialed again with an exponential backoff
+// and the active subscriptions are created again on the new connection.
+func NewWebSocketProviderWithOptions(address string, opts ...ConnectionOption) (*WebSocketProvider, error) {
+	config := newConnectionConfig(opts)
+
+	dial := func(ctx context.Context) (Codec, error) {
+		wsConn, _, err := config.dialer.DialContext(ctx, address, config.header)
+		if err != nil {
+			return nil, err
+		}
+		return &websocketCodec{conn: wsConn}, nil
 	}
-	if jsonValue, err := json.Marshal(value); err == nil {
-		return json.Unmarshal(jsonValue, v)
-	} else {
-		return err
+
+	codec, err := dial(context.Background())
+	if err != nil {
+		return nil, &TransportError{Err: err}
 	}
+
+	provider := new(WebSocketProvider)
+	provider.address = address
+	provider.ws = newStream(codec, config.streamConfig(dial))
+	return provider, nil
+}
+
+func (provider WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+func (provider WebSocketProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
+	return provider.ws.CallInto(ctx, v, method, params)
+}
+
+func (provider WebSocketProvider) BatchSendRequest(elems []BatchElem) error {
+	return provider.BatchSendRequestContext(context.Background(), elems)
+}
+
+// BatchSendRequestContext sends all elems in a single websocket message
+func (provider WebSocketProvider) BatchSendRequestContext(ctx context.Context, elems []BatchElem) error {
+	return provider.ws.BatchCallContext(ctx, elems)
+}
+
+// Subscribe creates a subscription in the given namespace, e.g. "core", and
+// delivers its notifications to channel, see SubscriptionProvider
+func (provider WebSocketProvider) Subscribe(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*Subscription, error) {
+	return provider.ws.Subscribe(ctx, namespace, channel, args...)
+}
+
+// State returns the current state of the connection
+func (provider WebSocketProvider) State() ConnectionState {
+	return provider.ws.State()
+}
+
+// Stats returns the state of the connection and its load
+func (provider WebSocketProvider) Stats() ConnectionStats {
+	return provider.ws.stats()
 }
 
 func (provider WebSocketProvider) Close() error {
@@ -65,19 +110,14 @@ func (provider WebSocketProvider) Close() error {
 
 }
 
-func newWebsocket(url string) (*stream, error) {
-	wsConn, _, err := websocket.DefaultDialer.Dial(url, http.Header{})
-	if err != nil {
-		return nil, err
-	}
-	codec := &websocketCodec{
-		conn: wsConn,
-	}
-	return newStream(codec)
-}
-
-// ErrTimeout happens when the websocket requests times out
-var ErrTimeout = fmt.Errorf("timeout")
+var (
+	// ErrTimeout happens when the websocket requests times out
+	ErrTimeout = fmt.Errorf("timeout")
+	// ErrConnectionLost is returned by the requests pending or sent while the connection is down
+	ErrConnectionLost = errors.New("connection lost")
+	// ErrProviderClosed is returned by the requests pending or sent after Close
+	ErrProviderClosed = errors.New("provider closed")
+)
 
 type ackMessage struct {
 	buf []byte
@@ -86,33 +126,75 @@ type ackMessage struct {
 
 type callback func(b []byte, err error)
 
+// streamConfig sets how a stream recovers from a dropped connection
+type streamConfig struct {
+	// redial opens a new connection, the stream is not reconnected when
+	// redial or reconnect is nil
+	redial        func(ctx context.Context) (Codec, error)
+	reconnect     *reconnectConfig
+	onStateChange StateCallback
+
+	// requestTimeout applies to the requests whose context has no deadline, 0 disables it
+	requestTimeout time.Duration
+	// maxInFlight bounds the number of requests waiting for a response, 0 disables it
+	maxInFlight int
+}
+
 type stream struct {
-	seq   uint64
-	codec Codec
+	seq    uint64
+	state  int32
+	config streamConfig
+
+	// the current connection, requests are only written while connected
+	codecLock sync.RWMutex
+	codec     Codec
+	connected bool
 
 	// call handlers
 	handlerLock sync.Mutex
 	handler     map[uint64]callback
 
-	closeCh chan struct{}
-	timer   *time.Timer
+	// active subscriptions by id
+	subsLock sync.Mutex
+	subs     map[string]*Subscription
+
+	// in-flight request slots, nil when unbounded
+	inflight chan struct{}
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
 }
 
-func newStream(codec Codec) (*stream, error) {
+func newStream(codec Codec, config streamConfig) *stream {
 	w := &stream{
-		codec:   codec,
-		closeCh: make(chan struct{}),
-		handler: map[uint64]callback{},
+		config:    config,
+		codec:     codec,
+		connected: true,
+		closeCh:   make(chan struct{}),
+		handler:   map[uint64]callback{},
+		subs:      map[string]*Subscription{},
+	}
+	if config.maxInFlight > 0 {
+		w.inflight = make(chan struct{}, config.maxInFlight)
 	}
 
+	w.setState(StateConnected, nil)
 	go w.listen()
-	return w, nil
+	return w
 }
 
 // Close implements the the transport interface
 func (s *stream) Close() error {
-	close(s.closeCh)
-	return s.codec.Close()
+	err := ErrProviderClosed
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+
+		s.codecLock.Lock()
+		s.connected = false
+		err = s.codec.Close()
+		s.codecLock.Unlock()
+	})
+	return err
 }
 
 func (s *stream) incSeq() uint64 {
@@ -128,30 +210,197 @@ func (s *stream) isClosed() bool {
 	}
 }
 
+// State returns the current state of the connection
+func (s *stream) State() ConnectionState {
+	return ConnectionState(atomic.LoadInt32(&s.state))
+}
+
+// stats returns the state of the stream and its load
+func (s *stream) stats() ConnectionStats {
+	s.handlerLock.Lock()
+	inFlight := len(s.handler)
+	s.handlerLock.Unlock()
+
+	s.subsLock.Lock()
+	subscriptions := len(s.subs)
+	s.subsLock.Unlock()
+
+	return ConnectionStats{State: s.State(), InFlight: inFlight, Subscriptions: subscriptions}
+}
+
+func (s *stream) setState(state ConnectionState, err error) {
+	atomic.StoreInt32(&s.state, int32(state))
+	if s.config.onStateChange != nil {
+		s.config.onStateChange(state, err)
+	}
+}
+
+// listen reads the connection until it drops, then fails the pending requests
+// and dials a new connection when reconnection is enabled
 func (s *stream) listen() {
+	for {
+		s.codecLock.RLock()
+		codec := s.codec
+		s.codecLock.RUnlock()
+
+		err := s.read(codec)
+
+		s.codecLock.Lock()
+		s.connected = false
+		s.codecLock.Unlock()
+		codec.Close()
+
+		if s.isClosed() {
+			s.failPending(ErrProviderClosed)
+			s.closeSubscriptions(ErrSubscriptionClosed)
+			s.setState(StateClosed, nil)
+			return
+		}
+
+		// log error
+		s.failPending(&TransportError{Err: fmt.Errorf("%w: %v", ErrConnectionLost, err)})
+		s.setState(StateDisconnected, err)
+
+		if err = s.redial(err); err != nil {
+			if s.isClosed() {
+				s.closeSubscriptions(ErrSubscriptionClosed)
+				s.setState(StateClosed, nil)
+			} else {
+				s.closeSubscriptions(err)
+				s.setState(StateClosed, err)
+			}
+			return
+		}
+
+		s.setState(StateConnected, nil)
+		go s.resubscribe()
+	}
+}
+
+// read dispatches the messages of codec until it fails
+func (s *stream) read(codec Codec) error {
 	buf := []byte{}
 
 	for {
 		var err error
-		buf, err = s.codec.Read(buf[:0])
+		buf, err = codec.Read(buf[:0])
 		if err != nil {
-			if !s.isClosed() {
-				// log error
+			return err
+		}
+
+		if isBatch(buf) {
+			var batch []Response
+			if err = json.Unmarshal(buf, &batch); err != nil {
+				continue
 			}
-			return
+			for _, resp := range batch {
+				if resp.ID != 0 {
+					s.handleMsg(resp)
+				}
+			}
+			continue
 		}
 
 		var resp Response
 		if err = json.Unmarshal(buf, &resp); err != nil {
-			return
+			continue
 		}
 
+		// responses are handled in order, so that a subscription is registered
+		// before its first notification is read
 		if resp.ID != 0 {
-			go s.handleMsg(resp)
+			s.handleMsg(resp)
+		} else if resp.Method != "" {
+			s.handleNotification(resp)
 		}
 	}
 }
 
+// redial dials new connections with an exponential backoff until one succeeds,
+// the stream is closed or the attempts are exhausted
+func (s *stream) redial(cause error) error {
+	if s.config.redial == nil || s.config.reconnect == nil {
+		return &TransportError{Err: fmt.Errorf("%w: %v", ErrConnectionLost, cause)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	reconnect := s.config.reconnect
+	backoff := reconnect.minBackoff
+	for attempt := 1; reconnect.maxAttempts == 0 || attempt <= reconnect.maxAttempts; attempt++ {
+		s.setState(StateReconnecting, cause)
+
+		select {
+		case <-time.After(backoff):
+		case <-s.closeCh:
+			return ErrProviderClosed
+		}
+
+		codec, err := s.config.redial(ctx)
+		if err != nil {
+			cause = err
+			if backoff *= 2; backoff > reconnect.maxBackoff {
+				backoff = reconnect.maxBackoff
+			}
+			continue
+		}
+
+		s.codecLock.Lock()
+		if s.isClosed() {
+			s.codecLock.Unlock()
+			codec.Close()
+			return ErrProviderClosed
+		}
+		s.codec = codec
+		s.connected = true
+		s.codecLock.Unlock()
+		return nil
+	}
+
+	return &TransportError{Err: fmt.Errorf("%w: reconnection failed after %d attempts: %v", ErrConnectionLost, reconnect.maxAttempts, cause)}
+}
+
+// failPending fails all the requests waiting for a response with err
+func (s *stream) failPending(err error) {
+	s.handlerLock.Lock()
+	handlers := s.handler
+	s.handler = map[uint64]callback{}
+	s.handlerLock.Unlock()
+
+	for _, callback := range handlers {
+		callback(nil, err)
+	}
+}
+
+// send registers the handlers of a request and writes it, it fails fast when
+// the connection is down
+func (s *stream) send(raw []byte, register func(), unregister func()) error {
+	s.codecLock.RLock()
+	defer s.codecLock.RUnlock()
+
+	if !s.connected {
+		if s.isClosed() {
+			return ErrProviderClosed
+		}
+		return &TransportError{Err: ErrConnectionLost}
+	}
+
+	register()
+	if err := s.codec.Write(raw); err != nil {
+		unregister()
+		return &TransportError{Err: err}
+	}
+	return nil
+}
+
 func (s *stream) handleMsg(response Response) {
 	s.handlerLock.Lock()
 	callback, ok := s.handler[response.ID]
@@ -171,8 +420,19 @@ func (s *stream) handleMsg(response Response) {
 	}
 }
 
-func (s *stream) setHandler(id uint64, ack chan *ackMessage) {
+func (s *stream) removeHandler(id uint64) {
+	s.handlerLock.Lock()
+	delete(s.handler, id)
+	s.handlerLock.Unlock()
+}
+
+// setHandler registers the handler of the call id, onResult is run by the
+// listener when the call succeeds, before any later message is read
+func (s *stream) setHandler(id uint64, ack chan *ackMessage, onResult func(b []byte)) {
 	callback := func(b []byte, err error) {
+		if err == nil && onResult != nil {
+			onResult(b)
+		}
 		select {
 		case ack <- &ackMessage{b, err}:
 		default:
@@ -182,21 +442,58 @@ func (s *stream) setHandler(id uint64, ack chan *ackMessage) {
 	s.handlerLock.Lock()
 	s.handler[id] = callback
 	s.handlerLock.Unlock()
+}
 
-	s.timer = time.AfterFunc(5*time.Second, func() {
-		s.handlerLock.Lock()
-		delete(s.handler, id)
-		s.handlerLock.Unlock()
+// timeout returns the channel fired when a request times out, it's nil when
+// ctx has its own deadline or the stream has no default timeout
+func (s *stream) timeout(ctx context.Context) (<-chan time.Time, func()) {
+	if _, ok := ctx.Deadline(); ok || s.config.requestTimeout <= 0 {
+		return nil, func() {}
+	}
 
-		select {
-		case ack <- &ackMessage{nil, ErrTimeout}:
-		default:
-		}
-	})
+	timer := time.NewTimer(s.config.requestTimeout)
+	return timer.C, func() { timer.Stop() }
+}
+
+// acquire takes an in-flight slot, waiting while maxInFlight requests are pending
+func (s *stream) acquire(ctx context.Context, timeout <-chan time.Time) error {
+	if s.inflight == nil {
+		return nil
+	}
+
+	select {
+	case s.inflight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return ErrTimeout
+	case <-s.closeCh:
+		return ErrProviderClosed
+	}
+}
+
+func (s *stream) release() {
+	if s.inflight != nil {
+		<-s.inflight
+	}
 }
 
 // Call implements the transport interface
 func (s *stream) Call(method string, out interface{}, params interface{}) (interface{}, error) {
+	return s.CallContext(context.Background(), method, params)
+}
+
+// CallContext is like Call but stops waiting for the response once ctx is done
+func (s *stream) CallContext(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	return s.call(ctx, method, params, nil)
+}
+
+func (s *stream) call(ctx context.Context, method string, params interface{}, onResult func(b []byte)) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	seq := s.incSeq()
 	request := Request{
 		ID:     seq,
@@ -210,18 +507,38 @@ func (s *stream) Call(method string, out interface{}, params interface{}) (inter
 		request.Params = data
 	}
 
-	ack := make(chan *ackMessage)
-	s.setHandler(seq, ack)
-
 	raw, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
-	if err := s.codec.Write(raw); err != nil {
+
+	timeout, stop := s.timeout(ctx)
+	defer stop()
+	if err := s.acquire(ctx, timeout); err != nil {
 		return nil, err
 	}
+	defer s.release()
 
-	resp := <-ack
+	ack := make(chan *ackMessage, 1)
+	err = s.send(raw, func() {
+		s.setHandler(seq, ack, onResult)
+	}, func() {
+		s.removeHandler(seq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *ackMessage
+	select {
+	case resp = <-ack:
+	case <-ctx.Done():
+		s.removeHandler(seq)
+		return nil, ctx.Err()
+	case <-timeout:
+		s.removeHandler(seq)
+		return nil, ErrTimeout
+	}
 	if resp.err != nil {
 		return nil, resp.err
 	}
@@ -234,8 +551,130 @@ func (s *stream) Call(method string, out interface{}, params interface{}) (inter
 
 }
 
+// CallInto calls method and decodes its response, as a dto.RequestResult, into v
+func (s *stream) CallInto(ctx context.Context, v interface{}, method string, params interface{}) error {
+	value, err := s.CallContext(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(jsonValue, v); err != nil {
+		return &DecodeError{Body: jsonValue, Err: err}
+	}
+	return nil
+}
+
+// BatchCallContext sends elems as one message and waits for all of their responses
+func (s *stream) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	seqs := make([]uint64, len(elems))
+	acks := make([]chan *ackMessage, len(elems))
+	requests := make([]Request, len(elems))
+	for i, elem := range elems {
+		seqs[i] = s.incSeq()
+		requests[i] = Request{
+			ID:     seqs[i],
+			Method: elem.Method,
+		}
+		if elem.Params != nil {
+			data, err := json.Marshal(elem.Params)
+			if err != nil {
+				return err
+			}
+			requests[i].Params = data
+		}
+	}
+
+	removeHandlers := func() {
+		for _, seq := range seqs {
+			s.removeHandler(seq)
+		}
+	}
+
+	raw, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+
+	// the batch takes a single in-flight slot, it's written as one message
+	timeout, stop := s.timeout(ctx)
+	defer stop()
+	if err := s.acquire(ctx, timeout); err != nil {
+		return err
+	}
+	defer s.release()
+
+	err = s.send(raw, func() {
+		for i, seq := range seqs {
+			acks[i] = make(chan *ackMessage, 1)
+			s.setHandler(seq, acks[i], nil)
+		}
+	}, removeHandlers)
+	if err != nil {
+		return err
+	}
+
+	for i := range elems {
+		var resp *ackMessage
+		select {
+		case resp = <-acks[i]:
+		case <-ctx.Done():
+			removeHandlers()
+			return ctx.Err()
+		case <-timeout:
+			removeHandlers()
+			return ErrTimeout
+		}
+		if resp.err != nil {
+			// the connection lost with the batch in flight fails the whole batch
+			if !isElementError(resp.err) {
+				return resp.err
+			}
+			elems[i].Error = resp.err
+			continue
+		}
+		if elems[i].Result == nil {
+			continue
+		}
+
+		full, err := json.Marshal(Response{ID: seqs[i], Result: resp.buf})
+		if err != nil {
+			elems[i].Error = err
+			continue
+		}
+		if err := json.Unmarshal(full, elems[i].Result); err != nil {
+			elems[i].Error = &DecodeError{Body: full, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func isBatch(msg []byte) bool {
+	for _, c := range msg {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c == '['
+	}
+	return false
+}
+
 type websocketCodec struct {
 	conn *websocket.Conn
+
+	// the connection supports a single concurrent writer
+	writeLock sync.Mutex
 }
 
 func (w *websocketCodec) Close() error {
@@ -243,6 +682,8 @@ func (w *websocketCodec) Close() error {
 }
 
 func (w *websocketCodec) Write(b []byte) error {
+	w.writeLock.Lock()
+	defer w.writeLock.Unlock()
 	return w.conn.WriteMessage(websocket.TextMessage, b)
 }
 
@@ -262,27 +703,13 @@ type Request struct {
 	Params json.RawMessage `json:"params"`
 }
 
-// Response is a jsonrpc response
+// Response is a jsonrpc response, or a subscription notification when Method is set
 type Response struct {
 	ID     uint64          `json:"id"`
 	Result json.RawMessage `json:"result"`
 	Error  *ErrorObject    `json:"error,omitempty"`
-}
-
-// ErrorObject is a jsonrpc error
-type ErrorObject struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-}
-
-// Error implements error interface
-func (e *ErrorObject) Error() string {
-	data, err := json.Marshal(e)
-	if err != nil {
-		return fmt.Sprintf("jsonrpc.internal marshal error: %v", err)
-	}
-	return string(data)
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
 }
 
 // Codec is the codec to write and read messages
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
@@ -64,6 +65,11 @@ func registerDirectorPreCheck(directorInfo dto.AllianceInfo) error {
 }
 
 func (ali *Alliance) RegisterDirector(signTxParams *SysTxParams, directorInfo *dto.AllianceInfo) (string, error) {
+	return ali.RegisterDirectorCtx(context.Background(), signTxParams, directorInfo)
+}
+
+// RegisterDirectorCtx is like RegisterDirector but honours the cancellation and deadline of ctx.
+func (ali *Alliance) RegisterDirectorCtx(ctx context.Context, signTxParams *SysTxParams, directorInfo *dto.AllianceInfo) (string, error) {
 	err := registerDirectorPreCheck(*directorInfo)
 	if err != nil {
 		return "", err
@@ -77,15 +83,15 @@ func (ali *Alliance) RegisterDirector(signTxParams *SysTxParams, directorInfo *d
 		return "", err
 	}
 
-	signedTx, err := ali.super.prePareSignTransaction(signTxParams, inputEncode, AllianceContract)
-	if err != nil {
-		return "", err
-	}
-
-	return ali.super.sendRawTransaction(signedTx)
+	return ali.super.sendTransaction(ctx, signTxParams, inputEncode, AllianceContract)
 }
 
 func (ali *Alliance) UpgradeDirector(signTxParams *SysTxParams, director string) (string, error) {
+	return ali.UpgradeDirectorCtx(context.Background(), signTxParams, director)
+}
+
+// UpgradeDirectorCtx is like UpgradeDirector but honours the cancellation and deadline of ctx.
+func (ali *Alliance) UpgradeDirectorCtx(ctx context.Context, signTxParams *SysTxParams, director string) (string, error) {
 	if !isValidHexAddress(director) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -96,15 +102,15 @@ func (ali *Alliance) UpgradeDirector(signTxParams *SysTxParams, director string)
 		return "", err
 	}
 
-	signedTx, err := ali.super.prePareSignTransaction(signTxParams, inputEncode, AllianceContract)
-	if err != nil {
-		return "", err
-	}
-
-	return ali.super.sendRawTransaction(signedTx)
+	return ali.super.sendTransaction(ctx, signTxParams, inputEncode, AllianceContract)
 }
 
 func (ali *Alliance) Revoke(signTxParams *SysTxParams, member string, revokeReason string) (string, error) {
+	return ali.RevokeCtx(context.Background(), signTxParams, member, revokeReason)
+}
+
+// RevokeCtx is like Revoke but honours the cancellation and deadline of ctx.
+func (ali *Alliance) RevokeCtx(ctx context.Context, signTxParams *SysTxParams, member string, revokeReason string) (string, error) {
 	if !isValidHexAddress(member) {
 		return "", errors.New("member is not valid bid")
 	}
@@ -123,15 +129,15 @@ func (ali *Alliance) Revoke(signTxParams *SysTxParams, member string, revokeReas
 		return "", err
 	}
 
-	signedTx, err := ali.super.prePareSignTransaction(signTxParams, inputEncode, AllianceContract)
-	if err != nil {
-		return "", err
-	}
-
-	return ali.super.sendRawTransaction(signedTx)
+	return ali.super.sendTransaction(ctx, signTxParams, inputEncode, AllianceContract)
 }
 
 func (ali *Alliance) SetWeights(signTxParams *SysTxParams, directorWeights, viceWeights, directorGeneralWeights uint64) (string, error) {
+	return ali.SetWeightsCtx(context.Background(), signTxParams, directorWeights, viceWeights, directorGeneralWeights)
+}
+
+// SetWeightsCtx is like SetWeights but honours the cancellation and deadline of ctx.
+func (ali *Alliance) SetWeightsCtx(ctx context.Context, signTxParams *SysTxParams, directorWeights, viceWeights, directorGeneralWeights uint64) (string, error) {
 	// Revoke is a struct we need to use the components.
 	var values []interface{}
 	type Weights struct {
@@ -147,18 +153,18 @@ func (ali *Alliance) SetWeights(signTxParams *SysTxParams, directorWeights, vice
 		return "", err
 	}
 
-	signedTx, err := ali.super.prePareSignTransaction(signTxParams, inputEncode, AllianceContract)
-	if err != nil {
-		return "", err
-	}
-
-	return ali.super.sendRawTransaction(signedTx)
+	return ali.super.sendTransaction(ctx, signTxParams, inputEncode, AllianceContract)
 }
 
 func (ali *Alliance) AllDirectors() ([]*dto.Alliance, error) {
+	return ali.AllDirectorsCtx(context.Background())
+}
+
+// AllDirectorsCtx is like AllDirectors but honours the cancellation and deadline of ctx.
+func (ali *Alliance) AllDirectorsCtx(ctx context.Context) ([]*dto.Alliance, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_directors", nil)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_directors", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -172,9 +178,14 @@ func (ali *Alliance) AllDirectors() ([]*dto.Alliance, error) {
 }
 
 func (ali *Alliance) AllVices() ([]*dto.Alliance, error) {
+	return ali.AllVicesCtx(context.Background())
+}
+
+// AllVicesCtx is like AllVices but honours the cancellation and deadline of ctx.
+func (ali *Alliance) AllVicesCtx(ctx context.Context) ([]*dto.Alliance, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_vices", nil)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_vices", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -188,9 +199,14 @@ func (ali *Alliance) AllVices() ([]*dto.Alliance, error) {
 }
 
 func (ali *Alliance) AllDirectorGenerals() ([]*dto.Alliance, error) {
+	return ali.AllDirectorGeneralsCtx(context.Background())
+}
+
+// AllDirectorGeneralsCtx is like AllDirectorGenerals but honours the cancellation and deadline of ctx.
+func (ali *Alliance) AllDirectorGeneralsCtx(ctx context.Context) ([]*dto.Alliance, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_directorGenerals", nil)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_directorGenerals", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -204,9 +220,14 @@ func (ali *Alliance) AllDirectorGenerals() ([]*dto.Alliance, error) {
 }
 
 func (ali *Alliance) AllAlliances() ([]*dto.Alliance, error) {
+	return ali.AllAlliancesCtx(context.Background())
+}
+
+// AllAlliancesCtx is like AllAlliances but honours the cancellation and deadline of ctx.
+func (ali *Alliance) AllAlliancesCtx(ctx context.Context) ([]*dto.Alliance, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_alliances", nil)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_alliances", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +241,11 @@ func (ali *Alliance) AllAlliances() ([]*dto.Alliance, error) {
 }
 
 func (ali *Alliance) GetAlliance(id string) (*dto.Alliance, error) {
+	return ali.GetAllianceCtx(context.Background(), id)
+}
+
+// GetAllianceCtx is like GetAlliance but honours the cancellation and deadline of ctx.
+func (ali *Alliance) GetAllianceCtx(ctx context.Context, id string) (*dto.Alliance, error) {
 	if !isValidHexAddress(id) {
 		return nil, errors.New("id is not valid bid")
 	}
@@ -229,7 +255,7 @@ func (ali *Alliance) GetAlliance(id string) (*dto.Alliance, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_alliance", params)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_alliance", params)
 	if err != nil {
 		return nil, err
 	}
@@ -243,9 +269,14 @@ func (ali *Alliance) GetAlliance(id string) (*dto.Alliance, error) {
 }
 
 func (ali *Alliance) GetWeights() (*dto.Weights, error) {
+	return ali.GetWeightsCtx(context.Background())
+}
+
+// GetWeightsCtx is like GetWeights but honours the cancellation and deadline of ctx.
+func (ali *Alliance) GetWeightsCtx(ctx context.Context) (*dto.Weights, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := ali.super.provider.SendRequest(pointer, "alliance_weights", nil)
+	err := ali.super.provider.SendRequestContext(ctx, pointer, "alliance_weights", nil)
 	if err != nil {
 		return nil, err
 	}
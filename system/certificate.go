@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/account"
@@ -86,6 +87,11 @@ func (cer *Certificate) registerCertificatePreCheck(registerCertificate dto.Regi
   Call permissions: 只有信任锚地址可以调用
 */
 func (cer *Certificate) RegisterCertificate(signTxParams *SysTxParams, registerCertificate *dto.RegisterCertificate) (string, error) {
+	return cer.RegisterCertificateCtx(context.Background(), signTxParams, registerCertificate)
+}
+
+// RegisterCertificateCtx is like RegisterCertificate but honours the cancellation and deadline of ctx.
+func (cer *Certificate) RegisterCertificateCtx(ctx context.Context, signTxParams *SysTxParams, registerCertificate *dto.RegisterCertificate) (string, error) {
 	ok, err := cer.registerCertificatePreCheck(*registerCertificate)
 	if !ok {
 		return "", err
@@ -103,12 +109,7 @@ func (cer *Certificate) RegisterCertificate(signTxParams *SysTxParams, registerC
 		return "", err
 	}
 
-	signedTx, err := cer.super.prePareSignTransaction(signTxParams, inputEncode, CertificateContract)
-	if err != nil {
-		return "", err
-	}
-
-	return cer.super.sendRawTransaction(signedTx)
+	return cer.super.sendTransaction(ctx, signTxParams, inputEncode, CertificateContract)
 }
 
 /*
@@ -126,6 +127,11 @@ func (cer *Certificate) RegisterCertificate(signTxParams *SysTxParams, registerC
   Call permissions: 只有证书颁发者可以调用
 */
 func (cer *Certificate) RevokedCertificate(signTxParams *SysTxParams, id string) (string, error) {
+	return cer.RevokedCertificateCtx(context.Background(), signTxParams, id)
+}
+
+// RevokedCertificateCtx is like RevokedCertificate but honours the cancellation and deadline of ctx.
+func (cer *Certificate) RevokedCertificateCtx(ctx context.Context, signTxParams *SysTxParams, id string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -136,12 +142,7 @@ func (cer *Certificate) RevokedCertificate(signTxParams *SysTxParams, id string)
 		return "", err
 	}
 
-	signedTx, err := cer.super.prePareSignTransaction(signTxParams, inputEncode, CertificateContract)
-	if err != nil {
-		return "", err
-	}
-
-	return cer.super.sendRawTransaction(signedTx)
+	return cer.super.sendTransaction(ctx, signTxParams, inputEncode, CertificateContract)
 }
 
 /*
@@ -158,15 +159,15 @@ func (cer *Certificate) RevokedCertificate(signTxParams *SysTxParams, id string)
   Call permissions: 只有证书颁发者可以调用
 */
 func (cer *Certificate) RevokedCertificates(signTxParams *SysTxParams) (string, error) {
+	return cer.RevokedCertificatesCtx(context.Background(), signTxParams)
+}
+
+// RevokedCertificatesCtx is like RevokedCertificates but honours the cancellation and deadline of ctx.
+func (cer *Certificate) RevokedCertificatesCtx(ctx context.Context, signTxParams *SysTxParams) (string, error) {
 	// encoding
 	inputEncode, _ := cer.abi.Pack("revokedCertificates")
 
-	signedTx, err := cer.super.prePareSignTransaction(signTxParams, inputEncode, CertificateContract)
-	if err != nil {
-		return "", err
-	}
-
-	return cer.super.sendRawTransaction(signedTx)
+	return cer.super.sendTransaction(ctx, signTxParams, inputEncode, CertificateContract)
 }
 
 /*
@@ -183,6 +184,11 @@ func (cer *Certificate) RevokedCertificates(signTxParams *SysTxParams) (string,
   Call permissions: Anyone
 */
 func (cer *Certificate) GetPeriod(id string) (uint64, error) {
+	return cer.GetPeriodCtx(context.Background(), id)
+}
+
+// GetPeriodCtx is like GetPeriod but honours the cancellation and deadline of ctx.
+func (cer *Certificate) GetPeriodCtx(ctx context.Context, id string) (uint64, error) {
 	if !isValidHexAddress(id) {
 		return 0, errors.New("id is not valid bid")
 	}
@@ -192,7 +198,7 @@ func (cer *Certificate) GetPeriod(id string) (uint64, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := cer.super.provider.SendRequest(pointer, "certificate_period", params)
+	err := cer.super.provider.SendRequestContext(ctx, pointer, "certificate_period", params)
 	if err != nil {
 		return 0, err
 	}
@@ -214,6 +220,11 @@ func (cer *Certificate) GetPeriod(id string) (uint64, error) {
   Call permissions: Anyone
 */
 func (cer *Certificate) GetActive(id string) (bool, error) {
+	return cer.GetActiveCtx(context.Background(), id)
+}
+
+// GetActiveCtx is like GetActive but honours the cancellation and deadline of ctx.
+func (cer *Certificate) GetActiveCtx(ctx context.Context, id string) (bool, error) {
 	if !isValidHexAddress(id) {
 		return false, errors.New("id is not valid bid")
 	}
@@ -223,7 +234,7 @@ func (cer *Certificate) GetActive(id string) (bool, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := cer.super.provider.SendRequest(pointer, "certificate_active", params)
+	err := cer.super.provider.SendRequestContext(ctx, pointer, "certificate_active", params)
 	if err != nil {
 		return false, err
 	}
@@ -253,6 +264,11 @@ func (cer *Certificate) GetActive(id string) (bool, error) {
   Call permissions:
 */
 func (cer *Certificate) GetCertificate(id string) (dto.CertificateInfo, error) {
+	return cer.GetCertificateCtx(context.Background(), id)
+}
+
+// GetCertificateCtx is like GetCertificate but honours the cancellation and deadline of ctx.
+func (cer *Certificate) GetCertificateCtx(ctx context.Context, id string) (dto.CertificateInfo, error) {
 	var certificate dto.CertificateInfo
 	if !isValidHexAddress(id) {
 		return certificate, errors.New("id is not valid bid")
@@ -263,7 +279,7 @@ func (cer *Certificate) GetCertificate(id string) (dto.CertificateInfo, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := cer.super.provider.SendRequest(pointer, "certificate_certificate", params)
+	err := cer.super.provider.SendRequestContext(ctx, pointer, "certificate_certificate", params)
 	if err != nil {
 		return certificate, err
 	}
@@ -294,6 +310,11 @@ func (cer *Certificate) GetCertificate(id string) (dto.CertificateInfo, error) {
   Call permissions: Anyone
 */
 func (cer *Certificate) GetIssuer(id string) (dto.IssuerSignature, error) {
+	return cer.GetIssuerCtx(context.Background(), id)
+}
+
+// GetIssuerCtx is like GetIssuer but honours the cancellation and deadline of ctx.
+func (cer *Certificate) GetIssuerCtx(ctx context.Context, id string) (dto.IssuerSignature, error) {
 	var issuerSignature dto.IssuerSignature
 	if !isValidHexAddress(id) {
 		return issuerSignature, errors.New("id is not valid bid")
@@ -304,7 +325,7 @@ func (cer *Certificate) GetIssuer(id string) (dto.IssuerSignature, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := cer.super.provider.SendRequest(pointer, "certificate_issuer", params)
+	err := cer.super.provider.SendRequestContext(ctx, pointer, "certificate_issuer", params)
 	if err != nil {
 		return issuerSignature, err
 	}
@@ -335,6 +356,11 @@ func (cer *Certificate) GetIssuer(id string) (dto.IssuerSignature, error) {
   Call permissions: Anyone
 */
 func (cer *Certificate) GetSubject(id string) (dto.SubjectSignature, error) {
+	return cer.GetSubjectCtx(context.Background(), id)
+}
+
+// GetSubjectCtx is like GetSubject but honours the cancellation and deadline of ctx.
+func (cer *Certificate) GetSubjectCtx(ctx context.Context, id string) (dto.SubjectSignature, error) {
 	var subjectSignature dto.SubjectSignature
 	if !isValidHexAddress(id) {
 		return subjectSignature, errors.New("id is not valid bid")
@@ -345,7 +371,7 @@ func (cer *Certificate) GetSubject(id string) (dto.SubjectSignature, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := cer.super.provider.SendRequest(pointer, "certificate_subject", params)
+	err := cer.super.provider.SendRequestContext(ctx, pointer, "certificate_subject", params)
 	if err != nil {
 		return subjectSignature, err
 	}
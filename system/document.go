@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/dto"
@@ -40,6 +41,11 @@ func (sys *System) NewDoc() *Doc {
   Call permissions: 只能初始自己的文档，且只能初始化一次
 */
 func (doc *Doc) Init(signTxParams *SysTxParams, bidType uint64) (string, error) {
+	return doc.InitCtx(context.Background(), signTxParams, bidType)
+}
+
+// InitCtx is like Init but honours the cancellation and deadline of ctx.
+func (doc *Doc) InitCtx(ctx context.Context, signTxParams *SysTxParams, bidType uint64) (string, error) {
 	if bidType != 0 && bidType != 1 && bidType != 2 {
 		return "", errors.New("bidType should be 0, 1, or 2")
 	}
@@ -50,12 +56,7 @@ func (doc *Doc) Init(signTxParams *SysTxParams, bidType uint64) (string, error)
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -74,6 +75,11 @@ func (doc *Doc) Init(signTxParams *SysTxParams, bidType uint64) (string, error)
   Call permissions: 权限为`all`
 */
 func (doc *Doc) SetBidName(signTxParams *SysTxParams, id string, bidName string) (string, error) {
+	return doc.SetBidNameCtx(context.Background(), signTxParams, id, bidName)
+}
+
+// SetBidNameCtx is like SetBidName but honours the cancellation and deadline of ctx.
+func (doc *Doc) SetBidNameCtx(ctx context.Context, signTxParams *SysTxParams, id string, bidName string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -88,12 +94,7 @@ func (doc *Doc) SetBidName(signTxParams *SysTxParams, id string, bidName string)
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -123,6 +124,11 @@ func (doc *Doc) SetBidName(signTxParams *SysTxParams, id string, bidName string)
   todo:文档初始化的时间采用的是0时区的，所以时间会差8小时，这个后期根据需要再修改
 */
 func (doc *Doc) GetDocument(did string) (dto.Document, error) {
+	return doc.GetDocumentCtx(context.Background(), did)
+}
+
+// GetDocumentCtx is like GetDocument but honours the cancellation and deadline of ctx.
+func (doc *Doc) GetDocumentCtx(ctx context.Context, did string) (dto.Document, error) {
 	var document dto.Document
 	if len(did) == 0 || isBlankCharacter(did) {
 		return document, errors.New("did can't be empty or blank character")
@@ -133,7 +139,7 @@ func (doc *Doc) GetDocument(did string) (dto.Document, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := doc.super.provider.SendRequest(pointer, "document_document", params)
+	err := doc.super.provider.SendRequestContext(ctx, pointer, "document_document", params)
 	if err != nil {
 		return document, err
 	}
@@ -164,6 +170,11 @@ func (doc *Doc) GetDocument(did string) (dto.Document, error) {
   Call permissions: 权限为`all`
 */
 func (doc *Doc) AddPublic(signTxParams *SysTxParams, id string, publicType string, publicAuth string, publicKey string) (string, error) {
+	return doc.AddPublicCtx(context.Background(), signTxParams, id, publicType, publicAuth, publicKey)
+}
+
+// AddPublicCtx is like AddPublic but honours the cancellation and deadline of ctx.
+func (doc *Doc) AddPublicCtx(ctx context.Context, signTxParams *SysTxParams, id string, publicType string, publicAuth string, publicKey string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -191,12 +202,7 @@ func (doc *Doc) AddPublic(signTxParams *SysTxParams, id string, publicType strin
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -215,6 +221,11 @@ func (doc *Doc) AddPublic(signTxParams *SysTxParams, id string, publicType strin
   Call permissions: 权限为`all`
 */
 func (doc *Doc) DelPublic(signTxParams *SysTxParams, id string, publicKey string) (string, error) {
+	return doc.DelPublicCtx(context.Background(), signTxParams, id, publicKey)
+}
+
+// DelPublicCtx is like DelPublic but honours the cancellation and deadline of ctx.
+func (doc *Doc) DelPublicCtx(ctx context.Context, signTxParams *SysTxParams, id string, publicKey string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -236,12 +247,7 @@ func (doc *Doc) DelPublic(signTxParams *SysTxParams, id string, publicKey string
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -260,6 +266,11 @@ func (doc *Doc) DelPublic(signTxParams *SysTxParams, id string, publicKey string
   Call permissions: 权限为all、update
 */
 func (doc *Doc) AddAuth(signTxParams *SysTxParams, id string, auth string) (string, error) {
+	return doc.AddAuthCtx(context.Background(), signTxParams, id, auth)
+}
+
+// AddAuthCtx is like AddAuth but honours the cancellation and deadline of ctx.
+func (doc *Doc) AddAuthCtx(ctx context.Context, signTxParams *SysTxParams, id string, auth string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -273,12 +284,7 @@ func (doc *Doc) AddAuth(signTxParams *SysTxParams, id string, auth string) (stri
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -297,6 +303,11 @@ func (doc *Doc) AddAuth(signTxParams *SysTxParams, id string, auth string) (stri
   Call permissions: 权限为all、update
 */
 func (doc *Doc) DelAuth(signTxParams *SysTxParams, id string, auth string) (string, error) {
+	return doc.DelAuthCtx(context.Background(), signTxParams, id, auth)
+}
+
+// DelAuthCtx is like DelAuth but honours the cancellation and deadline of ctx.
+func (doc *Doc) DelAuthCtx(ctx context.Context, signTxParams *SysTxParams, id string, auth string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -310,12 +321,7 @@ func (doc *Doc) DelAuth(signTxParams *SysTxParams, id string, auth string) (stri
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -336,6 +342,11 @@ func (doc *Doc) DelAuth(signTxParams *SysTxParams, id string, auth string) (stri
   Call permissions: 权限为all、update
 */
 func (doc *Doc) AddService(signTxParams *SysTxParams, id string, serviceId string, serviceType string, serviceEndpoint string) (string, error) {
+	return doc.AddServiceCtx(context.Background(), signTxParams, id, serviceId, serviceType, serviceEndpoint)
+}
+
+// AddServiceCtx is like AddService but honours the cancellation and deadline of ctx.
+func (doc *Doc) AddServiceCtx(ctx context.Context, signTxParams *SysTxParams, id string, serviceId string, serviceType string, serviceEndpoint string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -355,12 +366,7 @@ func (doc *Doc) AddService(signTxParams *SysTxParams, id string, serviceId strin
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -379,6 +385,11 @@ func (doc *Doc) AddService(signTxParams *SysTxParams, id string, serviceId strin
   Call permissions: 权限为all、update
 */
 func (doc *Doc) DelService(signTxParams *SysTxParams, id string, serviceId string) (string, error) {
+	return doc.DelServiceCtx(context.Background(), signTxParams, id, serviceId)
+}
+
+// DelServiceCtx is like DelService but honours the cancellation and deadline of ctx.
+func (doc *Doc) DelServiceCtx(ctx context.Context, signTxParams *SysTxParams, id string, serviceId string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -392,11 +403,7 @@ func (doc *Doc) DelService(signTxParams *SysTxParams, id string, serviceId strin
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -417,6 +424,11 @@ func (doc *Doc) DelService(signTxParams *SysTxParams, id string, serviceId strin
   Call permissions: 权限为all、update
 */
 func (doc *Doc) AddProof(signTxParams *SysTxParams, id string, proofType string, proofCreator string, proofSign string) (string, error) {
+	return doc.AddProofCtx(context.Background(), signTxParams, id, proofType, proofCreator, proofSign)
+}
+
+// AddProofCtx is like AddProof but honours the cancellation and deadline of ctx.
+func (doc *Doc) AddProofCtx(ctx context.Context, signTxParams *SysTxParams, id string, proofType string, proofCreator string, proofSign string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -436,12 +448,7 @@ func (doc *Doc) AddProof(signTxParams *SysTxParams, id string, proofType string,
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -459,6 +466,11 @@ func (doc *Doc) AddProof(signTxParams *SysTxParams, id string, proofType string,
   Call permissions: 权限为all、update
 */
 func (doc *Doc) DelProof(signTxParams *SysTxParams, id string) (string, error) {
+	return doc.DelProofCtx(context.Background(), signTxParams, id)
+}
+
+// DelProofCtx is like DelProof but honours the cancellation and deadline of ctx.
+func (doc *Doc) DelProofCtx(ctx context.Context, signTxParams *SysTxParams, id string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -469,12 +481,7 @@ func (doc *Doc) DelProof(signTxParams *SysTxParams, id string) (string, error) {
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -493,6 +500,11 @@ func (doc *Doc) DelProof(signTxParams *SysTxParams, id string) (string, error) {
   Call permissions: publicAuth权限为all、update
 */
 func (doc *Doc) AddExtra(signTxParams *SysTxParams, id string, extra string) (string, error) {
+	return doc.AddExtraCtx(context.Background(), signTxParams, id, extra)
+}
+
+// AddExtraCtx is like AddExtra but honours the cancellation and deadline of ctx.
+func (doc *Doc) AddExtraCtx(ctx context.Context, signTxParams *SysTxParams, id string, extra string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -507,12 +519,7 @@ func (doc *Doc) AddExtra(signTxParams *SysTxParams, id string, extra string) (st
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -530,6 +537,11 @@ func (doc *Doc) AddExtra(signTxParams *SysTxParams, id string, extra string) (st
   Call permissions: publicAuth权限为all、update
 */
 func (doc *Doc) DelExtra(signTxParams *SysTxParams, id string) (string, error) {
+	return doc.DelExtraCtx(context.Background(), signTxParams, id)
+}
+
+// DelExtraCtx is like DelExtra but honours the cancellation and deadline of ctx.
+func (doc *Doc) DelExtraCtx(ctx context.Context, signTxParams *SysTxParams, id string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -540,12 +552,7 @@ func (doc *Doc) DelExtra(signTxParams *SysTxParams, id string) (string, error) {
 		return "", err
 	}
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -563,6 +570,11 @@ func (doc *Doc) DelExtra(signTxParams *SysTxParams, id string) (string, error) {
   Call permissions: 自身调用或者拥有相关权限的其他地址
 */
 func (doc *Doc) Enable(signTxParams *SysTxParams, id string) (string, error) {
+	return doc.EnableCtx(context.Background(), signTxParams, id)
+}
+
+// EnableCtx is like Enable but honours the cancellation and deadline of ctx.
+func (doc *Doc) EnableCtx(ctx context.Context, signTxParams *SysTxParams, id string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -570,12 +582,7 @@ func (doc *Doc) Enable(signTxParams *SysTxParams, id string) (string, error) {
 	// encoding
 	inputEncode, _ := doc.abi.Pack("enable", id)
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -593,6 +600,11 @@ func (doc *Doc) Enable(signTxParams *SysTxParams, id string) (string, error) {
   Call permissions: 自身调用或者拥有相关权限的其他地址
 */
 func (doc *Doc) Disable(signTxParams *SysTxParams, id string) (string, error) {
+	return doc.DisableCtx(context.Background(), signTxParams, id)
+}
+
+// DisableCtx is like Disable but honours the cancellation and deadline of ctx.
+func (doc *Doc) DisableCtx(ctx context.Context, signTxParams *SysTxParams, id string) (string, error) {
 	if !isValidHexAddress(id) {
 		return "", errors.New("id is not valid bid")
 	}
@@ -600,12 +612,7 @@ func (doc *Doc) Disable(signTxParams *SysTxParams, id string) (string, error) {
 	// encoding
 	inputEncode, _ := doc.abi.Pack("disable", id)
 
-	signedTx, err := doc.super.prePareSignTransaction(signTxParams, inputEncode, DocumentContract)
-	if err != nil {
-		return "", err
-	}
-
-	return doc.super.sendRawTransaction(signedTx)
+	return doc.super.sendTransaction(ctx, signTxParams, inputEncode, DocumentContract)
 }
 
 /*
@@ -622,6 +629,11 @@ func (doc *Doc) Disable(signTxParams *SysTxParams, id string) (string, error) {
   Call permissions: Anyone
 */
 func (doc *Doc) IsEnable(did string) (bool, error) {
+	return doc.IsEnableCtx(context.Background(), did)
+}
+
+// IsEnableCtx is like IsEnable but honours the cancellation and deadline of ctx.
+func (doc *Doc) IsEnableCtx(ctx context.Context, did string) (bool, error) {
 	if len(did) == 0 || isBlankCharacter(did) {
 		return false, errors.New("did can't be empty or blank character")
 	}
@@ -631,7 +643,7 @@ func (doc *Doc) IsEnable(did string) (bool, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := doc.super.provider.SendRequest(pointer, "document_isEnable", params)
+	err := doc.super.provider.SendRequestContext(ctx, pointer, "document_isEnable", params)
 	if err != nil {
 		return false, err
 	}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"math/big"
@@ -31,12 +32,17 @@ Returns:
 Call permissions: Anyone
 */
 func (dp *DPoS) GetValidators(blockNumber *big.Int) ([]string, error) {
+	return dp.GetValidatorsCtx(context.Background(), blockNumber)
+}
+
+// GetValidatorsCtx is like GetValidators but honours the cancellation and deadline of ctx.
+func (dp *DPoS) GetValidatorsCtx(ctx context.Context, blockNumber *big.Int) ([]string, error) {
 	params := make([]interface{}, 1)
 	params[0] = hexutil.EncodeBig(blockNumber)
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := dp.super.provider.SendRequest(pointer, "dpos_getValidators", params)
+	err := dp.super.provider.SendRequestContext(ctx, pointer, "dpos_getValidators", params)
 
 	if err != nil {
 		return nil, err
@@ -58,12 +64,17 @@ Returns:
 Call permissions: Anyone
 */
 func (dp *DPoS) GetValidatorsAtHash(hash string) ([]string, error) {
+	return dp.GetValidatorsAtHashCtx(context.Background(), hash)
+}
+
+// GetValidatorsAtHashCtx is like GetValidatorsAtHash but honours the cancellation and deadline of ctx.
+func (dp *DPoS) GetValidatorsAtHashCtx(ctx context.Context, hash string) ([]string, error) {
 	params := make([]interface{}, 1)
 	params[0] = hash
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := dp.super.provider.SendRequest(pointer, "dpos_getValidatorsAtHash", params)
+	err := dp.super.provider.SendRequestContext(ctx, pointer, "dpos_getValidatorsAtHash", params)
 
 	if err != nil {
 		return nil, err
@@ -92,9 +103,14 @@ Returns:
 Call permissions: Anyone
 */
 func (dp *DPoS) RoundStateInfo() (*dto.RoundStateInfo, error) {
+	return dp.RoundStateInfoCtx(context.Background())
+}
+
+// RoundStateInfoCtx is like RoundStateInfo but honours the cancellation and deadline of ctx.
+func (dp *DPoS) RoundStateInfoCtx(ctx context.Context) (*dto.RoundStateInfo, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := dp.super.provider.SendRequest(pointer, "dpos_roundStateInfo", nil)
+	err := dp.super.provider.SendRequestContext(ctx, pointer, "dpos_roundStateInfo", nil)
 
 	if err != nil {
 		return nil, err
@@ -118,9 +134,14 @@ Returns:
 Call permissions: Anyone
 */
 func (dp *DPoS) RoundChangeSetInfo() (*dto.RoundChangeSetInfo, error) {
+	return dp.RoundChangeSetInfoCtx(context.Background())
+}
+
+// RoundChangeSetInfoCtx is like RoundChangeSetInfo but honours the cancellation and deadline of ctx.
+func (dp *DPoS) RoundChangeSetInfoCtx(ctx context.Context) (*dto.RoundChangeSetInfo, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := dp.super.provider.SendRequest(pointer, "dpos_roundChangeSetInfo", nil)
+	err := dp.super.provider.SendRequestContext(ctx, pointer, "dpos_roundChangeSetInfo", nil)
 
 	if err != nil {
 		return nil, err
@@ -142,9 +163,14 @@ Returns:
 Call permissions: Anyone
 */
 func (dp *DPoS) Backlogs() (map[string][]*dto.Message, error) {
+	return dp.BacklogsCtx(context.Background())
+}
+
+// BacklogsCtx is like Backlogs but honours the cancellation and deadline of ctx.
+func (dp *DPoS) BacklogsCtx(ctx context.Context) (map[string][]*dto.Message, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := dp.super.provider.SendRequest(pointer, "dpos_backlogs", nil)
+	err := dp.super.provider.SendRequestContext(ctx, pointer, "dpos_backlogs", nil)
 
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk/abi"
@@ -82,6 +83,11 @@ func registerTrustNodePreCheck(trustNode *dto.PeerNodeInfo) error {
 }
 
 func (e *Election) RegisterTrustNode(signTxParams *SysTxParams, trustNode *dto.PeerNodeInfo, idPassword string, idKeyFile []byte) (string, error) {
+	return e.RegisterTrustNodeCtx(context.Background(), signTxParams, trustNode, idPassword, idKeyFile)
+}
+
+// RegisterTrustNodeCtx is like RegisterTrustNode but honours the cancellation and deadline of ctx.
+func (e *Election) RegisterTrustNodeCtx(ctx context.Context, signTxParams *SysTxParams, trustNode *dto.PeerNodeInfo, idPassword string, idKeyFile []byte) (string, error) {
 	err := registerTrustNodePreCheck(trustNode)
 	if err != nil {
 		return "", err
@@ -105,15 +111,15 @@ func (e *Election) RegisterTrustNode(signTxParams *SysTxParams, trustNode *dto.P
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) DeleteTrustNode(signTxParams *SysTxParams, trustNodeId string, revokeReason string) (string, error) {
+	return e.DeleteTrustNodeCtx(context.Background(), signTxParams, trustNodeId, revokeReason)
+}
+
+// DeleteTrustNodeCtx is like DeleteTrustNode but honours the cancellation and deadline of ctx.
+func (e *Election) DeleteTrustNodeCtx(ctx context.Context, signTxParams *SysTxParams, trustNodeId string, revokeReason string) (string, error) {
 	if !isValidHexAddress(trustNodeId) {
 		return "", errors.New("trustNodeId is not valid bid")
 	}
@@ -132,15 +138,15 @@ func (e *Election) DeleteTrustNode(signTxParams *SysTxParams, trustNodeId string
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) ApplyCandidate(signTxParams *SysTxParams, candidateAddress string) (string, error) {
+	return e.ApplyCandidateCtx(context.Background(), signTxParams, candidateAddress)
+}
+
+// ApplyCandidateCtx is like ApplyCandidate but honours the cancellation and deadline of ctx.
+func (e *Election) ApplyCandidateCtx(ctx context.Context, signTxParams *SysTxParams, candidateAddress string) (string, error) {
 	if !isValidHexAddress(candidateAddress) {
 		return "", errors.New("candidateAddress is not valid bid")
 	}
@@ -151,15 +157,15 @@ func (e *Election) ApplyCandidate(signTxParams *SysTxParams, candidateAddress st
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) CancelCandidate(signTxParams *SysTxParams, candidateAddress string) (string, error) {
+	return e.CancelCandidateCtx(context.Background(), signTxParams, candidateAddress)
+}
+
+// CancelCandidateCtx is like CancelCandidate but honours the cancellation and deadline of ctx.
+func (e *Election) CancelCandidateCtx(ctx context.Context, signTxParams *SysTxParams, candidateAddress string) (string, error) {
 	if !isValidHexAddress(candidateAddress) {
 		return "", errors.New("candidateAddress is not valid bid")
 	}
@@ -170,15 +176,15 @@ func (e *Election) CancelCandidate(signTxParams *SysTxParams, candidateAddress s
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) VoteCandidate(signTxParams *SysTxParams, candidates string) (string, error) {
+	return e.VoteCandidateCtx(context.Background(), signTxParams, candidates)
+}
+
+// VoteCandidateCtx is like VoteCandidate but honours the cancellation and deadline of ctx.
+func (e *Election) VoteCandidateCtx(ctx context.Context, signTxParams *SysTxParams, candidates string) (string, error) {
 	candis := strings.TrimSpace(candidates)
 	if len(candis) == 0 {
 		return "", errors.New("candidateAddress is not valid bid")
@@ -190,15 +196,15 @@ func (e *Election) VoteCandidate(signTxParams *SysTxParams, candidates string) (
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) CancelConsensusNode(signTxParams *SysTxParams, consensusNode string, cancelConsensusReason string) (string, error) {
+	return e.CancelConsensusNodeCtx(context.Background(), signTxParams, consensusNode, cancelConsensusReason)
+}
+
+// CancelConsensusNodeCtx is like CancelConsensusNode but honours the cancellation and deadline of ctx.
+func (e *Election) CancelConsensusNodeCtx(ctx context.Context, signTxParams *SysTxParams, consensusNode string, cancelConsensusReason string) (string, error) {
 	if !isValidHexAddress(consensusNode) {
 		return "", errors.New("consensusNode is not valid bid")
 	}
@@ -216,57 +222,57 @@ func (e *Election) CancelConsensusNode(signTxParams *SysTxParams, consensusNode
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) SetDeadline(signTxParams *SysTxParams, deadline uint64) (string, error) {
+	return e.SetDeadlineCtx(context.Background(), signTxParams, deadline)
+}
+
+// SetDeadlineCtx is like SetDeadline but honours the cancellation and deadline of ctx.
+func (e *Election) SetDeadlineCtx(ctx context.Context, signTxParams *SysTxParams, deadline uint64) (string, error) {
 	// encoding
 	inputEncode, err := e.abi.Pack("setDeadline", deadline)
 	if err != nil {
 		return "", err
 	}
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) ExtractOwnBounty(signTxParams *SysTxParams) (string, error) {
+	return e.ExtractOwnBountyCtx(context.Background(), signTxParams)
+}
+
+// ExtractOwnBountyCtx is like ExtractOwnBounty but honours the cancellation and deadline of ctx.
+func (e *Election) ExtractOwnBountyCtx(ctx context.Context, signTxParams *SysTxParams) (string, error) {
 	// encoding
 	inputEncode, _ := e.abi.Pack("extractOwnBounty")
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) IssueAdditionalBounty(signTxParams *SysTxParams) (string, error) {
+	return e.IssueAdditionalBountyCtx(context.Background(), signTxParams)
+}
+
+// IssueAdditionalBountyCtx is like IssueAdditionalBounty but honours the cancellation and deadline of ctx.
+func (e *Election) IssueAdditionalBountyCtx(ctx context.Context, signTxParams *SysTxParams) (string, error) {
 	// encoding
 	inputEncode, _ := e.abi.Pack("issueAdditionalBounty")
 
-	signedTx, err := e.super.prePareSignTransaction(signTxParams, inputEncode, ElectionContract)
-	if err != nil {
-		return "", err
-	}
-
-	return e.super.sendRawTransaction(signedTx)
+	return e.super.sendTransaction(ctx, signTxParams, inputEncode, ElectionContract)
 }
 
 func (e *Election) GetRestBIFBounty() (*big.Int, error) {
+	return e.GetRestBIFBountyCtx(context.Background())
+}
+
+// GetRestBIFBountyCtx is like GetRestBIFBounty but honours the cancellation and deadline of ctx.
+func (e *Election) GetRestBIFBountyCtx(ctx context.Context) (*big.Int, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_restBIFBounty", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_restBIFBounty", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -280,9 +286,14 @@ func (e *Election) GetRestBIFBounty() (*big.Int, error) {
 }
 
 func (e *Election) AllTrusted() ([]*dto.PeerNodeDetail, error) {
+	return e.AllTrustedCtx(context.Background())
+}
+
+// AllTrustedCtx is like AllTrusted but honours the cancellation and deadline of ctx.
+func (e *Election) AllTrustedCtx(ctx context.Context) ([]*dto.PeerNodeDetail, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_allTrusted", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_allTrusted", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -296,9 +307,14 @@ func (e *Election) AllTrusted() ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) AllCandidates() ([]*dto.PeerNodeDetail, error) {
+	return e.AllCandidatesCtx(context.Background())
+}
+
+// AllCandidatesCtx is like AllCandidates but honours the cancellation and deadline of ctx.
+func (e *Election) AllCandidatesCtx(ctx context.Context) ([]*dto.PeerNodeDetail, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_allCandidates", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_allCandidates", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -312,9 +328,14 @@ func (e *Election) AllCandidates() ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) AllConsensus() ([]*dto.PeerNodeDetail, error) {
+	return e.AllConsensusCtx(context.Background())
+}
+
+// AllConsensusCtx is like AllConsensus but honours the cancellation and deadline of ctx.
+func (e *Election) AllConsensusCtx(ctx context.Context) ([]*dto.PeerNodeDetail, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_allConsensus", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_allConsensus", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -328,9 +349,14 @@ func (e *Election) AllConsensus() ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) AllNodes() ([]*dto.PeerNodeDetail, error) {
+	return e.AllNodesCtx(context.Background())
+}
+
+// AllNodesCtx is like AllNodes but honours the cancellation and deadline of ctx.
+func (e *Election) AllNodesCtx(ctx context.Context) ([]*dto.PeerNodeDetail, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_allNodes", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_allNodes", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -344,6 +370,11 @@ func (e *Election) AllNodes() ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) GetPeerNode(peerNodeId string) (*dto.PeerNodeDetail, error) {
+	return e.GetPeerNodeCtx(context.Background(), peerNodeId)
+}
+
+// GetPeerNodeCtx is like GetPeerNode but honours the cancellation and deadline of ctx.
+func (e *Election) GetPeerNodeCtx(ctx context.Context, peerNodeId string) (*dto.PeerNodeDetail, error) {
 	if !isValidHexAddress(peerNodeId) {
 		return nil, errors.New("peerNodeId is not valid bid")
 	}
@@ -353,7 +384,7 @@ func (e *Election) GetPeerNode(peerNodeId string) (*dto.PeerNodeDetail, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_peerNode", params)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_peerNode", params)
 	if err != nil {
 		return nil, err
 	}
@@ -367,6 +398,11 @@ func (e *Election) GetPeerNode(peerNodeId string) (*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) VoteNodes(voter string) ([]*dto.PeerNodeDetail, error) {
+	return e.VoteNodesCtx(context.Background(), voter)
+}
+
+// VoteNodesCtx is like VoteNodes but honours the cancellation and deadline of ctx.
+func (e *Election) VoteNodesCtx(ctx context.Context, voter string) ([]*dto.PeerNodeDetail, error) {
 	if !isValidHexAddress(voter) {
 		return nil, errors.New("voter is not valid bid")
 	}
@@ -376,7 +412,7 @@ func (e *Election) VoteNodes(voter string) ([]*dto.PeerNodeDetail, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_voteNodes", params)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_voteNodes", params)
 	if err != nil {
 		return nil, err
 	}
@@ -390,6 +426,11 @@ func (e *Election) VoteNodes(voter string) ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) ApplyNodes(apply string) ([]*dto.PeerNodeDetail, error) {
+	return e.ApplyNodesCtx(context.Background(), apply)
+}
+
+// ApplyNodesCtx is like ApplyNodes but honours the cancellation and deadline of ctx.
+func (e *Election) ApplyNodesCtx(ctx context.Context, apply string) ([]*dto.PeerNodeDetail, error) {
 	if !isValidHexAddress(apply) {
 		return nil, errors.New("apply is not valid bid")
 	}
@@ -399,7 +440,7 @@ func (e *Election) ApplyNodes(apply string) ([]*dto.PeerNodeDetail, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_applyNodes", params)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_applyNodes", params)
 	if err != nil {
 		return nil, err
 	}
@@ -413,9 +454,14 @@ func (e *Election) ApplyNodes(apply string) ([]*dto.PeerNodeDetail, error) {
 }
 
 func (e *Election) GetDeadline() (uint64, error) {
+	return e.GetDeadlineCtx(context.Background())
+}
+
+// GetDeadlineCtx is like GetDeadline but honours the cancellation and deadline of ctx.
+func (e *Election) GetDeadlineCtx(ctx context.Context) (uint64, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_deadline", nil)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_deadline", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -429,6 +475,11 @@ func (e *Election) GetDeadline() (uint64, error) {
 }
 
 func (e *Election) NodeBounty(peerNodeId string) (*dto.PeerNodeBounty, error) {
+	return e.NodeBountyCtx(context.Background(), peerNodeId)
+}
+
+// NodeBountyCtx is like NodeBounty but honours the cancellation and deadline of ctx.
+func (e *Election) NodeBountyCtx(ctx context.Context, peerNodeId string) (*dto.PeerNodeBounty, error) {
 	if !isValidHexAddress(peerNodeId) {
 		return nil, errors.New("peerNodeId is not valid bid")
 	}
@@ -438,7 +489,7 @@ func (e *Election) NodeBounty(peerNodeId string) (*dto.PeerNodeBounty, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := e.super.provider.SendRequest(pointer, "election_nodeBounty", params)
+	err := e.super.provider.SendRequestContext(ctx, pointer, "election_nodeBounty", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/dto"
@@ -38,6 +39,11 @@ func (sys *System) NewManager() *Manager {
   Call permissions: 监管节点地址，权限包含1的地址
 */
 func (manager *Manager) Enable(signTxParams *SysTxParams, contractAddress string) (string, error) {
+	return manager.EnableCtx(context.Background(), signTxParams, contractAddress)
+}
+
+// EnableCtx is like Enable but honours the cancellation and deadline of ctx.
+func (manager *Manager) EnableCtx(ctx context.Context, signTxParams *SysTxParams, contractAddress string) (string, error) {
 	if !isValidHexAddress(contractAddress) {
 		return "", errors.New("contractAddress is not valid address")
 	}
@@ -48,12 +54,7 @@ func (manager *Manager) Enable(signTxParams *SysTxParams, contractAddress string
 		return "", err
 	}
 
-	signedTx, err := manager.super.prePareSignTransaction(signTxParams, inputEncode, SuperManagerContract)
-	if err != nil {
-		return "", err
-	}
-
-	return manager.super.sendRawTransaction(signedTx)
+	return manager.super.sendTransaction(ctx, signTxParams, inputEncode, SuperManagerContract)
 }
 
 /*
@@ -71,6 +72,11 @@ func (manager *Manager) Enable(signTxParams *SysTxParams, contractAddress string
   Call permissions: 监管节点地址，权限包含2的地址
 */
 func (manager *Manager) Disable(signTxParams *SysTxParams, contractAddress string) (string, error) {
+	return manager.DisableCtx(context.Background(), signTxParams, contractAddress)
+}
+
+// DisableCtx is like Disable but honours the cancellation and deadline of ctx.
+func (manager *Manager) DisableCtx(ctx context.Context, signTxParams *SysTxParams, contractAddress string) (string, error) {
 	if !isValidHexAddress(contractAddress) {
 		return "", errors.New("contractAddress is not valid address")
 	}
@@ -81,12 +87,7 @@ func (manager *Manager) Disable(signTxParams *SysTxParams, contractAddress strin
 		return "", err
 	}
 
-	signedTx, err := manager.super.prePareSignTransaction(signTxParams, inputEncode, SuperManagerContract)
-	if err != nil {
-		return "", err
-	}
-
-	return manager.super.sendRawTransaction(signedTx)
+	return manager.super.sendTransaction(ctx, signTxParams, inputEncode, SuperManagerContract)
 }
 
 /*
@@ -105,6 +106,11 @@ func (manager *Manager) Disable(signTxParams *SysTxParams, contractAddress strin
   Call permissions: 监管节点地址，权限包含4的地址
 */
 func (manager *Manager) SetPower(signTxParams *SysTxParams, userAddress string, power uint64) (string, error) {
+	return manager.SetPowerCtx(context.Background(), signTxParams, userAddress, power)
+}
+
+// SetPowerCtx is like SetPower but honours the cancellation and deadline of ctx.
+func (manager *Manager) SetPowerCtx(ctx context.Context, signTxParams *SysTxParams, userAddress string, power uint64) (string, error) {
 	if !isValidHexAddress(userAddress) {
 		return "", errors.New("userAddress is not valid address")
 	}
@@ -118,12 +124,7 @@ func (manager *Manager) SetPower(signTxParams *SysTxParams, userAddress string,
 		return "", err
 	}
 
-	signedTx, err := manager.super.prePareSignTransaction(signTxParams, inputEncode, SuperManagerContract)
-	if err != nil {
-		return "", err
-	}
-
-	return manager.super.sendRawTransaction(signedTx)
+	return manager.super.sendTransaction(ctx, signTxParams, inputEncode, SuperManagerContract)
 }
 
 /*
@@ -140,9 +141,14 @@ func (manager *Manager) SetPower(signTxParams *SysTxParams, userAddress string,
   Call permissions: Anyone
 */
 func (manager *Manager) GetAllContracts() ([]dto.AllContract, error) {
+	return manager.GetAllContractsCtx(context.Background())
+}
+
+// GetAllContractsCtx is like GetAllContracts but honours the cancellation and deadline of ctx.
+func (manager *Manager) GetAllContractsCtx(ctx context.Context) ([]dto.AllContract, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := manager.super.provider.SendRequest(pointer, "supermanager_allContracts", nil)
+	err := manager.super.provider.SendRequestContext(ctx, pointer, "supermanager_allContracts", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +170,11 @@ func (manager *Manager) GetAllContracts() ([]dto.AllContract, error) {
   Call permissions: Anyone
 */
 func (manager *Manager) IsEnable(contractAddress string) (bool, error) {
+	return manager.IsEnableCtx(context.Background(), contractAddress)
+}
+
+// IsEnableCtx is like IsEnable but honours the cancellation and deadline of ctx.
+func (manager *Manager) IsEnableCtx(ctx context.Context, contractAddress string) (bool, error) {
 	if !isValidHexAddress(contractAddress) {
 		return false, errors.New("contractAddress is not valid address")
 	}
@@ -173,7 +184,7 @@ func (manager *Manager) IsEnable(contractAddress string) (bool, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := manager.super.provider.SendRequest(pointer, "supermanager_isEnable", params)
+	err := manager.super.provider.SendRequestContext(ctx, pointer, "supermanager_isEnable", params)
 	if err != nil {
 		return false, err
 	}
@@ -195,6 +206,11 @@ func (manager *Manager) IsEnable(contractAddress string) (bool, error) {
   Call permissions: Anyone
 */
 func (manager *Manager) GetPower(userAddress string) (uint64, error) {
+	return manager.GetPowerCtx(context.Background(), userAddress)
+}
+
+// GetPowerCtx is like GetPower but honours the cancellation and deadline of ctx.
+func (manager *Manager) GetPowerCtx(ctx context.Context, userAddress string) (uint64, error) {
 	if !isValidHexAddress(userAddress) {
 		return 0, errors.New("userAddress is not valid address")
 	}
@@ -204,7 +220,7 @@ func (manager *Manager) GetPower(userAddress string) (uint64, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := manager.super.provider.SendRequest(pointer, "supermanager_power", params)
+	err := manager.super.provider.SendRequestContext(ctx, pointer, "supermanager_power", params)
 	if err != nil {
 		return 0, err
 	}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/dto"
@@ -38,6 +39,11 @@ func (sys *System) NewSensitiveWord() *SensitiveWord {
   Call permissions: 只有监管节点地址可以操作
 */
 func (sen *SensitiveWord) AddWords(signTxParams *SysTxParams, wordsLi []string) (string, error) {
+	return sen.AddWordsCtx(context.Background(), signTxParams, wordsLi)
+}
+
+// AddWordsCtx is like AddWords but honours the cancellation and deadline of ctx.
+func (sen *SensitiveWord) AddWordsCtx(ctx context.Context, signTxParams *SysTxParams, wordsLi []string) (string, error) {
 	if wordsLi == nil || len(wordsLi) == 0 {
 		return "", errors.New("wordsLi can't be nil or empty")
 	}
@@ -58,12 +64,7 @@ func (sen *SensitiveWord) AddWords(signTxParams *SysTxParams, wordsLi []string)
 		return "", err
 	}
 
-	signedTx, err := sen.super.prePareSignTransaction(signTxParams, inputEncode, SensitiveContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sen.super.sendRawTransaction(signedTx)
+	return sen.super.sendTransaction(ctx, signTxParams, inputEncode, SensitiveContract)
 }
 
 /*
@@ -81,6 +82,11 @@ func (sen *SensitiveWord) AddWords(signTxParams *SysTxParams, wordsLi []string)
   Call permissions: 只有监管节点地址可以操作
 */
 func (sen *SensitiveWord) DelWord(signTxParams *SysTxParams, word string) (string, error) {
+	return sen.DelWordCtx(context.Background(), signTxParams, word)
+}
+
+// DelWordCtx is like DelWord but honours the cancellation and deadline of ctx.
+func (sen *SensitiveWord) DelWordCtx(ctx context.Context, signTxParams *SysTxParams, word string) (string, error) {
 	if len(word) == 0 || isBlankCharacter(word) {
 		return "", errors.New("word can't be empty or blank character")
 	}
@@ -91,12 +97,7 @@ func (sen *SensitiveWord) DelWord(signTxParams *SysTxParams, word string) (strin
 		return "", err
 	}
 
-	signedTx, err := sen.super.prePareSignTransaction(signTxParams, inputEncode, SensitiveContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sen.super.sendRawTransaction(signedTx)
+	return sen.super.sendTransaction(ctx, signTxParams, inputEncode, SensitiveContract)
 }
 
 /*
@@ -113,9 +114,14 @@ func (sen *SensitiveWord) DelWord(signTxParams *SysTxParams, word string) (strin
   Call permissions: Anyone
 */
 func (sen *SensitiveWord) GetAllWords() ([]string, error) {
+	return sen.GetAllWordsCtx(context.Background())
+}
+
+// GetAllWordsCtx is like GetAllWords but honours the cancellation and deadline of ctx.
+func (sen *SensitiveWord) GetAllWordsCtx(ctx context.Context) ([]string, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := sen.super.provider.SendRequest(pointer, "sensitive_allWords", nil)
+	err := sen.super.provider.SendRequestContext(ctx, pointer, "sensitive_allWords", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +147,11 @@ func (sen *SensitiveWord) GetAllWords() ([]string, error) {
   Call permissions: Anyone
 */
 func (sen *SensitiveWord) IsContainWord(word string) (bool, error) {
+	return sen.IsContainWordCtx(context.Background(), word)
+}
+
+// IsContainWordCtx is like IsContainWord but honours the cancellation and deadline of ctx.
+func (sen *SensitiveWord) IsContainWordCtx(ctx context.Context, word string) (bool, error) {
 	if len(word) == 0 || isBlankCharacter(word) {
 		return false, errors.New("word can't be empty or blank character")
 	}
@@ -150,7 +161,7 @@ func (sen *SensitiveWord) IsContainWord(word string) (bool, error) {
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := sen.super.provider.SendRequest(pointer, "sensitive_isContainWord", params)
+	err := sen.super.provider.SendRequestContext(ctx, pointer, "sensitive_isContainWord", params)
 	if err != nil {
 		return false, err
 	}
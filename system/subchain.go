@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk/abi"
@@ -71,6 +72,11 @@ func applySubChainPreCheck(subChainInfo *dto.SubChainInfo) error {
 }
 
 func (sc *SubChain) ApplySubChain(signTxParams *SysTxParams, subChain *dto.SubChainInfo) (string, error) {
+	return sc.ApplySubChainCtx(context.Background(), signTxParams, subChain)
+}
+
+// ApplySubChainCtx is like ApplySubChain but honours the cancellation and deadline of ctx.
+func (sc *SubChain) ApplySubChainCtx(ctx context.Context, signTxParams *SysTxParams, subChain *dto.SubChainInfo) (string, error) {
 	err := applySubChainPreCheck(subChain)
 	if err != nil {
 		return "", err
@@ -85,15 +91,15 @@ func (sc *SubChain) ApplySubChain(signTxParams *SysTxParams, subChain *dto.SubCh
 		return "", err
 	}
 
-	signedTx, err := sc.super.prePareSignTransaction(signTxParams, inputEncode, SubChainContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sc.super.sendRawTransaction(signedTx)
+	return sc.super.sendTransaction(ctx, signTxParams, inputEncode, SubChainContract)
 }
 
 func (sc *SubChain) VoteSubChain(signTxParams *SysTxParams, candidates string) (string, error) {
+	return sc.VoteSubChainCtx(context.Background(), signTxParams, candidates)
+}
+
+// VoteSubChainCtx is like VoteSubChain but honours the cancellation and deadline of ctx.
+func (sc *SubChain) VoteSubChainCtx(ctx context.Context, signTxParams *SysTxParams, candidates string) (string, error) {
 	candis := strings.TrimSpace(candidates)
 	if len(candis) == 0 {
 		return "", errors.New("candidateAddress is not valid bid")
@@ -105,30 +111,30 @@ func (sc *SubChain) VoteSubChain(signTxParams *SysTxParams, candidates string) (
 		return "", err
 	}
 
-	signedTx, err := sc.super.prePareSignTransaction(signTxParams, inputEncode, SubChainContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sc.super.sendRawTransaction(signedTx)
+	return sc.super.sendTransaction(ctx, signTxParams, inputEncode, SubChainContract)
 }
 
 func (sc *SubChain) SetDeadline(signTxParams *SysTxParams, deadline uint64) (string, error) {
+	return sc.SetDeadlineCtx(context.Background(), signTxParams, deadline)
+}
+
+// SetDeadlineCtx is like SetDeadline but honours the cancellation and deadline of ctx.
+func (sc *SubChain) SetDeadlineCtx(ctx context.Context, signTxParams *SysTxParams, deadline uint64) (string, error) {
 	// encoding
 	inputEncode, err := sc.abi.Pack("setDeadline", deadline)
 	if err != nil {
 		return "", err
 	}
 
-	signedTx, err := sc.super.prePareSignTransaction(signTxParams, inputEncode, SubChainContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sc.super.sendRawTransaction(signedTx)
+	return sc.super.sendTransaction(ctx, signTxParams, inputEncode, SubChainContract)
 }
 
 func (sc *SubChain) Revoke(signTxParams *SysTxParams, subChainId string, revokeReason string) (string, error) {
+	return sc.RevokeCtx(context.Background(), signTxParams, subChainId, revokeReason)
+}
+
+// RevokeCtx is like Revoke but honours the cancellation and deadline of ctx.
+func (sc *SubChain) RevokeCtx(ctx context.Context, signTxParams *SysTxParams, subChainId string, revokeReason string) (string, error) {
 	if !isValidHexAddress(subChainId) {
 		return "", errors.New("subChainId is not valid bid")
 	}
@@ -147,18 +153,18 @@ func (sc *SubChain) Revoke(signTxParams *SysTxParams, subChainId string, revokeR
 		return "", err
 	}
 
-	signedTx, err := sc.super.prePareSignTransaction(signTxParams, inputEncode, SubChainContract)
-	if err != nil {
-		return "", err
-	}
-
-	return sc.super.sendRawTransaction(signedTx)
+	return sc.super.sendTransaction(ctx, signTxParams, inputEncode, SubChainContract)
 }
 
 func (sc *SubChain) AllSubChains() ([]*dto.SubChainDetail, error) {
+	return sc.AllSubChainsCtx(context.Background())
+}
+
+// AllSubChainsCtx is like AllSubChains but honours the cancellation and deadline of ctx.
+func (sc *SubChain) AllSubChainsCtx(ctx context.Context) ([]*dto.SubChainDetail, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := sc.super.provider.SendRequest(pointer, "subchain_allSubChains", nil)
+	err := sc.super.provider.SendRequestContext(ctx, pointer, "subchain_allSubChains", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +178,11 @@ func (sc *SubChain) AllSubChains() ([]*dto.SubChainDetail, error) {
 }
 
 func (sc *SubChain) GetSubChain(subChainId string) (*dto.SubChainDetail, error) {
+	return sc.GetSubChainCtx(context.Background(), subChainId)
+}
+
+// GetSubChainCtx is like GetSubChain but honours the cancellation and deadline of ctx.
+func (sc *SubChain) GetSubChainCtx(ctx context.Context, subChainId string) (*dto.SubChainDetail, error) {
 	if !isValidHexAddress(subChainId) {
 		return nil, errors.New("subChainId is not valid bid")
 	}
@@ -181,7 +192,7 @@ func (sc *SubChain) GetSubChain(subChainId string) (*dto.SubChainDetail, error)
 
 	pointer := &dto.SystemRequestResult{}
 
-	err := sc.super.provider.SendRequest(pointer, "subchain_subChain", params)
+	err := sc.super.provider.SendRequestContext(ctx, pointer, "subchain_subChain", params)
 	if err != nil {
 		return nil, err
 	}
@@ -195,9 +206,14 @@ func (sc *SubChain) GetSubChain(subChainId string) (*dto.SubChainDetail, error)
 }
 
 func (sc *SubChain) GetDeadline() (uint64, error) {
+	return sc.GetDeadlineCtx(context.Background())
+}
+
+// GetDeadlineCtx is like GetDeadline but honours the cancellation and deadline of ctx.
+func (sc *SubChain) GetDeadlineCtx(ctx context.Context) (uint64, error) {
 	pointer := &dto.SystemRequestResult{}
 
-	err := sc.super.provider.SendRequest(pointer, "subchain_deadline", nil)
+	err := sc.super.provider.SendRequestContext(ctx, pointer, "subchain_deadline", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -1,10 +1,12 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"github.com/tchain/go-tchain-sdk/abi"
 	"github.com/tchain/go-tchain-sdk/account"
 	"github.com/tchain/go-tchain-sdk/account/types"
+	"github.com/tchain/go-tchain-sdk/core"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
 	"github.com/tchain/go-tchain-sdk/utils"
@@ -19,6 +21,7 @@ import (
 type System struct {
 	provider providers.ProviderInterface
 	acc      *types.Account
+	builder  *core.TxBuilder
 }
 
 type LogData struct {
@@ -48,15 +51,21 @@ func NewSystem(provider providers.ProviderInterface) *System {
 	return system
 }
 
+// SetTxBuilder makes the writers fill the missing GasPrice, Gas, Nonce and
+// ChainId of SysTxParams with builder, nil signs the transactions as they are
+func (sys *System) SetTxBuilder(builder *core.TxBuilder) {
+	sys.builder = builder
+}
+
 /*
 	prePareSignTransaction - Construct transaction
 
-	prePareSignTransaction - 构造交易
+	prePareSignTransaction - 构造交易，返回待签名的交易及私钥
 */
-func (sys *System) prePareSignTransaction(signTxParams *SysTxParams, payLoad []byte, contractAddr string) (string, error) {
+func (sys *System) prePareSignTransaction(signTxParams *SysTxParams, payLoad []byte, contractAddr string) (*account.SignTxParams, string, error) {
 	_, privateKey, err := account.Decrypt(signTxParams.KeyFileData, signTxParams.IsSM2, signTxParams.Password)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
 	sender := utils.StringToAddress(signTxParams.From)
@@ -71,12 +80,31 @@ func (sys *System) prePareSignTransaction(signTxParams *SysTxParams, payLoad []b
 		Payload:   payLoad,
 		ChainId:   signTxParams.ChainId,
 	}
+
+	return signTx, privateKey, nil
+}
+
+/*
+	sendTransaction - Sign and send the transaction to a system contract
+
+	sendTransaction - 签署并发送系统合约交易，设置了交易构建器时自动填充缺少的字段
+*/
+func (sys *System) sendTransaction(ctx context.Context, signTxParams *SysTxParams, payLoad []byte, contractAddr string) (string, error) {
+	signTx, privateKey, err := sys.prePareSignTransaction(signTxParams, payLoad, contractAddr)
+	if err != nil {
+		return "", err
+	}
+
+	if sys.builder != nil {
+		return sys.builder.SignAndSend(ctx, signTx, privateKey, signTxParams.IsSM2)
+	}
+
 	signResult, err := account.SignTransaction(signTx, privateKey, signTxParams.IsSM2)
 	if err != nil {
 		return "", err
 	}
 
-	return hexutil.Encode(signResult.Raw), nil
+	return sys.sendRawTransaction(ctx, hexutil.Encode(signResult.Raw))
 }
 
 // structToInterface - 将结构体转换为[]interface{}
@@ -106,14 +134,14 @@ func (sys *System) structToInterface(convert interface{}, values []interface{})
 
   Call permissions: Anyone
 */
-func (sys *System) sendRawTransaction(encodedTx string) (string, error) {
+func (sys *System) sendRawTransaction(ctx context.Context, encodedTx string) (string, error) {
 
 	params := make([]string, 1)
 	params[0] = encodedTx
 
 	pointer := &dto.CoreRequestResult{}
 
-	err := sys.provider.SendRequest(&pointer, "core_sendRawTransaction", params)
+	err := sys.provider.SendRequestContext(ctx, &pointer, "core_sendRawTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -139,12 +167,17 @@ func decodeTxHash(transactionHash string) (*LogData, error) {
 
 // Deprecated:解析交易hash的Log data，这个后续应该简化，实现快速解析，而不是调用abi
 func (sys *System) SystemLogDecode(transactionHash string) (*LogData, error) {
+	return sys.SystemLogDecodeCtx(context.Background(), transactionHash)
+}
+
+// SystemLogDecodeCtx is like SystemLogDecode but honours the cancellation and deadline of ctx.
+func (sys *System) SystemLogDecodeCtx(ctx context.Context, transactionHash string) (*LogData, error) {
 	params := make([]string, 1)
 	params[0] = transactionHash
 
 	pointer := &dto.RequestResult{}
 
-	err := sys.provider.SendRequest(pointer, "core_getTransactionReceipt", params)
+	err := sys.provider.SendRequestContext(ctx, pointer, "core_getTransactionReceipt", params)
 
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package System
 import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"path"
 	"testing"
@@ -11,7 +12,7 @@ import (
 
 func TestRegisterDirector(t *testing.T) {
 	file := path.Join(bif.GetCurrentAbPath(), "test", "resources", "keystore", resources.TestAddressRegulatoryFile)
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -49,7 +50,7 @@ func TestRegisterDirector(t *testing.T) {
 
 func TestUpgradeDirector(t *testing.T) {
 	file := path.Join(bif.GetCurrentAbPath(), "test", "resources", "keystore", resources.TestAddressRegulatoryFile)
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -80,7 +81,7 @@ func TestUpgradeDirector(t *testing.T) {
 
 func TestRevoke(t *testing.T) {
 	file := path.Join(bif.GetCurrentAbPath(), "test", "resources", "keystore", resources.TestAddressRegulatoryFile)
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -113,7 +114,7 @@ func TestRevoke(t *testing.T) {
 
 func TestSetWeights(t *testing.T) {
 	file := path.Join(bif.GetCurrentAbPath(), "test", "resources", "keystore", resources.TestAddressRegulatoryFile)
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -142,14 +143,25 @@ func TestSetWeights(t *testing.T) {
 	}
 }
 
-func TestAllDirectors(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
+// testAlliance returns an alliance member as the node encodes it
+func testAlliance(id string, role uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"role":        role,
+		"publicKey":   resources.RegisterAllianceOnePubKey,
+		"companyName": "teleInfo",
+		"companyCode": "110112",
+		"auditor":     resources.TestAddressRegulatory,
+		"auditTime":   1629970893,
+		"active":      true,
 	}
+}
 
-	ali := con.System.NewAlliance()
+func TestAllDirectors(t *testing.T) {
+	provider := mock.NewProvider()
+	provider.On("alliance_directors").Return([]interface{}{testAlliance(resources.RegisterAllianceOne, 1)})
+
+	ali := bif.NewBif(provider).System.NewAlliance()
 
 	directors, err := ali.AllDirectors()
 	if err != nil {
@@ -157,19 +169,16 @@ func TestAllDirectors(t *testing.T) {
 		t.FailNow()
 	}
 
-	for _, v := range directors {
-		t.Logf("directors is %+v \n", v)
+	if len(directors) != 1 || directors[0].Id != resources.RegisterAllianceOne || directors[0].Role != 1 || !directors[0].Active {
+		t.Errorf("unexpected directors %+v", directors)
 	}
 }
 
 func TestAllVices(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("alliance_vices").Return([]interface{}{testAlliance(resources.RegisterAllianceTwo, 2)})
 
-	ali := con.System.NewAlliance()
+	ali := bif.NewBif(provider).System.NewAlliance()
 
 	vices, err := ali.AllVices()
 	if err != nil {
@@ -177,19 +186,20 @@ func TestAllVices(t *testing.T) {
 		t.FailNow()
 	}
 
-	for _, v := range vices {
-		t.Logf("vices is %+v \n", v)
+	if len(vices) != 1 || vices[0].Id != resources.RegisterAllianceTwo || vices[0].Role != 2 {
+		t.Errorf("unexpected vices %+v", vices)
 	}
 }
 
 func TestAllAlliance(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("alliance_alliances").Return([]interface{}{
+		testAlliance(resources.RegisterAllianceOne, 1),
+		testAlliance(resources.RegisterAllianceTwo, 2),
+		testAlliance(resources.TestAddressRegulatory, 3),
+	})
 
-	ali := con.System.NewAlliance()
+	ali := bif.NewBif(provider).System.NewAlliance()
 
 	alliances, err := ali.AllAlliances()
 	if err != nil {
@@ -197,41 +207,46 @@ func TestAllAlliance(t *testing.T) {
 		t.FailNow()
 	}
 
-	for _, v := range alliances {
-		t.Logf("alliances is %+v \n", v)
+	if len(alliances) != 3 || alliances[2].Id != resources.TestAddressRegulatory || alliances[2].Role != 3 {
+		t.Errorf("unexpected alliances %+v", alliances)
 	}
 }
 
 func TestGetAlliance(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("alliance_alliance", resources.RegisterAllianceOne).Return(testAlliance(resources.RegisterAllianceOne, 1))
 
-	ali := con.System.NewAlliance()
+	ali := bif.NewBif(provider).System.NewAlliance()
 
 	alliance, err := ali.GetAlliance(resources.RegisterAllianceOne)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("aliance is %+v \n", alliance)
+
+	if alliance.Id != resources.RegisterAllianceOne || alliance.CompanyCode != "110112" || alliance.AuditTime != 1629970893 {
+		t.Errorf("unexpected alliance %+v", alliance)
+	}
 }
 
 func TestGetWeights(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("alliance_weights").Return(map[string]interface{}{
+		"directorWeights":        2,
+		"viceWeights":            3,
+		"directorGeneralWeights": 4,
+		"score":                  9,
+	})
 
-	ali := con.System.NewAlliance()
+	ali := bif.NewBif(provider).System.NewAlliance()
 
 	weights, err := ali.GetWeights()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("weights is %v \n", weights)
+
+	if *weights != (dto.Weights{DirectorWeights: 2, ViceWeights: 3, DirectorGeneralWeights: 4, Score: 9}) {
+		t.Errorf("unexpected weights %+v", weights)
+	}
 }
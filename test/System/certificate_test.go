@@ -4,19 +4,18 @@ import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"math/big"
 	"path"
-	"strconv"
 	"testing"
 	"time"
 )
 
 func TestRegisterCertificate(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -87,7 +86,7 @@ func TestRegisterCertificate(t *testing.T) {
 }
 
 func TestRevokedCertificate(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -131,7 +130,7 @@ func TestRevokedCertificate(t *testing.T) {
 }
 
 func TestRevokedCertificates(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -175,30 +174,27 @@ func TestRevokedCertificates(t *testing.T) {
 }
 
 func TestGetPeriod(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	cer := connection.System.NewCertificate()
+	provider := mock.NewProvider()
+	provider.On("certificate_period", resources.PersonCertificateId).Return("0xa")
+
+	cer := bif.NewBif(provider).System.NewCertificate()
 
 	period, err := cer.GetPeriod(resources.PersonCertificateId)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(period)
+
+	if period != 10 {
+		t.Errorf("unexpected period %d", period)
+	}
 }
 
 func TestGetActive(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	cer := connection.System.NewCertificate()
+	provider := mock.NewProvider()
+	provider.On("certificate_active", resources.PersonCertificateId).Return(true)
+
+	cer := bif.NewBif(provider).System.NewCertificate()
 
 	isEnable, err := cer.GetActive(resources.PersonCertificateId)
 	if err != nil {
@@ -206,17 +202,25 @@ func TestGetActive(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(isEnable)
+	if !isEnable {
+		t.Errorf("expected an active certificate")
+	}
 }
 
 func TestGetCertificate(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	cer := connection.System.NewCertificate()
+	provider := mock.NewProvider()
+	provider.On("certificate_certificate", resources.PersonCertificateId).Return(map[string]interface{}{
+		"id":             resources.PersonCertificateId,
+		"context":        "test",
+		"issuer":         resources.TestAddressRegulatory,
+		"subject":        resources.Addr2,
+		"issuedTime":     1629970893,
+		"period":         10,
+		"isEnable":       true,
+		"revocationTime": 0,
+	})
+
+	cer := bif.NewBif(provider).System.NewCertificate()
 
 	certificate, err := cer.GetCertificate(resources.PersonCertificateId)
 	if err != nil {
@@ -224,39 +228,60 @@ func TestGetCertificate(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(certificate)
+	expected := dto.CertificateInfo{
+		Id:         resources.PersonCertificateId,
+		Context:    "test",
+		Issuer:     resources.TestAddressRegulatory,
+		Subject:    resources.Addr2,
+		IssuedTime: 1629970893,
+		Period:     10,
+		IsEnable:   true,
+	}
+	if certificate != expected {
+		t.Errorf("unexpected certificate %+v", certificate)
+	}
 }
 
 func TestGetIssuer(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Log(err)
-		t.FailNow()
-	}
-	cer := connection.System.NewCertificate()
+	provider := mock.NewProvider()
+	provider.On("certificate_issuer", resources.PersonCertificateId).Return(map[string]interface{}{
+		"id":        resources.PersonCertificateId,
+		"publicKey": resources.RegisterAllianceOnePubKey,
+		"algorithm": "secp256k1",
+		"signature": "0x1234",
+	})
+
+	cer := bif.NewBif(provider).System.NewCertificate()
 
 	issuer, err := cer.GetIssuer(resources.PersonCertificateId)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(issuer)
+
+	if issuer != (dto.IssuerSignature{Id: resources.PersonCertificateId, PublicKey: resources.RegisterAllianceOnePubKey, Algorithm: "secp256k1", Signature: "0x1234"}) {
+		t.Errorf("unexpected issuer %+v", issuer)
+	}
 }
 
 func TestGetSubject(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Log(err)
-		t.FailNow()
-	}
-	cer := connection.System.NewCertificate()
+	provider := mock.NewProvider()
+	provider.On("certificate_subject", resources.PersonCertificateId).Return(map[string]interface{}{
+		"id":        resources.PersonCertificateId,
+		"publicKey": resources.RegisterAllianceTwoPubKey,
+		"algorithm": "secp256k1",
+		"signature": "0x5678",
+	})
+
+	cer := bif.NewBif(provider).System.NewCertificate()
 
 	subjectSignature, err := cer.GetSubject(resources.PersonCertificateId)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(subjectSignature)
+
+	if subjectSignature != (dto.SubjectSignature{Id: resources.PersonCertificateId, PublicKey: resources.RegisterAllianceTwoPubKey, Algorithm: "secp256k1", Signature: "0x5678"}) {
+		t.Errorf("unexpected subject %+v", subjectSignature)
+	}
 }
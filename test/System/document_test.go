@@ -3,17 +3,16 @@ package System
 import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"math/big"
-	"strconv"
 	"testing"
 )
 
 func TestInit(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -51,7 +50,7 @@ func TestInit(t *testing.T) {
 }
 
 func TestSetBidName(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -96,39 +95,53 @@ func TestSetBidName(t *testing.T) {
 }
 
 func TestGetDocument(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	doc := connection.System.NewDoc()
+	provider := mock.NewProvider()
+	provider.On("document_document", resources.Addr1).Return(map[string]interface{}{
+		"id":      resources.Addr1,
+		"context": "https://w3.org/ns/did/v1",
+		"name":    "teleInfo",
+		"type":    2,
+		"publicKey": []interface{}{map[string]interface{}{
+			"id":         resources.Addr1 + "#keys-1",
+			"type":       "secp256k1",
+			"controller": resources.Addr1,
+			"authority":  "all",
+			"publicKey":  "0x04",
+		}},
+		"authentication": []string{resources.Addr2},
+		"service": []interface{}{map[string]interface{}{
+			"id":              resources.Addr2,
+			"type":            "agent",
+			"serviceEndpoint": "https://example.com",
+		}},
+		"extra":    "test",
+		"isEnable": true,
+	})
+
+	doc := bif.NewBif(provider).System.NewDoc()
 
-	document, err := doc.GetDocument(resources.TestAddressDoc)
+	document, err := doc.GetDocument(resources.Addr1)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Logf("doc is %#v \n", document)
-	// t.Logf("doc name is %#v \n", document.Name)
-	// t.Logf("doc  extra is %#v \n", document.Extra)
-	// t.Logf("doc Authentications len is %#v \n", len(document.Authentications))
-	// t.Logf("doc atuh is %#v \n", document.Authentications)
-	// t.Logf("doc publicKey len is %#v \n", len(document.PublicKeys))
-	// if len(document.PublicKeys) != 0{
-	// 	t.Logf("doc publicKey is %#v \n", document.PublicKeys[0])
-	// }
-	t.Logf("doc Services len is %#v \n", len(document.Services))
-	if len(document.Services) != 0 {
-		t.Logf("doc service is %#v \n", document.Services[0])
+	if document.Id != resources.Addr1 || document.Name != "teleInfo" || document.Type != 2 || !document.IsEnable || document.Proof != nil {
+		t.Errorf("unexpected document %#v", document)
+	}
+	if len(document.PublicKeys) != 1 || document.PublicKeys[0].Authority != "all" {
+		t.Errorf("unexpected public keys %#v", document.PublicKeys)
+	}
+	if len(document.Authentications) != 1 || document.Authentications[0] != resources.Addr2 {
+		t.Errorf("unexpected authentications %#v", document.Authentications)
+	}
+	if len(document.Services) != 1 || document.Services[0].Endpoint != "https://example.com" {
+		t.Errorf("unexpected services %#v", document.Services)
 	}
-	// t.Logf("doc Proof is %#v \n", document.Proof)
-
 }
 
 func TestAddPublic(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -173,7 +186,7 @@ func TestAddPublic(t *testing.T) {
 }
 
 func TestDelPublic(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -218,7 +231,7 @@ func TestDelPublic(t *testing.T) {
 }
 
 func TestAddAuth(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -263,7 +276,7 @@ func TestAddAuth(t *testing.T) {
 }
 
 func TestDelAuth(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -308,7 +321,7 @@ func TestDelAuth(t *testing.T) {
 }
 
 func TestAddService(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -353,7 +366,7 @@ func TestAddService(t *testing.T) {
 }
 
 func TestDelService(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -398,7 +411,7 @@ func TestDelService(t *testing.T) {
 }
 
 func TestAddProof(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -443,7 +456,7 @@ func TestAddProof(t *testing.T) {
 }
 
 func TestDelProof(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -488,7 +501,7 @@ func TestDelProof(t *testing.T) {
 }
 
 func TestAddExtra(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -533,7 +546,7 @@ func TestAddExtra(t *testing.T) {
 }
 
 func TestDelExtra(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -578,7 +591,7 @@ func TestDelExtra(t *testing.T) {
 }
 
 func TestEnable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -623,7 +636,7 @@ func TestEnable(t *testing.T) {
 }
 
 func TestDisable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -668,20 +681,18 @@ func TestDisable(t *testing.T) {
 }
 
 func TestIsEnable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetGenerator()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("document_isEnable", resources.Addr1).Return(false)
 
-	doc := connection.System.NewDoc()
+	doc := bif.NewBif(provider).System.NewDoc()
 
-	isEnable, err := doc.IsEnable(resources.TestAddressDoc)
+	isEnable, err := doc.IsEnable(resources.Addr1)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(isEnable)
+	if isEnable {
+		t.Errorf("expected a disabled document")
+	}
 }
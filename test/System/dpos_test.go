@@ -1,23 +1,24 @@
 package System
 
 import (
-	"fmt"
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
-	"strconv"
 	"testing"
 )
 
+const testBlockHash = "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+
 func TestGetValidators(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x1b4")
+	provider.On("dpos_getValidators", "0x1b4").Return([]string{resources.Addr1, resources.Addr2})
+
+	var connection = bif.NewBif(provider)
 	// 距离最新区块间隔数超过126会报错，因此测试最新区块
 	blockNumber, err := connection.Core.GetBlockNumber()
-	// fmt.Println("blockNumber is ", blockNumber)
-	// blockNumberOld := new(big.Int)
-	// fmt.Println("blockNumberOld is ", blockNumberOld.Sub(blockNumber, big.NewInt(127)))
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -30,11 +31,25 @@ func TestGetValidators(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(validators)
+
+	if len(validators) != 2 || validators[0] != resources.Addr1 || validators[1] != resources.Addr2 {
+		t.Errorf("unexpected validators %v", validators)
+	}
 }
 
 func TestGetValidatorsAtHash(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x1b4")
+	provider.On("core_getBlockByNumber", "0x1b4", false).Return(map[string]interface{}{
+		"number":       "0x1b4",
+		"hash":         testBlockHash,
+		"size":         "0x27f",
+		"timestamp":    "0x5f5e100",
+		"transactions": []string{},
+	})
+	provider.On("dpos_getValidatorsAtHash", testBlockHash).Return([]string{resources.Addr1})
+
+	var connection = bif.NewBif(provider)
 
 	// 距离最新区块间隔数超过126会报错，因此测试最新区块
 	blockNumber, err := connection.Core.GetBlockNumber()
@@ -57,11 +72,27 @@ func TestGetValidatorsAtHash(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(validators)
+
+	if len(validators) != 1 || validators[0] != resources.Addr1 {
+		t.Errorf("unexpected validators %v", validators)
+	}
 }
 
 func TestRoundStateInfo(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("dpos_roundStateInfo").Return(map[string]interface{}{
+		"commits": map[string]interface{}{
+			"view":     map[string]interface{}{"round": 1, "sequence": 436},
+			"valSet":   []string{resources.Addr1},
+			"messages": []interface{}{},
+		},
+		"lockedHash": testBlockHash,
+		"proposer":   resources.Addr1,
+		"round":      "0x1",
+		"sequence":   "0x1b4",
+	})
+
+	var connection = bif.NewBif(provider)
 
 	DPoS := connection.System.NewDPoS()
 	roundStateInfo, err := DPoS.RoundStateInfo()
@@ -70,11 +101,29 @@ func TestRoundStateInfo(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	fmt.Printf("roundStateInfo is %#v \n", roundStateInfo)
+
+	if roundStateInfo.Round.Int64() != 1 || roundStateInfo.Sequence.Int64() != 436 || roundStateInfo.Proposer != resources.Addr1 {
+		t.Errorf("unexpected round state %#v", roundStateInfo)
+	}
+	if roundStateInfo.LockedHash != testBlockHash || roundStateInfo.Commits.View.Sequence != 436 || roundStateInfo.Prepares != nil {
+		t.Errorf("unexpected round state %#v", roundStateInfo)
+	}
 }
 
 func TestRoundChangeSetInfo(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("dpos_roundChangeSetInfo").Return(map[string]interface{}{
+		"roundChanges": map[string]interface{}{
+			"2": map[string]interface{}{
+				"view":     map[string]interface{}{"round": 2, "sequence": 436},
+				"valSet":   []string{resources.Addr1, resources.Addr2},
+				"messages": []interface{}{},
+			},
+		},
+		"validates": []string{resources.Addr1, resources.Addr2},
+	})
+
+	var connection = bif.NewBif(provider)
 
 	DPoS := connection.System.NewDPoS()
 	roundChangeSetInfo, err := DPoS.RoundChangeSetInfo()
@@ -83,11 +132,25 @@ func TestRoundChangeSetInfo(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	fmt.Printf("roundStateInfo is %#v \n", roundChangeSetInfo)
+
+	roundChange := roundChangeSetInfo.RoundChanges[2]
+	if roundChange == nil || roundChange.View.Round != 2 || len(roundChange.ValSet) != 2 || len(roundChangeSetInfo.Validates) != 2 {
+		t.Errorf("unexpected round change set %#v", roundChangeSetInfo)
+	}
 }
 
 func TestBacklogs(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("dpos_backlogs").Return(map[string]interface{}{
+		resources.Addr1: []interface{}{map[string]interface{}{
+			"code":      1,
+			"message":   "AQI=",
+			"address":   resources.Addr1,
+			"signature": "AwQ=",
+		}},
+	})
+
+	var connection = bif.NewBif(provider)
 	DPoS := connection.System.NewDPoS()
 	backlogs, err := DPoS.Backlogs()
 
@@ -95,5 +158,9 @@ func TestBacklogs(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	fmt.Printf("roundStateInfo is %#v \n", backlogs)
+
+	messages := backlogs[resources.Addr1]
+	if len(messages) != 1 || messages[0].Code != 1 || string(messages[0].Msg) != "\x01\x02" || string(messages[0].Signature) != "\x03\x04" {
+		t.Errorf("unexpected backlogs %#v", backlogs)
+	}
 }
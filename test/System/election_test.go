@@ -5,6 +5,7 @@ import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"testing"
@@ -15,9 +16,9 @@ import (
 func TestRegisterTrustNode(t *testing.T) {
 	// 签名的节点是联盟成员
 	// file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-08-26T09-21-33.005300071Z--did-bid-llj1-sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"
-	// con, sigPara, err := connectWithSig("did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
+	// con, sigPara, err := connectWithSig(t, "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-10-19T05-33-49.419105162Z--did_bid_qwer_sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y"
-	con, sigPara, err := connectWithSig("did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
+	con, sigPara, err := connectWithSig(t, "did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -103,7 +104,7 @@ func TestRegisterTrustNode(t *testing.T) {
 func TestDeleteTrustNode(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.TestAddressRegulatoryFile
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -138,7 +139,7 @@ func TestDeleteTrustNode(t *testing.T) {
 func TestApplyCandidate(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-10-19T05-33-49.419105162Z--did_bid_qwer_sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y"
-	con, sigPara, err := connectWithSig("did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
+	con, sigPara, err := connectWithSig(t, "did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -172,7 +173,7 @@ func TestApplyCandidate(t *testing.T) {
 func TestCancelCandidate(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.TestAddressRegulatoryFile
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -205,7 +206,7 @@ func TestCancelCandidate(t *testing.T) {
 func TestVoteCandidate(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-10-19T05-33-49.419105162Z--did_bid_qwer_sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y"
-	con, sigPara, err := connectWithSig("did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
+	con, sigPara, err := connectWithSig(t, "did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -238,7 +239,7 @@ func TestVoteCandidate(t *testing.T) {
 func TestCancelConsensusNode(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.TestAddressRegulatoryFile
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -274,7 +275,7 @@ func TestCancelConsensusNode(t *testing.T) {
 func TestSetDeadline(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.TestAddressRegulatoryFile
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -308,7 +309,7 @@ func TestSetDeadline(t *testing.T) {
 
 func TestExtractOwnBounty(t *testing.T) {
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.RegisterAllianceOneFile
-	con, sigPara, err := connectWithSig(resources.RegisterAllianceOne, file)
+	con, sigPara, err := connectWithSig(t, resources.RegisterAllianceOne, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -342,7 +343,7 @@ func TestExtractOwnBounty(t *testing.T) {
 func TestIssueAdditionalBounty(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + resources.TestAddressRegulatoryFile
-	con, sigPara, err := connectWithSig(resources.TestAddressRegulatory, file)
+	con, sigPara, err := connectWithSig(t, resources.TestAddressRegulatory, file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -374,13 +375,11 @@ func TestIssueAdditionalBounty(t *testing.T) {
 }
 
 func TestGetRestBIFBounty(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	// 999984574000000000000000000
+	provider := mock.NewProvider()
+	provider.On("election_restBIFBounty").Return("0x33b2af8610f4ab050380000")
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
 	restBounty, err := ele.GetRestBIFBounty()
 
@@ -389,177 +388,204 @@ func TestGetRestBIFBounty(t *testing.T) {
 		t.FailNow()
 	}
 
-	// 999984574000000000000000000
-	// 1049984514000000000000000000
-	fmt.Printf("restBounty %s \n", restBounty.String())
+	if restBounty.String() != "999984574000000000000000000" {
+		t.Errorf("unexpected rest bounty %s", restBounty)
+	}
 }
 
-func TestAllTrusted(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
+// testPeerNode returns a node of the election contract as the node encodes it
+func testPeerNode(id string, role uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"issuer":      resources.TestAddressRegulatory,
+		"apply":       resources.RegisterAllianceTwo,
+		"publicKey":   resources.RegisterAllianceTwoPubKey,
+		"nodeName":    "teleInfo",
+		"url":         "127.0.0.1:44444",
+		"nodeType":    0,
+		"companyName": "teleInfo",
+		"companyCode": "110112",
+		"role":        role,
+		"active":      true,
+		"create":      1629970893,
+		"score":       2,
+		"voterList":   []string{resources.RegisterAllianceOne, resources.RegisterAllianceTwo},
 	}
+}
 
-	ele := con.System.NewElection()
+func TestAllTrusted(t *testing.T) {
+	provider := mock.NewProvider()
+	provider.On("election_allTrusted").Return([]interface{}{
+		testPeerNode(resources.Addr1, 1),
+		testPeerNode(resources.Addr2, 1),
+	})
+
+	ele := bif.NewBif(provider).System.NewElection()
 
 	nodes, err := ele.AllTrusted()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("AllTrusted nodes is %+v \n", node)
+
+	if len(nodes) != 2 || nodes[0].Id != resources.Addr1 || nodes[1].Id != resources.Addr2 || nodes[1].Role != 1 {
+		t.Errorf("unexpected nodes %+v", nodes)
 	}
 }
 
 func TestAllCandidates(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("election_allCandidates").Return([]interface{}{
+		testPeerNode(resources.Addr1, 2),
+		testPeerNode(resources.Addr2, 2),
+	})
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
 	nodes, err := ele.AllCandidates()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("AllCandidates nodes is %+v \n", node)
+
+	if len(nodes) != 2 || nodes[0].Id != resources.Addr1 || nodes[1].Id != resources.Addr2 || nodes[1].Role != 2 {
+		t.Errorf("unexpected nodes %+v", nodes)
 	}
 }
 
 func TestGetAllConsensus(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("election_allConsensus").Return([]interface{}{
+		testPeerNode(resources.Addr1, 3),
+		testPeerNode(resources.Addr2, 3),
+	})
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
 	nodes, err := ele.AllConsensus()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("AllConsensus nodes is %+v \n", node)
-	}
 
+	if len(nodes) != 2 || nodes[0].Id != resources.Addr1 || nodes[1].Id != resources.Addr2 || nodes[1].Role != 3 {
+		t.Errorf("unexpected nodes %+v", nodes)
+	}
 }
 
 func TestAllNodes(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("election_allNodes").Return([]interface{}{
+		testPeerNode(resources.Addr1, 1),
+		testPeerNode(resources.Addr2, 1),
+	})
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
 	nodes, err := ele.AllNodes()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("AllNodes node is %s , %d\n", node.Id, node.Role)
+
+	if len(nodes) != 2 || nodes[0].Id != resources.Addr1 || nodes[1].Id != resources.Addr2 || nodes[1].Role != 1 {
+		t.Errorf("unexpected nodes %+v", nodes)
 	}
 }
 
 func TestGetPeerNode(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	peerNodeId := resources.RegisterAllianceTwo
 
-	ele := con.System.NewElection()
+	provider := mock.NewProvider()
+	provider.On("election_peerNode", peerNodeId).Return(testPeerNode(peerNodeId, 2))
 
-	peerNodeId := resources.RegisterAllianceTwo
+	ele := bif.NewBif(provider).System.NewElection()
 
 	node, err := ele.GetPeerNode(peerNodeId)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("PeerNode is %+v \n", node)
+
+	if node.Id != peerNodeId || node.Role != 2 || node.CreateTime != 1629970893 || len(node.VoterList) != 2 {
+		t.Errorf("unexpected node %+v", node)
+	}
 }
 
 func TestVoteNodes(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	voter := resources.RegisterAllianceTwo
 
-	ele := con.System.NewElection()
+	provider := mock.NewProvider()
+	provider.On("election_voteNodes", voter).Return([]interface{}{testPeerNode(resources.Addr1, 2)})
+
+	ele := bif.NewBif(provider).System.NewElection()
 
-	voter := resources.RegisterAllianceTwo
 	nodes, err := ele.VoteNodes(voter)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("VoteNodes nodes is %+v \n", node)
+
+	if len(nodes) != 1 || nodes[0].Id != resources.Addr1 || nodes[0].VoterList[1] != voter {
+		t.Errorf("unexpected nodes %+v", nodes)
 	}
 }
 
 func TestApplyNodes(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	apply := resources.RegisterAllianceTwo
 
-	ele := con.System.NewElection()
+	provider := mock.NewProvider()
+	provider.On("election_applyNodes", apply).Return([]interface{}{testPeerNode(resources.Addr1, 1)})
+
+	ele := bif.NewBif(provider).System.NewElection()
 
-	apply := resources.RegisterAllianceTwo
 	nodes, err := ele.ApplyNodes(apply)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, node := range nodes {
-		t.Logf("ApplyNodes nodes is %+v \n", node)
+
+	if len(nodes) != 1 || nodes[0].Apply != apply {
+		t.Errorf("unexpected nodes %+v", nodes)
 	}
 }
 
 func TestGetDeadline(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("election_deadline").Return("0x15180")
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
 	deadline, err := ele.GetDeadline()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("deadline is %v \n", deadline)
+
+	if deadline != 86400 {
+		t.Errorf("unexpected deadline %d", deadline)
+	}
 }
 
 func TestNodeBounty(t *testing.T) {
-	con, err := connectBif()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("election_nodeBounty", resources.Addr1).Return(map[string]interface{}{
+		"id":              resources.Addr1,
+		"totalBounty":     5000000000000000000,
+		"extractedBounty": 2000000000000000000,
+		"lastExtractTime": 1629970893,
+	})
 
-	ele := con.System.NewElection()
+	ele := bif.NewBif(provider).System.NewElection()
 
-	nodeBounty, err := ele.NodeBounty("did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y")
+	nodeBounty, err := ele.NodeBounty(resources.Addr1)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("nodeBounty is %v \n", nodeBounty)
+
+	if nodeBounty.Id != resources.Addr1 || nodeBounty.TotalBounty.String() != "5000000000000000000" ||
+		nodeBounty.ExtractedBounty.String() != "2000000000000000000" || nodeBounty.LastExtractTime != 1629970893 {
+		t.Errorf("unexpected bounty %+v", nodeBounty)
+	}
 }
@@ -3,17 +3,17 @@ package System
 import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"math/big"
-	"strconv"
 	"testing"
 )
 
 func TestContractEnable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -59,7 +59,7 @@ func TestContractEnable(t *testing.T) {
 }
 
 func TestContractDisable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -105,7 +105,7 @@ func TestContractDisable(t *testing.T) {
 }
 
 func TestSetPower(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -152,64 +152,57 @@ func TestSetPower(t *testing.T) {
 }
 
 func TestAllContracts(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	provider := mock.NewProvider()
+	provider.On("supermanager_allContracts").Return([]interface{}{
+		map[string]interface{}{"contractName": resources.Addr1, "isEnable": true},
+		map[string]interface{}{"contractName": resources.Addr2, "isEnable": false},
+	})
 
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-
-	manager := connection.System.NewManager()
+	manager := bif.NewBif(provider).System.NewManager()
 	contracts, err := manager.GetAllContracts()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Logf("%#v \n", contracts)
-
-	if len(contracts) != 0 {
-		t.Logf("%#v \n", contracts[0])
+	if len(contracts) != 2 || contracts[0] != (dto.AllContract{ContractName: resources.Addr1, IsEnable: true}) ||
+		contracts[1] != (dto.AllContract{ContractName: resources.Addr2}) {
+		t.Errorf("unexpected contracts %#v", contracts)
 	}
 }
 
 func TestContractIsEnable(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	contractAddress := resources.Addr2
 
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("supermanager_isEnable", contractAddress).Return(true)
 
-	manager := connection.System.NewManager()
-	contractAddress := resources.TestContractAddress
+	manager := bif.NewBif(provider).System.NewManager()
 	isEnable, err := manager.IsEnable(contractAddress)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(isEnable)
+	if !isEnable {
+		t.Errorf("expected an enabled contract")
+	}
 }
 
 func TestGetPower(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	userAddress := resources.Addr1
 
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("supermanager_power", userAddress).Return("0x7")
 
-	manager := connection.System.NewManager()
-	userAddress := resources.Addr1
+	manager := bif.NewBif(provider).System.NewManager()
 	power, err := manager.GetPower(userAddress)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(power)
+	if power != 7 {
+		t.Errorf("unexpected power %d", power)
+	}
 }
@@ -3,17 +3,16 @@ package System
 import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"math/big"
-	"strconv"
 	"testing"
 )
 
 func TestAddWords(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -59,7 +58,7 @@ func TestAddWords(t *testing.T) {
 }
 
 func TestDelWord(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		t.Log(err)
@@ -105,40 +104,42 @@ func TestDelWord(t *testing.T) {
 }
 
 func TestGetAllWords(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	provider := mock.NewProvider()
+	provider.On("sensitive_allWords").Return("北京,上海")
 
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-
-	sen := connection.System.NewSensitiveWord()
+	sen := bif.NewBif(provider).System.NewSensitiveWord()
 	wordsLi, err := sen.GetAllWords()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(wordsLi)
+	if len(wordsLi) != 2 || wordsLi[0] != "北京" || wordsLi[1] != "上海" {
+		t.Errorf("unexpected words %v", wordsLi)
+	}
 }
 
 func TestIsContainWord(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	word := "北京"
 
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("sensitive_isContainWord", word).Return(true)
 
-	sen := connection.System.NewSensitiveWord()
-	word := "北京"
+	sen := bif.NewBif(provider).System.NewSensitiveWord()
 	isContain, err := sen.IsContainWord(word)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(isContain)
+	if !isContain {
+		t.Errorf("expected %s to be a sensitive word", word)
+	}
+
+	if _, err := sen.IsContainWord(" "); err == nil {
+		t.Errorf("expected an error for a blank word")
+	}
+	if len(provider.Calls()) != 1 {
+		t.Errorf("the blank word was sent to the node")
+	}
 }
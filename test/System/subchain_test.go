@@ -6,7 +6,7 @@ import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
@@ -15,8 +15,11 @@ import (
 	"time"
 )
 
-func connectWithSigNow(sigAddr string, singAddrFile string) (*bif.Bif, *system.SysTxParams, error) {
-	var connection = bif.NewBif(providers.NewHTTPProvider("127.0.0.1:55550", 10, false))
+// subChainNode is the node of the subchain the tests run on
+const subChainNode = "127.0.0.1:55550"
+
+func connectWithSigNow(t testing.TB, sigAddr string, singAddrFile string) (*bif.Bif, *system.SysTxParams, error) {
+	var connection = bif.NewBif(resources.NewProviderAt(t, subChainNode))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		return nil, nil, err
@@ -49,19 +52,10 @@ func connectWithSigNow(sigAddr string, singAddrFile string) (*bif.Bif, *system.S
 	return connection, sysTxParams, nil
 }
 
-func connectBifNow() (*bif.Bif, error) {
-	var connection = bif.NewBif(providers.NewHTTPProvider("127.0.0.1:55550", 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		return nil, err
-	}
-	return connection, nil
-}
-
 func TestApplySubChain(t *testing.T) {
 	// 签名的节点是联盟成员
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-08-26T09-21-33.005300071Z--did-bid-llj1-sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"
-	con, sigPara, err := connectWithSigNow("did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
+	con, sigPara, err := connectWithSigNow(t, "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -124,7 +118,7 @@ func TestApplySubChain(t *testing.T) {
 
 func TestRevokeSubChain(t *testing.T) {
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-08-26T09-21-33.005300071Z--did-bid-llj1-sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"
-	con, sigPara, err := connectWithSigNow("did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
+	con, sigPara, err := connectWithSigNow(t, "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -157,7 +151,7 @@ func TestRevokeSubChain(t *testing.T) {
 
 func TestVoteSubChain(t *testing.T) {
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-08-26T09-21-33.005300071Z--did-bid-llj1-sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"
-	con, sigPara, err := connectWithSigNow("did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
+	con, sigPara, err := connectWithSigNow(t, "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -190,7 +184,7 @@ func TestVoteSubChain(t *testing.T) {
 
 func TestSetSubDeadline(t *testing.T) {
 	file := bif.GetCurrentAbPath() + resources.KeyStoreFile + "UTC--2021-08-26T09-21-33.005300071Z--did-bid-llj1-sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"
-	con, sigPara, err := connectWithSigNow("did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
+	con, sigPara, err := connectWithSigNow(t, "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc", file)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
@@ -222,60 +216,74 @@ func TestSetSubDeadline(t *testing.T) {
 	}
 }
 
-func TestAllSubChains(t *testing.T) {
-	con, err := connectBifNow()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
+// testSubChain returns a subchain as the node encodes it
+func testSubChain(id string, status uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             id,
+		"apply":          "did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc",
+		"subChainName":   "teleInfo",
+		"chainCode":      "llj1",
+		"chainIndustry":  "finance",
+		"chainFramework": "bif",
+		"consensus":      "dpos",
+		"status":         status,
+		"startTime":      1629970893,
+		"score":          1,
+		"voterList":      []string{"did:bid:llj1:sfYVq8gWNHSFhwUtA5KcKCVMszR86Zgc"},
 	}
+}
 
-	subChain := con.System.NewSubChain()
+func TestAllSubChains(t *testing.T) {
+	subChainId := "did:bid:llj1:sfrVXK5LxB6ZYrqXsaqp6g3izMkm2r8n"
+
+	provider := mock.NewProvider()
+	provider.On("subchain_allSubChains").Return([]interface{}{testSubChain(subChainId, 2)})
+
+	subChain := bif.NewBif(provider).System.NewSubChain()
 
 	subChains, err := subChain.AllSubChains()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	for _, sub := range subChains {
-		t.Logf("subChain is %+v \n", sub)
+
+	if len(subChains) != 1 || subChains[0].Id != subChainId || subChains[0].Status != 2 {
+		t.Errorf("unexpected subchains %+v", subChains)
 	}
 }
 
 func TestGetSubChain(t *testing.T) {
-	var con = bif.NewBif(providers.NewHTTPProvider("127.0.0.1:55550", 10, false))
-	_, err := con.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	subChainId := "did:bid:llj1:sfrVXK5LxB6ZYrqXsaqp6g3izMkm2r8n"
 
-	subChain := con.System.NewSubChain()
+	provider := mock.NewProvider()
+	provider.On("subchain_subChain", subChainId).Return(testSubChain(subChainId, 1))
 
-	subChainId := "did:bid:llj1:sfrVXK5LxB6ZYrqXsaqp6g3izMkm2r8n"
+	subChain := bif.NewBif(provider).System.NewSubChain()
 
 	sub, err := subChain.GetSubChain(subChainId)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("SubChain is %+v \n", sub)
 
+	if sub.Id != subChainId || sub.Status != 1 || sub.ChainCode != "llj1" || len(sub.VoterList) != 1 {
+		t.Errorf("unexpected subchain %+v", sub)
+	}
 }
 
 func TestGetSubDeadline(t *testing.T) {
-	var con = bif.NewBif(providers.NewHTTPProvider("127.0.0.1:55550", 10, false))
-	_, err := con.Core.GetChainId()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
+	provider := mock.NewProvider()
+	provider.On("subchain_deadline").Return("0x15180")
 
-	subChain := con.System.NewSubChain()
+	subChain := bif.NewBif(provider).System.NewSubChain()
 
 	deadline, err := subChain.GetDeadline()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Logf("deadline is %v \n", deadline)
+
+	if deadline != 86400 {
+		t.Errorf("unexpected deadline %d", deadline)
+	}
 }
@@ -2,30 +2,53 @@ package System
 
 import (
 	"github.com/tchain/go-tchain-sdk"
-	"github.com/tchain/go-tchain-sdk/providers"
-	"github.com/tchain/go-tchain-sdk/test/resources"
-	"strconv"
+	"github.com/tchain/go-tchain-sdk/abi"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"strings"
 	"testing"
 )
 
 // 测试系统合约的执行结果
 func TestSystemLogDecode(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
+	const transactionHash = "0x38e959864d83385724628807f22d5df5f8d8b63562b014c987d656ab7f7c7ccc"
+
+	// the data of the log of a system contract is the abi encoding of (method, status, result)
+	def := `[{ "name" : "log", "type": "function", "outputs": [{"name":"method","type":"string"},{"name":"status","type":"bool"},{"name":"result","type":"string"}]}]`
+	ABI, err := abi.JSON(strings.NewReader(def))
 	if err != nil {
-		t.Error(err)
-		t.FailNow()
+		t.Fatal(err)
+	}
+	data, err := ABI.Methods["log"].Outputs.Pack("registerDirector", true, "success")
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	// 0xe1fa5649868d8dba74ebb693e69edae57c446a8f00978653e53fc6da4e52a0c3
-	log, err := connection.System.SystemLogDecode("0x38e959864d83385724628807f22d5df5f8d8b63562b014c987d656ab7f7c7ccc")
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionReceipt", transactionHash).Return(map[string]interface{}{
+		"transactionHash":   transactionHash,
+		"blockNumber":       "0x1b4",
+		"cumulativeGasUsed": "0x5208",
+		"gasUsed":           "0x5208",
+		"status":            "0x1",
+		"logs": []interface{}{map[string]interface{}{
+			"data":            hexutil.Encode(data),
+			"blockNumber":     "0x1b4",
+			"logIndex":        "0x0",
+			"transactionHash": transactionHash,
+		}},
+	})
+
+	var connection = bif.NewBif(provider)
+
+	log, err := connection.System.SystemLogDecode(transactionHash)
 
 	if err != nil {
 		t.Errorf("err log : %v ", err)
 		t.FailNow()
 	}
 
-	t.Log("method is ", log.Method)
-	t.Log("status is ", log.Status)
-	t.Log("result is ", log.Result)
+	if log.Method != "registerDirector" || !log.Status || log.Result != "success" {
+		t.Errorf("unexpected log %+v", log)
+	}
 }
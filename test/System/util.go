@@ -4,16 +4,15 @@ import (
 	"errors"
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
-	"github.com/tchain/go-tchain-sdk/providers"
 	"github.com/tchain/go-tchain-sdk/system"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"io/ioutil"
 	"math/big"
-	"strconv"
+	"testing"
 )
 
-func connectWithSig(sigAddr string, singAddrFile string) (*bif.Bif, *system.SysTxParams, error) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+func connectWithSig(t testing.TB, sigAddr string, singAddrFile string) (*bif.Bif, *system.SysTxParams, error) {
+	var connection = bif.NewBif(resources.NewProvider(t))
 	chainId, err := connection.Core.GetChainId()
 	if err != nil {
 		return nil, nil, err
@@ -45,12 +44,3 @@ func connectWithSig(sigAddr string, singAddrFile string) (*bif.Bif, *system.SysT
 
 	return connection, sysTxParams, nil
 }
-
-func connectBif() (*bif.Bif, error) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-	_, err := connection.Core.GetChainId()
-	if err != nil {
-		return nil, err
-	}
-	return connection, nil
-}
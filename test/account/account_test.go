@@ -160,8 +160,8 @@ func TestGenKeyStore(t *testing.T) {
 		chainCode         string
 		useLightweightKDF bool
 	}{
-		{"../resources/keystore", true, resources.PassWord, resources.ChainCode, false},
-		{"../resources/keystore", false, resources.PassWord, resources.ChainCode, false},
+		{t.TempDir(), true, resources.PassWord, resources.ChainCode, false},
+		{t.TempDir(), false, resources.PassWord, resources.ChainCode, false},
 	} {
 		addr, err := account.GenKeyStore(test.storeKeyDir, test.isSm2, test.password, test.chainCode, test.useLightweightKDF)
 		if err != nil {
@@ -199,7 +199,7 @@ func TestPriKeyToKeyStore(t *testing.T) {
 		password   string
 		chainCode  string
 	}{
-		{"../resources/keystore", false, resources.Addr1Pri, resources.PassWord, resources.ChainCode},
+		{t.TempDir(), false, resources.Addr1Pri, resources.PassWord, resources.ChainCode},
 	} {
 		isSuccess, err := account.PriKeyToKeyStore(test.keyDir, test.isSM2, test.privateKey, test.password, test.chainCode)
 		if err != nil {
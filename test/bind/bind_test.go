@@ -0,0 +1,392 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/abi/bind"
+	"github.com/tchain/go-tchain-sdk/compiler"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	tokenbinding "github.com/tchain/go-tchain-sdk/test/bind/token"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+
+	"github.com/gorilla/websocket"
+)
+
+const testTokenABI = `[
+	{"inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+	{"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"info","outputs":[{"name":"name","type":"string"},{"name":"decimals","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"origin","outputs":[{"name":"","type":"tuple","internalType":"struct Token.Point","components":[{"name":"x","type":"int64"},{"name":"y","type":"uint256"}]}],"stateMutability":"pure","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"type","type":"uint256[]"}],"name":"batch","outputs":[],"stateMutability":"payable","type":"function"}
+]`
+
+// testWord returns the 32 bytes ABI word of n
+func testWord(n int64) []byte {
+	return utils.LeftPadBytes(big.NewInt(n).Bytes(), 32)
+}
+
+func TestBind(t *testing.T) {
+	code, err := bind.Bind([]string{"token"}, []string{testTokenABI}, []string{"6080604052"}, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "token.go", code, 0)
+	if err != nil {
+		t.Fatalf("invalid generated code: %v\n%s", err, code)
+	}
+	if file.Name.Name != "token" {
+		t.Errorf("package %s", file.Name.Name)
+	}
+
+	// the generated code compiles against the packages of the SDK
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check("token", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated code doesn't type-check: %v\n%s", err, code)
+	}
+
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			declared[decl.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					declared[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						declared[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	for _, name := range []string{
+		"TokenABI", "TokenBin", "DeployToken", "NewToken", "NewTokenCaller", "NewTokenTransactor", "NewTokenFilterer",
+		"Token", "TokenCaller", "TokenTransactor", "TokenFilterer", "TokenTransfer", "TokenPoint",
+		"BalanceOf", "Info", "Origin", "Transfer", "Batch", "FilterTransfer", "WatchTransfer", "ParseTransfer",
+	} {
+		if !declared[name] {
+			t.Errorf("%s isn't generated", name)
+		}
+	}
+
+	// the contracts of a compiler output are named after their name in the sources
+	output := &compiler.Output{Contracts: map[string]*compiler.Artifact{
+		"token.sol:Token": {Abi: testTokenABI, Bin: "6080604052"},
+	}}
+	fromOutput, err := bind.BindOutput(output, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ = bind.Bind([]string{"Token"}, []string{testTokenABI}, []string{"6080604052"}, "token")
+	if fromOutput != code {
+		t.Error("the bindings of the compiler output differ from the ones of the ABI")
+	}
+
+	// the bindings used by the tests of the generated code are up to date
+	generated, err := ioutil.ReadFile("token/token.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(generated) != code {
+		t.Error("token/token.go is outdated, generate it again with abigen from testTokenABI")
+	}
+
+	if _, err := bind.Bind([]string{"Token"}, []string{"{"}, []string{""}, "token"); err == nil {
+		t.Error("expected an error for an invalid ABI")
+	}
+}
+
+func TestBoundContract(t *testing.T) {
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	provider := mock.NewProvider()
+	provider.On("core_chainId").Return("0x14d").Once()
+
+	var connection = bif.NewBif(provider)
+	contract, err := bind.NewBoundContract(resources.Addr2, testTokenABI, connection.Core)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a single output
+	provider.On("core_call").Return(hexutil.Encode(testWord(1000))).Once()
+	balance := new(*big.Int)
+	if err := contract.Call(nil, balance, "balanceOf", sender); err != nil {
+		t.Fatal(err)
+	}
+	if (*balance).Int64() != 1000 {
+		t.Errorf("balance %s", *balance)
+	}
+
+	// several outputs
+	var output []byte
+	output = append(output, testWord(64)...)
+	output = append(output, testWord(18)...)
+	output = append(output, testWord(3)...)
+	output = append(output, utils.RightPadBytes([]byte("BIF"), 32)...)
+	provider.On("core_call").Return(hexutil.Encode(output)).Once()
+	name, decimals := new(string), new(uint8)
+	if err := contract.Call(&bind.CallOpts{Sender: resources.Addr1}, &[]interface{}{name, decimals}, "info"); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "BIF" || *decimals != 18 {
+		t.Errorf("info %q %d", *name, *decimals)
+	}
+
+	// no output at the address
+	provider.On("core_call").Return("0x").Once()
+	if err := contract.Call(nil, balance, "balanceOf", sender); err != bind.ErrNoCode {
+		t.Errorf("expected ErrNoCode, got %v", err)
+	}
+
+	provider.On("core_sendRawTransaction").Return("0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b").Once()
+	hash, err := contract.Transact(&bind.TransactOpts{
+		PrivateKey: resources.Addr1Pri,
+		ChainId:    333,
+		Nonce:      big.NewInt(1),
+		GasPrice:   big.NewInt(1),
+		GasLimit:   60000,
+	}, "transfer", recipient, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b" {
+		t.Errorf("hash %s", hash)
+	}
+
+	// the events are filtered by their indexed arguments and unpacked
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	from := utils.BytesToHash(sender.Bytes()).Hex()
+	to := utils.BytesToHash(recipient.Bytes()).Hex()
+	provider.On("core_getLogs", map[string]interface{}{
+		"fromBlock": "0x64",
+		"toBlock":   "latest",
+		"address":   resources.Addr2,
+		"topics":    []interface{}{transfer, from, nil},
+	}).Return(json.RawMessage(`[{
+		"address": "` + resources.Addr2 + `",
+		"topics": ["` + transfer + `", "` + from + `", "` + to + `"],
+		"data": "` + hexutil.Encode(testWord(5)) + `",
+		"blockNumber": "0x65",
+		"transactionHash": "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+		"transactionIndex": "0x0",
+		"blockHash": "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+		"logIndex": "0x0",
+		"removed": false
+	}]`)).Once()
+
+	logs, err := contract.FilterLogs(&bind.FilterOpts{Start: 100}, "Transfer", []interface{}{sender}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+
+	var event struct {
+		From  utils.Address
+		To    utils.Address
+		Value *big.Int
+		Raw   dto.TransactionLogs
+	}
+	if err := contract.UnpackLog(&event, "Transfer", logs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if event.From != sender || event.To != recipient || event.Value.Int64() != 5 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// a log of another event isn't unpacked
+	logs[0].Topics[0] = to
+	if err := contract.UnpackLog(&event, "Transfer", logs[0]); err == nil {
+		t.Error("expected an error for a log of another event")
+	}
+
+	if pending := provider.Pending(); len(pending) != 0 {
+		t.Errorf("pending expectations %v", pending)
+	}
+}
+
+func TestBoundContractWatch(t *testing.T) {
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	from := utils.BytesToHash(sender.Bytes()).Hex()
+	to := utils.BytesToHash(recipient.Bytes()).Hex()
+	subscription := "0x9ce59a13059e417087c02d3236a0b1cc"
+	notification := func(data []byte) map[string]interface{} {
+		return map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "core_subscription",
+			"params": map[string]interface{}{
+				"subscription": subscription,
+				"result": map[string]interface{}{
+					"address":          resources.Addr2,
+					"topics":           []string{transfer, from, to},
+					"data":             hexutil.Encode(data),
+					"blockNumber":      "0x65",
+					"transactionHash":  "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+					"transactionIndex": "0x0",
+					"blockHash":        "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+					"logIndex":         "0x0",
+					"removed":          false,
+				},
+			},
+		}
+	}
+
+	// the node streams a null notification and two transfers on every
+	// subscription, and drops the connection when the test asks for it
+	upgrader := websocket.Upgrader{}
+	filters := make(chan interface{}, 2)
+	drop := make(chan struct{})
+	var dropOnce sync.Once
+	dropConnections := func() { dropOnce.Do(func() { close(drop) }) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		go func() {
+			for {
+				var request map[string]interface{}
+				if err := conn.ReadJSON(&request); err != nil {
+					return
+				}
+				if request["method"] != "core_subscribe" {
+					continue
+				}
+				filters <- request["params"].([]interface{})[1]
+				conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": subscription})
+				conn.WriteJSON(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"method":  "core_subscription",
+					"params":  map[string]interface{}{"subscription": subscription, "result": nil},
+				})
+				conn.WriteJSON(notification(testWord(5)))
+				conn.WriteJSON(notification(testWord(6)))
+			}
+		}()
+		<-drop
+	}))
+	defer server.Close()
+	defer dropConnections()
+
+	provider, err := providers.NewWebSocketProviderWithOptions("ws"+strings.TrimPrefix(server.URL, "http"), providers.WithoutReconnect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var connection = bif.NewBif(provider)
+	defer connection.Provider.Close()
+
+	// the logs are handed to the handler of WatchLogs
+	contract, err := bind.NewBoundContract(resources.Addr2, testTokenABI, connection.Core)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handled := make(chan dto.TransactionLogs)
+	sub, err := contract.WatchLogs(nil, "Transfer", func(ctx context.Context, log dto.TransactionLogs) error {
+		select {
+		case handled <- log:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, []interface{}{sender})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := (<-filters).(map[string]interface{})
+	if topics, _ := filter["topics"].([]interface{}); filter["address"] != resources.Addr2 || len(topics) != 2 || topics[0] != transfer || topics[1] != from {
+		t.Errorf("unexpected filter %v", filter)
+	}
+	select {
+	case log := <-handled:
+		if log.TransactionHash != "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b" {
+			t.Errorf("unexpected log %+v", log)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no log handled")
+	}
+	sub.Unsubscribe()
+
+	// the generated Watch functions send the unpacked events to their sink
+	filterer, err := tokenbinding.NewTokenFilterer(resources.Addr2, connection.Core)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := make(chan *tokenbinding.TokenTransfer)
+	sub, err = filterer.WatchTransfer(nil, sink, nil, []utils.Address{recipient})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter = (<-filters).(map[string]interface{})
+	if topics, _ := filter["topics"].([]interface{}); len(topics) != 3 || topics[1] != nil || topics[2] != to {
+		t.Errorf("unexpected filter %v", filter)
+	}
+	select {
+	case event := <-sink:
+		if event.From != sender || event.To != recipient || event.Value.Int64() != 5 || event.Raw.BlockNumber.Int64() != 0x65 {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	// the second transfer is left in the sink, a dropped connection ends the
+	// watch and its error is left to the caller
+	dropConnections()
+	select {
+	case err := <-sub.Err():
+		if err == nil {
+			t.Error("expected the error of the dropped connection")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the subscription didn't end")
+	}
+}
@@ -0,0 +1,231 @@
+// Code generated by abigen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package token
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/tchain/go-tchain-sdk/abi/bind"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/utils"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = context.Background
+	_ = dto.TransactionLogs{}
+	_ = (*providers.Subscription)(nil)
+	_ = utils.Address{}
+)
+
+// TokenPoint is an auto generated low-level Go binding around a user-defined struct.
+type TokenPoint struct {
+	X int64
+	Y *big.Int
+}
+
+// TokenABI is the input ABI used to generate the binding from.
+const TokenABI = "[{\"inputs\":[{\"name\":\"supply\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"info\",\"outputs\":[{\"name\":\"name\",\"type\":\"string\"},{\"name\":\"decimals\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"origin\",\"outputs\":[{\"name\":\"\",\"type\":\"tuple\",\"internalType\":\"struct Token.Point\",\"components\":[{\"name\":\"x\",\"type\":\"int64\"},{\"name\":\"y\",\"type\":\"uint256\"}]}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"to\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"type\",\"type\":\"uint256[]\"}],\"name\":\"batch\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]"
+
+// TokenBin is the compiled bytecode used for deploying new contracts.
+const TokenBin = "0x6080604052"
+
+// DeployToken deploys a new Token contract, it returns the hash of the
+// transaction, the address of the contract is in its receipt.
+func DeployToken(opts *bind.TransactOpts, backend *core.Core, supply *big.Int) (string, error) {
+	return bind.DeployContract(opts, TokenABI, TokenBin, backend, supply)
+}
+
+// Token is an auto generated Go binding around a contract.
+type Token struct {
+	TokenCaller     // Read-only binding to the contract
+	TokenTransactor // Write-only binding to the contract
+	TokenFilterer   // Log filterer for contract events
+}
+
+// TokenCaller is an auto generated read-only Go binding around a contract.
+type TokenCaller struct {
+	contract *bind.BoundContract
+}
+
+// TokenTransactor is an auto generated write-only Go binding around a contract.
+type TokenTransactor struct {
+	contract *bind.BoundContract
+}
+
+// TokenFilterer is an auto generated log filtering Go binding around a contract's events.
+type TokenFilterer struct {
+	contract *bind.BoundContract
+}
+
+// NewToken creates a new instance of Token, bound to the contract at
+// address, in did:bid form.
+func NewToken(address string, backend *core.Core) (*Token, error) {
+	contract, err := bind.NewBoundContract(address, TokenABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{TokenCaller: TokenCaller{contract: contract}, TokenTransactor: TokenTransactor{contract: contract}, TokenFilterer: TokenFilterer{contract: contract}}, nil
+}
+
+// NewTokenCaller creates a new read-only instance of Token, bound to the contract at address.
+func NewTokenCaller(address string, backend *core.Core) (*TokenCaller, error) {
+	contract, err := bind.NewBoundContract(address, TokenABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenCaller{contract: contract}, nil
+}
+
+// NewTokenTransactor creates a new write-only instance of Token, bound to the contract at address.
+func NewTokenTransactor(address string, backend *core.Core) (*TokenTransactor, error) {
+	contract, err := bind.NewBoundContract(address, TokenABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenTransactor{contract: contract}, nil
+}
+
+// NewTokenFilterer creates a new log filterer instance of Token, bound to the contract at address.
+func NewTokenFilterer(address string, backend *core.Core) (*TokenFilterer, error) {
+	contract, err := bind.NewBoundContract(address, TokenABI, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenFilterer{contract: contract}, nil
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_Token *TokenCaller) BalanceOf(opts *bind.CallOpts, owner utils.Address) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _Token.contract.Call(opts, out, "balanceOf", owner)
+	return *ret0, err
+}
+
+// Info is a free data retrieval call binding the contract method 0x370158ea.
+//
+// Solidity: function info() view returns(string name, uint8 decimals)
+func (_Token *TokenCaller) Info(opts *bind.CallOpts) (string, uint8, error) {
+	var (
+		ret0 = new(string)
+		ret1 = new(uint8)
+	)
+	out := &[]interface{}{
+		ret0,
+		ret1,
+	}
+	err := _Token.contract.Call(opts, out, "info")
+	return *ret0, *ret1, err
+}
+
+// Origin is a free data retrieval call binding the contract method 0x938b5f32.
+//
+// Solidity: function origin() pure returns((int64,uint256))
+func (_Token *TokenCaller) Origin(opts *bind.CallOpts) (TokenPoint, error) {
+	var (
+		ret0 = new(TokenPoint)
+	)
+	out := ret0
+	err := _Token.contract.Call(opts, out, "origin")
+	return *ret0, err
+}
+
+// Batch is a paid mutator transaction binding the contract method 0x29ba1629,
+// it returns the hash of the transaction.
+//
+// Solidity: function batch(uint256[] type) payable returns()
+func (_Token *TokenTransactor) Batch(opts *bind.TransactOpts, type_ []*big.Int) (string, error) {
+	return _Token.contract.Transact(opts, "batch", type_)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb,
+// it returns the hash of the transaction.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_Token *TokenTransactor) Transfer(opts *bind.TransactOpts, to utils.Address, value *big.Int) (string, error) {
+	return _Token.contract.Transact(opts, "transfer", to, value)
+}
+
+// TokenTransfer represents a Transfer event raised by the Token contract.
+type TokenTransfer struct {
+	From  utils.Address
+	To    utils.Address
+	Value *big.Int
+	Raw   dto.TransactionLogs // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Token *TokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []utils.Address, to []utils.Address) ([]*TokenTransfer, error) {
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, err := _Token.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*TokenTransfer, len(logs))
+	for i, log := range logs {
+		if events[i], err = _Token.ParseTransfer(log); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef,
+// the events are sent to sink until the context of opts is done or the subscription fails.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Token *TokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *TokenTransfer, from []utils.Address, to []utils.Address) (*providers.Subscription, error) {
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	return _Token.contract.WatchLogs(opts, "Transfer", func(ctx context.Context, log dto.TransactionLogs) error {
+		event, err := _Token.ParseTransfer(log)
+		if err != nil {
+			return err
+		}
+		select {
+		case sink <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, fromRule, toRule)
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Token *TokenFilterer) ParseTransfer(log dto.TransactionLogs) (*TokenTransfer, error) {
+	event := new(TokenTransfer)
+	if err := _Token.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -18,12 +18,15 @@ func main() {
 
 	var connection = bif.NewBif(providers.NewHTTPProvider(address, 10, false))
 
-	var lastBlock *dto.BlockNoDetails
-	var lastLastBlock *dto.BlockNoDetails
+	var lastBlock *dto.Block
+	var lastLastBlock *dto.Block
 	for true {
 		number, _ := connection.Core.GetBlockNumber()
-		block, _ := connection.Core.GetBlockByNumber(number.String(), false)
-		b := block.(*dto.BlockNoDetails)
+		b, err := connection.Core.GetBlockWithHashesByNumber(number.String())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 		txLen := len(b.Transactions)
 		if lastBlock == nil {
 			lastBlock = b
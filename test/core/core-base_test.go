@@ -18,16 +18,19 @@ import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"math/big"
-	"strconv"
 	"testing"
 )
 
 func TestCoreEstimateGas(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_estimateGas").Return("0x5a3c")
+
+	var connection = bif.NewBif(provider)
 
 	generator, err := connection.Core.GetGenerator()
 
@@ -37,6 +40,7 @@ func TestCoreEstimateGas(t *testing.T) {
 	}
 
 	transaction := new(dto.TransactionParameters)
+	transaction.ChainId = 333
 	transaction.Payload = "test"
 	transaction.Sender = generator
 	transaction.Recipient = generator
@@ -50,32 +54,36 @@ func TestCoreEstimateGas(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(gas)
-
+	if gas.Int64() != 23100 {
+		t.Errorf("unexpected gas %v", gas)
+	}
 }
 
 func TestCoreGasPrice(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_gasPrice").Return("0x2d")
+
+	var connection = bif.NewBif(provider)
 
 	gasPrice, err := connection.Core.GetGasPrice()
 
 	if err != nil {
 		t.Error(err)
-		t.Fail()
-	}
-
-	if gasPrice.Int64() < 0 {
-		t.Errorf("Negative gasprice")
 		t.FailNow()
 	}
 
-	t.Log(gasPrice.Int64())
+	if gasPrice.Int64() != 45 {
+		t.Errorf("unexpected gas price %v", gasPrice)
+	}
 }
 
 func TestCoreGenerating(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generating").Return(true)
+
+	var connection = bif.NewBif(provider)
 
 	generating, err := connection.Core.Generating()
 
@@ -84,13 +92,17 @@ func TestCoreGenerating(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(generating)
-
+	if !generating {
+		t.Errorf("expected a generating node")
+	}
 }
 
 func TestGetAccounts(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_accounts").Return([]string{resources.Addr1, resources.Addr2})
+
+	var connection = bif.NewBif(provider)
 
 	accounts, err := connection.Core.GetAccounts()
 
@@ -99,12 +111,18 @@ func TestGetAccounts(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(accounts)
+	if len(accounts) != 2 || accounts[0] != resources.Addr1 || accounts[1] != resources.Addr2 {
+		t.Errorf("unexpected accounts %v", accounts)
+	}
 }
 
 func TestCoreGetBalance(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_getBalance", resources.Addr1, block.PENDING).Return("0xde0b6b3a7640000")
+
+	var connection = bif.NewBif(provider)
 
 	generator, _ := connection.Core.GetGenerator()
 
@@ -115,27 +133,36 @@ func TestCoreGetBalance(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(bal)
-
+	if bal.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("unexpected balance %v", bal)
+	}
 }
 
 func TestGetChainId(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_chainId").Return("0x14d")
+
+	var connection = bif.NewBif(provider)
 
 	chainId, err := connection.Core.GetChainId()
 
 	if err != nil {
 		t.Error(err)
-		t.Fail()
+		t.FailNow()
 	}
 
-	t.Log(chainId)
+	if chainId != 333 {
+		t.Errorf("unexpected chainId %d", chainId)
+	}
 }
 
 func TestGetGenerator(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+
+	var connection = bif.NewBif(provider)
 
 	generator, err := connection.Core.GetGenerator()
 
@@ -144,12 +171,17 @@ func TestGetGenerator(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(generator)
+	if generator != resources.Addr1 {
+		t.Errorf("unexpected generator %s", generator)
+	}
 }
 
 func TestCoreHashRate(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_hashrate").Return("0x0")
+
+	var connection = bif.NewBif(provider)
 
 	rate, err := connection.Core.GetHashRate()
 
@@ -158,16 +190,18 @@ func TestCoreHashRate(t *testing.T) {
 		t.FailNow()
 	}
 
-	if rate.Uint64() < 0 {
-		t.Errorf("Less than 0 hash rate")
-		t.FailNow()
+	if rate.Sign() != 0 {
+		t.Errorf("unexpected hash rate %v", rate)
 	}
-
-	t.Log(rate)
 }
+
 func TestCoreGetTrustNumber(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_getCanTrust", resources.Addr1, block.LATEST).Return("0x3")
+
+	var connection = bif.NewBif(provider)
 	address, err := connection.Core.GetGenerator()
 	if err != nil {
 		t.Error(err)
@@ -179,12 +213,19 @@ func TestCoreGetTrustNumber(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log("trustNumber is ", trustNumber)
+	if trustNumber != 3 {
+		t.Errorf("unexpected trust number %d", trustNumber)
+	}
 }
 
 func TestCoreGetPendingTransactions(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_sendTransaction").Return(testTxHash)
+	provider.On("core_pendingTransactions").Return([]interface{}{testPendingTransaction()})
+
+	var connection = bif.NewBif(provider)
 
 	generator, err := connection.Core.GetGenerator()
 	if err != nil {
@@ -193,6 +234,7 @@ func TestCoreGetPendingTransactions(t *testing.T) {
 	}
 
 	transaction := new(dto.TransactionParameters)
+	transaction.ChainId = 333
 	transaction.Sender = generator
 	transaction.Recipient = resources.Addr1
 	transaction.Amount = big.NewInt(0).Mul(big.NewInt(1), big.NewInt(1e15))
@@ -201,35 +243,50 @@ func TestCoreGetPendingTransactions(t *testing.T) {
 
 	_, err = connection.Core.SendTransaction(transaction)
 	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	pendingTransactions, err := connection.Core.GetPendingTransactions()
+	if err != nil {
+		t.Error(err)
 		t.FailNow()
 	}
-	pendingTransactions, _ := connection.Core.GetPendingTransactions()
 
-	if len(pendingTransactions) != 0 {
-		t.Logf("%#v \n", pendingTransactions[0])
+	if len(pendingTransactions) != 1 || pendingTransactions[0].Hash != testTxHash || pendingTransactions[0].Nonce != 1 {
+		t.Errorf("unexpected pending transactions %v", pendingTransactions)
 	}
 }
 
 func TestCoreGetStorageAt(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	value := "0x00000000000000000000000000000000000000000000000000000000000004d2"
+
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_getStorageAt", resources.Addr1, "0x0", block.LATEST).Return(value)
+
+	var connection = bif.NewBif(provider)
 
 	generator, _ := connection.Core.GetGenerator()
 
-	value, err := connection.Core.GetStorageAt(generator, big.NewInt(0), block.LATEST)
+	storage, err := connection.Core.GetStorageAt(generator, big.NewInt(0), block.LATEST)
 
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(value)
-
+	if storage != value {
+		t.Errorf("unexpected storage %s", storage)
+	}
 }
 
 func TestCoreGetProtocolVersion(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_protocolVersion").Return("0x41")
+
+	var connection = bif.NewBif(provider)
 
 	version, err := connection.Core.GetProtocolVersion()
 
@@ -238,13 +295,18 @@ func TestCoreGetProtocolVersion(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(version)
-
+	if version != 65 {
+		t.Errorf("unexpected protocol version %d", version)
+	}
 }
 
 func TestCoreSyncing(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_syncing").Return(map[string]interface{}{"startingBlock": 1, "currentBlock": 436, "highestBlock": 500}).Once()
+	provider.On("core_syncing").Return(false)
+
+	var connection = bif.NewBif(provider)
 
 	syncing, err := connection.Core.IsSyncing()
 
@@ -252,19 +314,43 @@ func TestCoreSyncing(t *testing.T) {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(syncing)
+	if syncing.CurrentBlock == nil || syncing.CurrentBlock.Int64() != 436 || syncing.HighestBlock.Int64() != 500 {
+		t.Errorf("unexpected syncing status %+v", syncing)
+	}
+
+	// a node which isn't syncing answers false
+	syncing, err = connection.Core.IsSyncing()
 
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if syncing.CurrentBlock != nil {
+		t.Errorf("unexpected syncing status %+v", syncing)
+	}
 }
 
-// todo: 测试失败  测试方法的问题
 func TestCoreGetProof(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_generator").Return(resources.Addr1)
+	provider.On("core_getProof", resources.Addr1, []string{"0", "1"}, block.LATEST).Return(map[string]interface{}{
+		"address":      resources.Addr1,
+		"accountProof": []string{"0xf8718080"},
+		"balance":      "0xde0b6b3a7640000",
+		"codeHash":     "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"nonce":        "0x7",
+		"storageHash":  "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"storageProof": []interface{}{
+			map[string]interface{}{"key": "0", "value": "0x0", "proof": []string{}},
+			map[string]interface{}{"key": "1", "value": "0x0", "proof": []string{}},
+		},
+	})
+
+	var connection = bif.NewBif(provider)
 
 	generator, _ := connection.Core.GetGenerator()
 
-	t.Logf("generator is %s \n", generator)
-
 	proof, err := connection.Core.GetProof(generator, []string{"0", "1"}, block.LATEST)
 
 	if err != nil {
@@ -272,6 +358,7 @@ func TestCoreGetProof(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Logf("%#v \n", proof)
-
-}
\ No newline at end of file
+	if proof.Balance.ToInt().Cmp(big.NewInt(1e18)) != 0 || proof.Nonce != 7 || len(proof.StorageProof) != 2 {
+		t.Errorf("unexpected proof %+v", proof)
+	}
+}
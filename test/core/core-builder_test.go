@@ -0,0 +1,168 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+)
+
+func testBlockWithGasPrices(number string, prices ...string) map[string]interface{} {
+	var transactions []interface{}
+	for _, price := range prices {
+		transactions = append(transactions, map[string]interface{}{
+			"chainId":          "0x14d",
+			"blockNumber":      number,
+			"gas":              "0x5208",
+			"gasPrice":         price,
+			"nonce":            "0x0",
+			"transactionIndex": "0x0",
+			"amount":           "0x0",
+		})
+	}
+	return map[string]interface{}{"number": number, "timestamp": "0x0", "size": "0x0", "transactions": transactions}
+}
+
+func TestCoreTxBuilder_Fill(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	provider := &batchingProvider{Provider: mock.NewProvider()}
+	provider.On("core_chainId").Return("0x14d").Times(2)
+	provider.On("core_blockNumber").Return("0x2")
+	provider.On("core_getBlockByNumber", "0x2", true).Return(testBlockWithGasPrices("0x2", "0x1", "0x5"))
+	provider.On("core_getBlockByNumber", "0x1", true).Return(testBlockWithGasPrices("0x1"))
+	provider.On("core_getBlockByNumber", "0x0", true).Return(testBlockWithGasPrices("0x0", "0x3", "0x9", "0x7"))
+	provider.On("core_estimateGas").Return("0x5208")
+	provider.On("core_getTransactionCount", sender.String(""), "pending").Return("0x7")
+
+	var connection = bif.NewBif(provider)
+	builder := connection.Core.NewTxBuilder(&core.BuilderOptions{
+		GasPrice:      core.CappedGasPrice{Strategy: core.PercentileGasPrice{Blocks: 5, Percentile: 50}, Cap: big.NewInt(4)},
+		GasMultiplier: 1.5,
+	})
+
+	tx := &account.SignTxParams{Sender: &sender, Recipient: &recipient, Amount: big.NewInt(1)}
+	reservation, err := builder.Fill(context.Background(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reservation != nil {
+		t.Error("unexpected reservation without a nonce manager")
+	}
+
+	// the median of 1, 3, 5, 7, 9 is 5, capped to 4
+	if tx.ChainId != 333 || tx.Nonce.Int64() != 7 || tx.GasPrice.Int64() != 4 || tx.GasLimit != 31500 {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+	// the blocks of the percentile are read with one batch
+	if fmt.Sprint(provider.batches) != "[[core_getBlockByNumber core_getBlockByNumber core_getBlockByNumber]]" {
+		t.Errorf("unexpected batches %v", provider.batches)
+	}
+
+	// the fields set by the caller are kept, the chain id is read once per builder
+	tx = &account.SignTxParams{Sender: &sender, Recipient: &recipient, Nonce: big.NewInt(1), GasLimit: 21000}
+	builder = connection.Core.NewTxBuilder(&core.BuilderOptions{GasPrice: core.FixedGasPrice{Price: big.NewInt(2)}})
+	if _, err := builder.Fill(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builder.Fill(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.ChainId != 333 || tx.Nonce.Int64() != 1 || tx.GasPrice.Int64() != 2 || tx.GasLimit != 21000 {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+}
+
+func TestCoreTxBuilder_SignAndSend(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	provider := mock.NewProvider()
+	provider.On("core_chainId").Return("0x14d")
+	provider.On("core_gasPrice").Return("0x1")
+	provider.On("core_estimateGas").Return("0x5208")
+	provider.On("core_getTransactionCount", sender.String(""), "pending").Return("0x3")
+	provider.On("core_sendRawTransaction").ReturnError(-32000, "insufficient funds for gas * price + value").Once()
+	provider.On("core_sendRawTransaction").Return(testTxHash)
+
+	var connection = bif.NewBif(provider)
+	builder := connection.Core.NewTxBuilder(&core.BuilderOptions{Nonces: connection.Core.NewNonceManager(nil)})
+
+	tx := &account.SignTxParams{Sender: &sender, Recipient: &recipient, Amount: big.NewInt(1)}
+	if _, err := builder.SignAndSend(context.Background(), tx, resources.Addr1Pri, false); err == nil {
+		t.Fatal("expected the error of the node")
+	}
+
+	// the nonce of the rejected transaction is handed out again
+	tx = &account.SignTxParams{Sender: &sender, Recipient: &recipient, Amount: big.NewInt(1)}
+	hash, err := builder.SignAndSend(context.Background(), tx, resources.Addr1Pri, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != testTxHash || tx.Nonce.Int64() != 3 || tx.GasLimit != 25200 {
+		t.Errorf("unexpected transaction %s %+v", hash, tx)
+	}
+}
+
+func TestCoreTxBuilder_SignAndSendUncertain(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	provider := mock.NewProvider()
+	provider.On("core_chainId").Return("0x14d")
+	provider.On("core_gasPrice").Return("0x1")
+	provider.On("core_estimateGas").Return("0x5208")
+	provider.On("core_getTransactionCount", sender.String(""), "pending").Return("0x3").Once()
+	provider.On("core_getTransactionCount", sender.String(""), "pending").Fail(io.ErrUnexpectedEOF)
+	provider.On("core_sendRawTransaction").Fail(&providers.TransportError{Err: io.ErrUnexpectedEOF})
+
+	var connection = bif.NewBif(provider)
+	builder := connection.Core.NewTxBuilder(&core.BuilderOptions{Nonces: connection.Core.NewNonceManager(nil)})
+
+	// the transaction may have been broadcast, the resync error is returned
+	// with the error of the send
+	tx := &account.SignTxParams{Sender: &sender, Recipient: &recipient, Amount: big.NewInt(1)}
+	_, err := builder.SignAndSend(context.Background(), tx, resources.Addr1Pri, false)
+	var transportErr *providers.TransportError
+	if !errors.As(err, &transportErr) || !strings.Contains(err.Error(), "resyncing the nonce failed") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the nonce isn't handed out again
+	tx = &account.SignTxParams{Sender: &sender, Recipient: &recipient, Amount: big.NewInt(1)}
+	if _, err := builder.SignAndSend(context.Background(), tx, resources.Addr1Pri, false); err == nil {
+		t.Fatal("expected the error of the transport")
+	}
+	if tx.Nonce.Int64() != 4 {
+		t.Errorf("unexpected nonce %v", tx.Nonce)
+	}
+}
@@ -0,0 +1,131 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+const (
+	testTxHash     = "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+	testBlockHashA = "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+	testBlockHashB = "0xe99e022112df268087ea7eafaf4790497fd21dbeeb6bd7a1721df161a6657a54"
+)
+
+func testReceipt(blockHash string, blockNumber string) map[string]interface{} {
+	return map[string]interface{}{
+		"transactionHash":   testTxHash,
+		"transactionIndex":  "0x0",
+		"blockHash":         blockHash,
+		"blockNumber":       blockNumber,
+		"cumulativeGasUsed": "0x5208",
+		"gasUsed":           "0x5208",
+		"logs":              []interface{}{},
+		"status":            "0x1",
+	}
+}
+
+func testPendingTransaction() map[string]interface{} {
+	return map[string]interface{}{
+		"hash":     testTxHash,
+		"chainId":  "0x14d",
+		"nonce":    "0x1",
+		"gas":      "0x5208",
+		"gasPrice": "0x1",
+		"amount":   "0x0",
+	}
+}
+
+func TestCoreWaitMined_Reorg(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionReceipt").Return(nil).Once()
+	provider.On("core_getTransactionReceipt").Return(testReceipt(testBlockHashA, "0x10")).Once()
+	provider.On("core_getTransactionReceipt").Return(nil).Once()
+	provider.On("core_getTransactionReceipt").Return(testReceipt(testBlockHashB, "0x11"))
+	provider.On("core_getTransactionByHash").Return(testPendingTransaction())
+	provider.On("core_getBlockByNumber", "0x10", false).Return(map[string]interface{}{"number": "0x10", "hash": testBlockHashA, "timestamp": "0x0"})
+	provider.On("core_getBlockByNumber", "0x11", false).Return(map[string]interface{}{"number": "0x11", "hash": testBlockHashB, "timestamp": "0x0"})
+	provider.On("core_blockNumber").Return("0x10").Once()
+	provider.On("core_blockNumber").Return("0x12").Once()
+	provider.On("core_blockNumber").Return("0x13")
+
+	var connection = bif.NewBif(provider)
+
+	tracker := connection.Core.NewConfirmationTracker(&core.WaitOptions{Confirmations: 2, PollInterval: 10 * time.Millisecond})
+	defer tracker.Close()
+
+	var statuses []core.ConfirmationStatus
+	var last core.ConfirmationEvent
+	for event := range tracker.Track(context.Background(), testTxHash) {
+		statuses = append(statuses, event.Status)
+		last = event
+	}
+
+	expected := []core.ConfirmationStatus{core.StatusPending, core.StatusMined, core.StatusReorged, core.StatusPending, core.StatusMined, core.StatusConfirmed}
+	if len(statuses) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, statuses)
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, statuses)
+		}
+	}
+	if last.Receipt.BlockHash != testBlockHashB || last.Confirmations != 2 || !last.Final() {
+		t.Errorf("unexpected event %+v", last)
+	}
+}
+
+func TestCoreWaitMined_Dropped(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionReceipt").Return(nil)
+	provider.On("core_getTransactionByHash").Return(nil)
+
+	var connection = bif.NewBif(provider)
+
+	_, err := connection.Core.WaitMined(context.Background(), testTxHash, &core.WaitOptions{PollInterval: 10 * time.Millisecond, DroppedAfter: 50 * time.Millisecond})
+	if !errors.Is(err, core.ErrTransactionDropped) {
+		t.Errorf("expected ErrTransactionDropped, got %v", err)
+	}
+}
+
+func TestCoreWaitMined_Timeout(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionReceipt").Return(nil)
+	provider.On("core_getTransactionByHash").Return(testPendingTransaction())
+
+	var connection = bif.NewBif(provider)
+
+	_, err := connection.Core.WaitMined(context.Background(), testTxHash, &core.WaitOptions{PollInterval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, core.ErrWaitTimeout) {
+		t.Errorf("expected ErrWaitTimeout, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = connection.Core.WaitMined(ctx, testTxHash, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+)
+
+const testContractABI = `[
+	{"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"info","outputs":[{"name":"name","type":"string"},{"name":"decimals","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+// testRevertData returns the revert data of Error(reason)
+func testRevertData(reason string) []byte {
+	data := []byte{0x08, 0xc3, 0x79, 0xa0}
+	data = append(data, utils.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	data = append(data, utils.LeftPadBytes(big.NewInt(int64(len(reason))).Bytes(), 32)...)
+	return append(data, utils.RightPadBytes([]byte(reason), 32)...)
+}
+
+func TestCoreContractCallInto(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+
+	var info []byte
+	info = append(info, utils.LeftPadBytes(big.NewInt(64).Bytes(), 32)...)
+	info = append(info, utils.LeftPadBytes(big.NewInt(18).Bytes(), 32)...)
+	info = append(info, utils.LeftPadBytes(big.NewInt(3).Bytes(), 32)...)
+	info = append(info, utils.RightPadBytes([]byte("BIF"), 32)...)
+
+	provider := mock.NewProvider()
+	provider.On("core_call").Return(hexutil.Encode(utils.LeftPadBytes(big.NewInt(1000).Bytes(), 32))).Once()
+	provider.On("core_call").Return(hexutil.Encode(info)).Times(2)
+	provider.On("core_call").Return("0x").Once()
+
+	var connection = bif.NewBif(provider)
+	contract, err := connection.Core.NewContract(testContractABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &core.CallOpts{
+		Transaction: &dto.TransactionParameters{ChainId: 333, Sender: resources.Addr1, Recipient: resources.Addr2},
+		Block:       "0x10",
+	}
+
+	balance := new(*big.Int)
+	if err := contract.CallInto(balance, opts, "balanceOf", sender); err != nil {
+		t.Fatal(err)
+	}
+	if (*balance).Int64() != 1000 {
+		t.Errorf("balance %s", *balance)
+	}
+	if opts.Transaction.Payload != "" {
+		t.Error("the transaction of opts is modified")
+	}
+
+	name, decimals := new(string), new(uint8)
+	if err := contract.CallInto(&[]interface{}{name, decimals}, &core.CallOpts{Transaction: opts.Transaction}, "info"); err != nil {
+		t.Fatal(err)
+	}
+	if *name != "BIF" || *decimals != 18 {
+		t.Errorf("info %q %d", *name, *decimals)
+	}
+
+	values, err := contract.CallValues(&core.CallOpts{Transaction: opts.Transaction}, "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0] != "BIF" || values[1] != uint8(18) {
+		t.Errorf("values %v", values)
+	}
+
+	if _, err := contract.CallValues(&core.CallOpts{Transaction: opts.Transaction}, "balanceOf", sender); err != core.ErrNoCode {
+		t.Errorf("expected ErrNoCode, got %v", err)
+	}
+
+	// the block of opts is the block of the call, latest by default
+	var blocks []string
+	for _, call := range provider.Calls() {
+		var params []json.RawMessage
+		if err := json.Unmarshal(call.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		var number string
+		json.Unmarshal(params[1], &number)
+		blocks = append(blocks, number)
+	}
+	if want := "[0x10 latest latest latest]"; fmt.Sprint(blocks) != want {
+		t.Errorf("blocks %v, want %s", blocks, want)
+	}
+
+	if pending := provider.Pending(); len(pending) != 0 {
+		t.Errorf("pending expectations %v", pending)
+	}
+}
+
+func TestCoreContractCallRevert(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+
+	provider := mock.NewProvider()
+	provider.On("core_call").Fail(&providers.RPCError{Code: 3, Message: "execution reverted: not allowed", Data: hexutil.Encode(testRevertData("not allowed"))}).Once()
+	provider.On("core_call").ReturnError(-32000, "execution reverted: no data").Once()
+	provider.On("core_call").Return(hexutil.Encode(testRevertData("as output"))).Once()
+	provider.On("core_call").ReturnError(-32000, "out of gas").Once()
+
+	var connection = bif.NewBif(provider)
+	contract, err := connection.Core.NewContract(testContractABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &core.CallOpts{Transaction: &dto.TransactionParameters{ChainId: 333, Recipient: resources.Addr2}}
+
+	for _, reason := range []string{"not allowed", "no data", "as output"} {
+		_, err := contract.CallValues(opts, "balanceOf", sender)
+
+		var revert *core.RevertError
+		if !errors.As(err, &revert) {
+			t.Fatalf("expected a RevertError, got %v", err)
+		}
+		if revert.Reason != reason {
+			t.Errorf("reason %q, want %q", revert.Reason, reason)
+		}
+		if !errors.Is(err, providers.ErrExecutionReverted) {
+			t.Errorf("%v doesn't match ErrExecutionReverted", err)
+		}
+	}
+
+	// the other errors are returned as they are
+	_, err = contract.CallValues(opts, "balanceOf", sender)
+	var revert *core.RevertError
+	if err == nil || errors.As(err, &revert) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if _, err := contract.CallValues(opts, "missing"); err == nil {
+		t.Error("expected an error for a missing method")
+	}
+	if _, err := contract.CallValues(nil, "balanceOf", sender); err == nil {
+		t.Error("expected an error without a transaction")
+	}
+}
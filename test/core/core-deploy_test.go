@@ -0,0 +1,157 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+)
+
+// testSM2Sender is the address of the SM2 key Addr1Pri
+const testSM2Sender = "did:bid:qwer:zfCW1znFTcccsx53SxT3USMKyTXDpiYK"
+
+func TestCorePredictContractAddress(t *testing.T) {
+
+	// the addresses of CREATE are the last 22 bytes of keccak256(rlp([sender, nonce])),
+	// the ones of CREATE2 of keccak256(0xff ++ sender ++ salt ++ keccak256(initCode)),
+	// with the prefix of a secp256k1 address for both key types of the sender
+	for _, vector := range []struct {
+		sender  string
+		nonce   uint64
+		address string
+	}{
+		{resources.Addr1, 0, "did:bid:qwer:sfkLTCV8RWLPomXMj8RCsGwdmPHxsaX7"},
+		{resources.Addr1, 1, "did:bid:qwer:sf3UfzFHXKPBHmv9pz84yh5BHh9hMsrB"},
+		{resources.Addr1, 300, "did:bid:qwer:sfeRvr2PQ1KzLXaJhuF248QoEjURMtBi"},
+		{testSM2Sender, 0, "did:bid:qwer:sfDW4oiZ1vdr6QYZKx8LGJDdDRXBMUn2"},
+		{testSM2Sender, 1, "did:bid:qwer:sf92GL5StyqXfAydVDuEJKKUDUbUpUbL"},
+		{testSM2Sender, 300, "did:bid:qwer:sf24Hy35hNL8MSFcqyrHXeNzQcf3FjbBe"},
+	} {
+		if address, err := core.PredictContractAddress(vector.sender, vector.nonce, "qwer"); err != nil || address != vector.address {
+			t.Errorf("contract of %s with nonce %d: expected %s, got %s %v", vector.sender, vector.nonce, vector.address, address, err)
+		}
+	}
+	var vectorSalt [32]byte
+	vectorSalt[31] = 1
+	for sender, expected := range map[string]string{
+		resources.Addr1: "did:bid:qwer:sf274dDtNbqAxjwjguYdPtwtuUNi6Ancu",
+		testSM2Sender:   "did:bid:qwer:sffq4xuknkgLwaVAsUC3mmhnW5aUcXrR",
+	} {
+		if address, err := core.PredictContractAddress2(sender, vectorSalt, utils.Hex2Bytes("6080604052"), "qwer"); err != nil || address != expected {
+			t.Errorf("CREATE2 contract of %s: expected %s, got %s %v", sender, expected, address, err)
+		}
+	}
+
+	first, err := core.PredictContractAddress(resources.Addr1, 0, "qwer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, "did:bid:qwer:") || utils.StringToAddress(first) == (utils.Address{}) {
+		t.Errorf("invalid address %s", first)
+	}
+	again, _ := core.PredictContractAddress(resources.Addr1, 0, "qwer")
+	second, _ := core.PredictContractAddress(resources.Addr1, 1, "qwer")
+	other, _ := core.PredictContractAddress(resources.Addr2, 0, "qwer")
+	if first != again || first == second || first == other {
+		t.Errorf("the address isn't derived from the sender and the nonce: %s %s %s %s", first, again, second, other)
+	}
+	if _, err := core.PredictContractAddress("did:bid:invalid", 0, ""); err == nil {
+		t.Error("expected an error for an invalid sender")
+	}
+
+	var salt [32]byte
+	initCode := utils.Hex2Bytes("6080604052")
+	create2, err := core.PredictContractAddress2(resources.Addr1, salt, initCode, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt[31] = 1
+	salted, _ := core.PredictContractAddress2(resources.Addr1, salt, initCode, "")
+	if create2 == salted || utils.StringToAddress(create2) == (utils.Address{}) {
+		t.Errorf("the address isn't derived from the salt: %s %s", create2, salted)
+	}
+}
+
+func TestCoreContractDeployAndWait(t *testing.T) {
+
+	address, _ := core.PredictContractAddress(resources.Addr1, 1, "")
+	sender := utils.StringToAddress(resources.Addr1)
+
+	deployed := testReceipt(testBlockHashA, "0x10")
+	deployed["contractAddress"] = address
+
+	provider := mock.NewProvider()
+	provider.On("core_sendRawTransaction").Return(testTxHash).Once()
+	provider.On("core_getTransactionReceipt").Return(deployed).Once()
+	provider.On("core_getBlockByNumber", "0x10", false).Return(map[string]interface{}{"number": "0x10", "hash": testBlockHashA, "timestamp": "0x0"})
+	provider.On("core_blockNumber").Return("0x10")
+	provider.On("core_chainId").Return("0x14d").Once()
+	provider.On("core_call").Return(hexutil.Encode(utils.LeftPadBytes(big.NewInt(1000).Bytes(), 32))).Once()
+
+	var connection = bif.NewBif(provider)
+	contract, err := connection.Core.NewContract(testContractABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &account.SignTxParams{ChainId: 333, Nonce: big.NewInt(1), GasPrice: big.NewInt(1), GasLimit: 600000, Sender: &sender, Amount: new(big.Int)}
+	opts := &core.WaitOptions{PollInterval: 10 * time.Millisecond}
+	contractAddress, receipt, bound, err := contract.DeployAndWait(context.Background(), tx, false, resources.Addr1Pri, "6080604052", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contractAddress != address || receipt.TransactionHash != testTxHash || bound.Address() != address {
+		t.Errorf("unexpected deployment %s %+v %s", contractAddress, receipt, bound.Address())
+	}
+	if contract.Address() != "" {
+		t.Error("the deploying contract is bound")
+	}
+
+	// the bound contract is the recipient of its calls
+	values, err := bound.CallValues(nil, "balanceOf", sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0].(*big.Int).Int64() != 1000 {
+		t.Errorf("values %v", values)
+	}
+	calls := provider.Calls()
+	if last := calls[len(calls)-1]; last.Method != "core_call" || !strings.Contains(string(last.Params), address) {
+		t.Errorf("unexpected call %s %s", last.Method, last.Params)
+	}
+
+	// a failed deployment returns its receipt
+	failed := testReceipt(testBlockHashA, "0x10")
+	failed["status"] = "0x0"
+	provider.On("core_sendRawTransaction").Return(testTxHash).Once()
+	provider.On("core_getTransactionReceipt").Return(failed).Once()
+
+	_, receipt, bound, err = contract.DeployAndWait(context.Background(), tx, false, resources.Addr1Pri, "6080604052", opts)
+	if !errors.Is(err, core.ErrDeployFailed) || receipt == nil || bound != nil {
+		t.Errorf("expected ErrDeployFailed with the receipt, got %v %+v", err, receipt)
+	}
+}
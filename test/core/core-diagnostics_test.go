@@ -0,0 +1,148 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+)
+
+const testErrorsABI = `[
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"}
+]`
+
+// testFailedTransaction mocks the failed transaction testTxHash of block 0x10
+// which used gasUsed of gasLimit
+func testFailedTransaction(provider *mock.Provider, gasUsed string, gasLimit string) {
+	receipt := testReceipt(testBlockHashA, "0x10")
+	receipt["status"] = "0x0"
+	receipt["gasUsed"] = gasUsed
+
+	transaction := testPendingTransaction()
+	transaction["gas"] = gasLimit
+	transaction["sender"] = resources.Addr1
+	transaction["recipient"] = resources.Addr2
+	transaction["payload"] = "0xa9059cbb"
+
+	provider.On("core_getTransactionReceipt", testTxHash).Return(receipt).Once()
+	provider.On("core_getTransactionByHash", testTxHash).Return(transaction).Once()
+}
+
+func TestCoreDiagnoseTransaction(t *testing.T) {
+
+	provider := mock.NewProvider()
+	var connection = bif.NewBif(provider)
+
+	// a revert with a reason, replayed on the parent block
+	testFailedTransaction(provider, "0x6000", "0x10000")
+	provider.On("core_call").Fail(&providers.RPCError{Code: 3, Message: "execution reverted: not allowed", Data: hexutil.Encode(testRevertData("not allowed"))}).Once()
+
+	failure, err := connection.Core.DiagnoseTransaction(testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure.Kind != core.FailureRevert || failure.Reason != "not allowed" || failure.GasUsed != 0x6000 || failure.GasLimit != 0x10000 {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+	if !errors.Is(failure, providers.ErrExecutionReverted) {
+		t.Errorf("%v doesn't match ErrExecutionReverted", failure)
+	}
+	calls := provider.Calls()
+	var params []json.RawMessage
+	if err := json.Unmarshal(calls[len(calls)-1].Params, &params); err != nil {
+		t.Fatal(err)
+	}
+	if string(params[1]) != `"0xf"` {
+		t.Errorf("replayed on block %s", params[1])
+	}
+
+	// a panic
+	testFailedTransaction(provider, "0x6000", "0x10000")
+	panicData := append([]byte{0x4e, 0x48, 0x7b, 0x71}, utils.LeftPadBytes([]byte{0x11}, 32)...)
+	provider.On("core_call").Fail(&providers.RPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(panicData)}).Once()
+
+	failure, err = connection.Core.DiagnoseTransaction(testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure.Kind != core.FailurePanic || failure.PanicCode.Int64() != 0x11 || failure.Reason != "arithmetic underflow or overflow" {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+
+	// a custom error of the ABI of the contract
+	contract, err := connection.Core.NewContract(testErrorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFailedTransaction(provider, "0x6000", "0x10000")
+	customData := append([]byte{}, utils.Hex2Bytes("cf479181")...)
+	customData = append(customData, utils.LeftPadBytes(big.NewInt(5).Bytes(), 32)...)
+	customData = append(customData, utils.LeftPadBytes(big.NewInt(10).Bytes(), 32)...)
+	provider.On("core_call").Fail(&providers.RPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(customData)}).Times(2)
+
+	failure, err = contract.DiagnoseTransaction(testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure.Kind != core.FailureCustomError || failure.ErrorName != "InsufficientBalance" || len(failure.ErrorArgs) != 2 || failure.ErrorArgs[1].(*big.Int).Int64() != 10 {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+	if failure.Error() != "transaction "+testTxHash+" failed: execution reverted: InsufficientBalance(5, 10)" {
+		t.Errorf("unexpected message %s", failure.Error())
+	}
+
+	// without the ABI the custom error is a revert with its data
+	testFailedTransaction(provider, "0x6000", "0x10000")
+	failure, err = connection.Core.DiagnoseTransaction(testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure.Kind != core.FailureRevert || failure.Reason != "" || len(failure.Data) != len(customData) {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+
+	// out of gas
+	testFailedTransaction(provider, "0x10000", "0x10000")
+	provider.On("core_call").ReturnError(-32000, "out of gas").Once()
+
+	failure, err = connection.Core.DiagnoseTransaction(testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure.Kind != core.FailureOutOfGas || errors.Is(failure, providers.ErrExecutionReverted) {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+
+	// a successful transaction has nothing to explain
+	provider.On("core_getTransactionReceipt", testTxHash).Return(testReceipt(testBlockHashA, "0x10")).Once()
+	if _, err := connection.Core.DiagnoseTransaction(testTxHash); err != core.ErrTransactionSucceeded {
+		t.Errorf("expected ErrTransactionSucceeded, got %v", err)
+	}
+
+	if pending := provider.Pending(); len(pending) != 0 {
+		t.Errorf("pending expectations %v", pending)
+	}
+}
@@ -0,0 +1,263 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+
+	"github.com/gorilla/websocket"
+)
+
+const testEventsABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":false,"name":"memo","type":"string"}],"name":"Memo","type":"event"}
+]`
+
+func TestCoreContractFilterEvents(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	from := utils.BytesToHash(sender.Bytes()).Hex()
+	to := utils.BytesToHash(recipient.Bytes()).Hex()
+	value := hexutil.Encode(utils.LeftPadBytes(big.NewInt(5).Bytes(), 32))
+
+	provider := mock.NewProvider()
+	// the did:bid and utils.Address arguments give the same topics
+	provider.On("core_getLogs", map[string]interface{}{
+		"fromBlock": "0x64",
+		"toBlock":   "latest",
+		"address":   resources.Addr2,
+		"topics":    []interface{}{transfer, nil, []interface{}{from, to}},
+	}).Return(json.RawMessage(`[{
+		"address": "` + resources.Addr2 + `",
+		"topics": ["` + transfer + `", "` + from + `", "` + to + `"],
+		"data": "` + value + `",
+		"blockNumber": "0x65",
+		"transactionHash": "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+		"transactionIndex": "0x0",
+		"blockHash": "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+		"logIndex": "0x0",
+		"removed": false
+	}]`)).Once()
+
+	var connection = bif.NewBif(provider)
+	contract, err := connection.Core.NewContract(testEventsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := dto.FilterQuery{FromBlock: "0x64", ToBlock: "latest", Addresses: []string{resources.Addr2}}
+	events, err := contract.FilterEvents("Transfer", query, nil, []interface{}{resources.Addr1, recipient})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.Name != "Transfer" || event.Log.BlockNumber.Int64() != 0x65 {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if address, ok := event.Args["from"].(utils.Address); !ok || address.String("qwer") != resources.Addr1 {
+		t.Errorf("from %v", event.Args["from"])
+	}
+	if address, ok := event.Args["to"].(utils.Address); !ok || address != recipient {
+		t.Errorf("to %v", event.Args["to"])
+	}
+	if amount, ok := event.Args["value"].(*big.Int); !ok || amount.Int64() != 5 {
+		t.Errorf("value %v", event.Args["value"])
+	}
+
+	// the event of a log is found by its first topic
+	unpacked, err := contract.UnpackEvent(event.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpacked.Name != "Transfer" || unpacked.Args["to"] != recipient {
+		t.Errorf("unexpected event %+v", unpacked)
+	}
+	event.Log.Topics[0] = to
+	if _, err := contract.UnpackEvent(event.Log); err == nil {
+		t.Error("expected an error for a log of an unknown event")
+	}
+
+	if _, err := contract.FilterEvents("Transfer", query, []interface{}{"did:bid:invalid"}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+	if _, err := contract.FilterEvents("Memo", query, nil, nil); err == nil {
+		t.Error("expected an error for too many indexed arguments")
+	}
+	if _, err := contract.FilterEvents("Missing", query); err == nil {
+		t.Error("expected an error for a missing event")
+	}
+
+	// the subscriptions need a provider which receives notifications
+	if _, err := contract.WatchEvents(context.Background(), "Transfer", query, make(chan *core.Event)); err != providers.ErrNotificationsUnsupported {
+		t.Errorf("expected ErrNotificationsUnsupported, got %v", err)
+	}
+
+	if pending := provider.Pending(); len(pending) != 0 {
+		t.Errorf("pending expectations %v", pending)
+	}
+}
+
+func TestCoreContractWatchEvents(t *testing.T) {
+
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	transfer := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	from := utils.BytesToHash(sender.Bytes()).Hex()
+	to := utils.BytesToHash(recipient.Bytes()).Hex()
+	value := hexutil.Encode(utils.LeftPadBytes(big.NewInt(5).Bytes(), 32))
+	notification := func(subscription string, data string) map[string]interface{} {
+		return map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "core_subscription",
+			"params": map[string]interface{}{
+				"subscription": subscription,
+				"result": map[string]interface{}{
+					"address":          resources.Addr2,
+					"topics":           []string{transfer, from, to},
+					"data":             data,
+					"blockNumber":      "0x65",
+					"transactionHash":  "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+					"transactionIndex": "0x0",
+					"blockHash":        "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+					"logIndex":         "0x0",
+					"removed":          false,
+				},
+			},
+		}
+	}
+
+	// the node passes the filter of the subscription to the test, then streams
+	// a null notification, a malformed log and a transfer
+	upgrader := websocket.Upgrader{}
+	received := make(chan []interface{}, 1)
+	drop := make(chan struct{})
+	var dropOnce sync.Once
+	dropConnection := func() { dropOnce.Do(func() { close(drop) }) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		var request map[string]interface{}
+		if err := conn.ReadJSON(&request); err != nil || request["method"] != "core_subscribe" {
+			t.Errorf("unexpected request %v %v", request, err)
+			return
+		}
+		received <- request["params"].([]interface{})
+
+		subscription := "0x9ce59a13059e417087c02d3236a0b1cc"
+		conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": subscription})
+		conn.WriteJSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "core_subscription",
+			"params":  map[string]interface{}{"subscription": subscription, "result": nil},
+		})
+		conn.WriteJSON(notification(subscription, "0x05"))
+		conn.WriteJSON(notification(subscription, value))
+
+		<-drop
+	}))
+	defer server.Close()
+	defer dropConnection()
+
+	provider, err := providers.NewWebSocketProviderWithOptions("ws"+strings.TrimPrefix(server.URL, "http"), providers.WithoutReconnect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var connection = bif.NewBif(provider)
+	defer connection.Provider.Close()
+
+	contract, err := connection.Core.NewContract(testEventsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract = contract.At(resources.Addr2)
+
+	events := make(chan *core.Event)
+	sub, err := contract.WatchEvents(context.Background(), "Transfer", dto.FilterQuery{}, events, []interface{}{resources.Addr1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := <-received
+	filter, _ := params[1].(map[string]interface{})
+	if params[0] != "logs" || filter["address"] != resources.Addr2 {
+		t.Errorf("unexpected subscription %v", params)
+	}
+	if topics, _ := filter["topics"].([]interface{}); len(topics) != 2 || topics[0] != transfer || topics[1] != from {
+		t.Errorf("unexpected topics %v", filter["topics"])
+	}
+
+	select {
+	case event := <-events:
+		if event.Name != "Transfer" || event.Log.BlockNumber.Int64() != 0x65 {
+			t.Errorf("unexpected event %+v", event)
+		}
+		if address, ok := event.Args["from"].(utils.Address); !ok || address.String("qwer") != resources.Addr1 {
+			t.Errorf("from %v", event.Args["from"])
+		}
+		if address, ok := event.Args["to"].(utils.Address); !ok || address.String("qwer") != resources.Addr2 {
+			t.Errorf("to %v", event.Args["to"])
+		}
+		if amount, ok := event.Args["value"].(*big.Int); !ok || amount.Int64() != 5 {
+			t.Errorf("value %v", event.Args["value"])
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	// the dropped connection ends the watch, its error is left to the caller
+	dropConnection()
+	select {
+	case err := <-sub.Err():
+		if err == nil {
+			t.Error("expected the error of the dropped connection")
+		}
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(5 * time.Second):
+		t.Error("the subscription didn't end")
+	}
+}
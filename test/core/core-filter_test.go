@@ -0,0 +1,133 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+const testLogs = `[{
+	"address": "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck",
+	"topics": ["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],
+	"data": "0x0000000000000000000000000000000000000000000000000000000000000001",
+	"blockNumber": "0x1b4",
+	"transactionHash": "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+	"transactionIndex": "0x1",
+	"blockHash": "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+	"logIndex": "0x2",
+	"removed": false
+}, {
+	"address": "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck",
+	"topics": [],
+	"data": "0x",
+	"blockNumber": null,
+	"transactionHash": "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+	"transactionIndex": null,
+	"blockHash": null,
+	"logIndex": null,
+	"removed": false
+}]`
+
+func TestCoreGetLogs(t *testing.T) {
+
+	query := dto.FilterQuery{
+		FromBlock: block.NUMBER(big.NewInt(400)),
+		ToBlock:   block.LATEST,
+		Addresses: []string{"did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck"},
+		Topics:    [][]string{{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"}},
+	}
+
+	provider := mock.NewProvider()
+	provider.On("core_getLogs", map[string]interface{}{
+		"fromBlock": "0x190",
+		"toBlock":   "latest",
+		"address":   "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck",
+		"topics":    []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+	}).Return(json.RawMessage(testLogs))
+
+	var connection = bif.NewBif(provider)
+
+	logs, err := connection.Core.GetLogs(query)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(logs))
+	}
+	if logs[0].BlockNumber.Int64() != 436 || logs[0].TransactionIndex.Int64() != 1 || logs[0].LogIndex.Int64() != 2 {
+		t.Errorf("unexpected log %+v", logs[0])
+	}
+	if logs[1].BlockNumber != nil || logs[1].LogIndex != nil {
+		t.Errorf("unexpected pending log %+v", logs[1])
+	}
+
+	_, err = connection.Core.GetLogs(dto.FilterQuery{BlockHash: "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae", FromBlock: block.LATEST})
+	if err == nil {
+		t.Error("expected an error for BlockHash with FromBlock")
+	}
+}
+
+func TestCoreFilters(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_newFilter", map[string]interface{}{"blockHash": "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"}).Return("0x1")
+	provider.On("core_newBlockFilter").Return("0x2")
+	provider.On("core_getFilterChanges", "0x1").Return(json.RawMessage(testLogs))
+	provider.On("core_getFilterChanges", "0x2").Return([]string{"0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"}).Once()
+	provider.On("core_getFilterChanges", "0x2").Return([]string{})
+	provider.On("core_uninstallFilter", "0x1").Return(true)
+
+	var connection = bif.NewBif(provider)
+
+	logFilter, err := connection.Core.NewFilter(dto.FilterQuery{BlockHash: "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"})
+	if err != nil || logFilter != "0x1" {
+		t.Fatalf("unexpected filter %s %v", logFilter, err)
+	}
+
+	blockFilter, err := connection.Core.NewBlockFilter()
+	if err != nil || blockFilter != "0x2" {
+		t.Fatalf("unexpected filter %s %v", blockFilter, err)
+	}
+
+	changes, err := connection.Core.GetFilterChanges(logFilter)
+	if err != nil || len(changes.Logs) != 2 || len(changes.Hashes) != 0 {
+		t.Errorf("unexpected changes %+v %v", changes, err)
+	}
+
+	changes, err = connection.Core.GetFilterChanges(blockFilter)
+	if err != nil || len(changes.Hashes) != 1 || len(changes.Logs) != 0 {
+		t.Errorf("unexpected changes %+v %v", changes, err)
+	}
+
+	changes, err = connection.Core.GetFilterChanges(blockFilter)
+	if err != nil || len(changes.Hashes) != 0 || len(changes.Logs) != 0 {
+		t.Errorf("unexpected changes %+v %v", changes, err)
+	}
+
+	uninstalled, err := connection.Core.UninstallFilter(logFilter)
+	if err != nil || !uninstalled {
+		t.Errorf("unexpected uninstall %v %v", uninstalled, err)
+	}
+}
@@ -0,0 +1,217 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+// testChainHash is the hash of the block number on the fork
+func testChainHash(fork string, number uint64) string {
+	return fmt.Sprintf("0x%s%062x", fork, number)
+}
+
+// testChainBlock is the block number of the fork, its parent is on the parentFork
+func testChainBlock(fork, parentFork string, number uint64, transactions ...string) map[string]interface{} {
+	var txs []interface{}
+	for _, hash := range transactions {
+		txs = append(txs, map[string]interface{}{
+			"hash":        hash,
+			"chainId":     "0x14d",
+			"blockNumber": fmt.Sprintf("0x%x", number),
+			"nonce":       "0x0",
+			"gas":         "0x5208",
+			"gasPrice":    "0x1",
+			"amount":      "0x0",
+		})
+	}
+	return map[string]interface{}{
+		"number":       fmt.Sprintf("0x%x", number),
+		"hash":         testChainHash(fork, number),
+		"parentHash":   testChainHash(parentFork, number-1),
+		"timestamp":    "0x0",
+		"size":         "0x0",
+		"transactions": txs,
+	}
+}
+
+func TestCoreChainFollower(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x9")
+	for number := uint64(1); number <= 6; number++ {
+		provider.On("core_getBlockByNumber", fmt.Sprintf("0x%x", number), true).Return(testChainBlock("aa", "aa", number, testTxHash))
+	}
+	provider.On("core_getTransactionReceipt", testTxHash).Return(testReceipt(testBlockHashA, "0x1"))
+
+	var connection = bif.NewBif(provider)
+	store := core.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	// the head is 9, with 3 confirmations the follower stops at 6
+	follower := connection.Core.NewChainFollower(&core.FollowerOptions{
+		From:          1,
+		To:            big.NewInt(100),
+		Concurrency:   2,
+		Confirmations: 3,
+		Receipts:      true,
+		Store:         store,
+	})
+
+	var numbers []uint64
+	ctx, cancel := context.WithCancel(context.Background())
+	err := follower.Run(ctx, func(ctx context.Context, event *core.FollowerEvent) error {
+		if event.Type != core.FollowerBlock || len(event.Receipts) != 1 || event.Receipts[0].TransactionHash != testTxHash {
+			t.Errorf("unexpected event %+v", event)
+		}
+		numbers = append(numbers, event.Block.Number.Uint64())
+		if event.Block.Number.Uint64() == 4 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if fmt.Sprint(numbers) != "[1 2 3 4]" {
+		t.Fatalf("unexpected blocks %v", numbers)
+	}
+
+	// a new run resumes after the checkpoint and stops at To
+	checkpoint, err := store.Load(context.Background())
+	if err != nil || checkpoint.Number != 4 || checkpoint.Hash != testChainHash("aa", 4) {
+		t.Fatalf("unexpected checkpoint %+v %v", checkpoint, err)
+	}
+
+	numbers = nil
+	follower = connection.Core.NewChainFollower(&core.FollowerOptions{To: big.NewInt(6), Store: store})
+	err = follower.Run(context.Background(), func(ctx context.Context, event *core.FollowerEvent) error {
+		numbers = append(numbers, event.Block.Number.Uint64())
+		return nil
+	})
+	if err != nil || fmt.Sprint(numbers) != "[5 6]" {
+		t.Fatalf("unexpected blocks %v %v", numbers, err)
+	}
+}
+
+// batchingProvider answers the batches with the expectations of the mock
+// provider and records their methods
+type batchingProvider struct {
+	*mock.Provider
+	batches [][]string
+}
+
+func (provider *batchingProvider) BatchSendRequest(elems []providers.BatchElem) error {
+	return provider.BatchSendRequestContext(context.Background(), elems)
+}
+
+func (provider *batchingProvider) BatchSendRequestContext(ctx context.Context, elems []providers.BatchElem) error {
+	var methods []string
+	for _, elem := range elems {
+		methods = append(methods, elem.Method)
+	}
+	provider.batches = append(provider.batches, methods)
+	return providers.BatchSendRequestContext(ctx, provider.Provider, elems)
+}
+
+func TestCoreChainFollower_Receipts(t *testing.T) {
+
+	otherTxHash := "0x" + strings.Repeat("12", 32)
+	provider := &batchingProvider{Provider: mock.NewProvider()}
+	provider.On("core_blockNumber").Return("0x2")
+	provider.On("core_getBlockByNumber", "0x1", true).Return(testChainBlock("aa", "aa", 1, testTxHash, otherTxHash))
+	provider.On("core_getBlockByNumber", "0x2", true).Return(testChainBlock("aa", "aa", 2))
+	provider.On("core_getTransactionReceipt", testTxHash).Return(testReceipt(testBlockHashA, "0x1"))
+	provider.On("core_getTransactionReceipt", otherTxHash).Return(nil)
+
+	var connection = bif.NewBif(provider)
+
+	// the receipts of a block are fetched with one batch, a missing receipt
+	// fails the run
+	follower := connection.Core.NewChainFollower(&core.FollowerOptions{From: 1, To: big.NewInt(2), Receipts: true})
+	err := follower.Run(context.Background(), func(ctx context.Context, event *core.FollowerEvent) error {
+		t.Errorf("unexpected event %+v", event)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), otherTxHash) {
+		t.Errorf("expected the error of the missing receipt, got %v", err)
+	}
+	if fmt.Sprint(provider.batches) != "[[core_getTransactionReceipt core_getTransactionReceipt]]" {
+		t.Errorf("unexpected batches %v", provider.batches)
+	}
+
+	// a block without transactions doesn't send a batch
+	provider.batches = nil
+	follower = connection.Core.NewChainFollower(&core.FollowerOptions{From: 2, To: big.NewInt(2), Receipts: true})
+	err = follower.Run(context.Background(), func(ctx context.Context, event *core.FollowerEvent) error {
+		if event.Block.Number.Uint64() != 2 || event.Receipts == nil || len(event.Receipts) != 0 {
+			t.Errorf("unexpected event %+v", event)
+		}
+		return nil
+	})
+	if err != nil || len(provider.batches) != 0 {
+		t.Errorf("unexpected run %v %v", provider.batches, err)
+	}
+}
+
+func TestCoreChainFollower_Rollback(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x3").Once()
+	provider.On("core_blockNumber").Return("0x5")
+	for number := uint64(1); number <= 3; number++ {
+		provider.On("core_getBlockByNumber", fmt.Sprintf("0x%x", number), true).Return(testChainBlock("aa", "aa", number)).Once()
+	}
+	// block 3 was replaced, the fork starts after block 2
+	provider.On("core_getBlockByNumber", "0x3", true).Return(testChainBlock("bb", "aa", 3))
+	provider.On("core_getBlockByNumber", "0x4", true).Return(testChainBlock("bb", "bb", 4))
+	provider.On("core_getBlockByNumber", "0x5", true).Return(testChainBlock("bb", "bb", 5))
+	provider.On("core_getBlockByNumber", "0x3", false).Return(testChainBlock("bb", "aa", 3))
+	provider.On("core_getBlockByNumber", "0x2", false).Return(testChainBlock("aa", "aa", 2))
+
+	var connection = bif.NewBif(provider)
+	follower := connection.Core.NewChainFollower(&core.FollowerOptions{From: 1, To: big.NewInt(5)})
+
+	var events []string
+	err := follower.Run(context.Background(), func(ctx context.Context, event *core.FollowerEvent) error {
+		switch event.Type {
+		case core.FollowerBlock:
+			events = append(events, fmt.Sprintf("block %d", event.Block.Number))
+		case core.FollowerRollback:
+			if len(event.Removed) != 1 || event.Removed[0].Hash != testChainHash("aa", 3) || event.Checkpoint.Hash != testChainHash("aa", 2) {
+				t.Errorf("unexpected rollback %+v", event)
+			}
+			events = append(events, fmt.Sprintf("rollback %d", event.Checkpoint.Number))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[block 1 block 2 block 3 rollback 2 block 3 block 4 block 5]"
+	if fmt.Sprint(events) != expected {
+		t.Errorf("expected %s, got %v", expected, events)
+	}
+}
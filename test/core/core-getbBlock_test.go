@@ -15,42 +15,51 @@
 package test
 
 import (
+	"encoding/json"
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/account"
 	block2 "github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
 	"math/big"
-	"strconv"
+	"strings"
 	"testing"
-	"time"
 )
 
 func TestCoreBlockNumber(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x1b4")
+
+	var connection = bif.NewBif(provider)
 
 	blockNumber, err := connection.Core.GetBlockNumber()
 
 	if err != nil {
 		t.Error(err)
-		t.Fail()
+		t.FailNow()
 	}
 
-	if blockNumber.Int64() < 0 {
-		t.Errorf("Invalid Block Number")
-		t.Fail()
+	if blockNumber.Int64() != 436 {
+		t.Errorf("Invalid Block Number %v", blockNumber)
 	}
-
-	t.Log(blockNumber)
 }
 
 func TestCoreGetBlockByHash(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	hash := "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+	unknownHash := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	provider := mock.NewProvider()
+	provider.On("core_getBlockByNumber", block2.LATEST, false).Return(testBlock(t, []string{testTxHash}))
+	provider.On("core_getBlockByHash", hash, false).Return(testBlock(t, []string{testTxHash}))
+	provider.On("core_getBlockByHash", unknownHash, false).Return(nil)
+
+	var connection = bif.NewBif(provider)
 
 	const transactionDetails = false
 	blockByNumber, err := connection.Core.GetBlockByNumber(block2.LATEST, transactionDetails)
@@ -74,8 +83,6 @@ func TestCoreGetBlockByHash(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(blockByHash.(*dto.BlockNoDetails).Hash, blockByNumber.(*dto.BlockNoDetails).Hash)
-
 	_, err = connection.Core.GetBlockByHash("0x1234", transactionDetails)
 
 	if err == nil {
@@ -97,7 +104,7 @@ func TestCoreGetBlockByHash(t *testing.T) {
 		t.FailNow()
 	}
 
-	blockByHash, err = connection.Core.GetBlockByHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", false)
+	_, err = connection.Core.GetBlockByHash(unknownHash, false)
 
 	if err == nil {
 		t.Errorf("Found a block with incorrect hash?")
@@ -106,7 +113,13 @@ func TestCoreGetBlockByHash(t *testing.T) {
 }
 
 func TestCoreGetBlockByNumber(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+
+	provider := mock.NewProvider()
+	provider.On("core_blockNumber").Return("0x1b4")
+	provider.On("core_getBlockByNumber", block2.LATEST, false).Return(testBlock(t, []string{testTxHash}))
+	provider.On("core_getBlockByNumber", block2.LATEST, true).Return(testBlock(t, []interface{}{testPendingTransaction()}))
+
+	var connection = bif.NewBif(provider)
 	for _, test := range []struct {
 		transactionDetails bool
 	}{
@@ -131,38 +144,14 @@ func TestCoreGetBlockByNumber(t *testing.T) {
 		}
 
 		if test.transactionDetails {
-			t.Log("this is detail ")
-			t.Log("Number:", block.(*dto.BlockDetails).Number)
-			t.Log("Hash:", block.(*dto.BlockDetails).Hash)
-			t.Log("ParentHash:", block.(*dto.BlockDetails).ParentHash)
-			t.Log("LogsBloom:", block.(*dto.BlockDetails).LogsBloom)
-			t.Log("StateRoot:", block.(*dto.BlockDetails).StateRoot)
-			t.Log("Generator:", block.(*dto.BlockDetails).Generator)
-			t.Log("Regulatory:", block.(*dto.BlockDetails).Regulatory)
-			t.Log("ExtraData:", block.(*dto.BlockDetails).ExtraData)
-			t.Log("Size:", block.(*dto.BlockDetails).Size)
-			t.Log("Timestamp:", block.(*dto.BlockDetails).Timestamp)
-			t.Log("TransactionsRoot:", block.(*dto.BlockDetails).TransactionsRoot)
-			t.Log("ReceiptsRoot:", block.(*dto.BlockDetails).ReceiptsRoot)
-			for _, val := range block.(*dto.BlockDetails).Transactions {
-				t.Logf("transactions: %#v \n", val)
+			details := block.(*dto.BlockDetails)
+			if details.Number.Int64() != 436 || details.Generator != resources.Addr1 || len(details.Transactions) != 1 || details.Transactions[0].Hash != testTxHash {
+				t.Errorf("unexpected block %+v", details)
 			}
 		} else {
-			t.Log("this is no detail ")
-			t.Log("Number:", block.(*dto.BlockNoDetails).Number)
-			t.Log("Hash:", block.(*dto.BlockNoDetails).Hash)
-			t.Log("ParentHash:", block.(*dto.BlockNoDetails).ParentHash)
-			t.Log("LogsBloom:", block.(*dto.BlockNoDetails).LogsBloom)
-			t.Log("StateRoot:", block.(*dto.BlockNoDetails).StateRoot)
-			t.Log("Generator:", block.(*dto.BlockNoDetails).Generator)
-			t.Log("Regulatory:", block.(*dto.BlockNoDetails).Regulatory)
-			t.Log("ExtraData:", block.(*dto.BlockNoDetails).ExtraData)
-			t.Log("Size:", block.(*dto.BlockNoDetails).Size)
-			t.Log("Timestamp:", block.(*dto.BlockNoDetails).Timestamp)
-			t.Log("TransactionsRoot:", block.(*dto.BlockNoDetails).TransactionsRoot)
-			t.Log("ReceiptsRoot:", block.(*dto.BlockNoDetails).ReceiptsRoot)
-			for idx, val := range block.(*dto.BlockNoDetails).Transactions {
-				t.Log("transactions:[0]", val[idx])
+			noDetails := block.(*dto.BlockNoDetails)
+			if noDetails.Number.Int64() != 436 || noDetails.Generator != resources.Addr1 || len(noDetails.Transactions) != 1 || noDetails.Transactions[0] != testTxHash {
+				t.Errorf("unexpected block %+v", noDetails)
 			}
 		}
 	}
@@ -171,21 +160,10 @@ func TestCoreGetBlockByNumber(t *testing.T) {
 
 func TestGetBlockTransactionCountByHash(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := testTransferProvider(resources.Addr1, resources.Addr2)
+	provider.On("core_getBlockTransactionCountByHash", testBlockHashA).Return("0x1")
 
-	block, err := connection.Core.GetBlockByNumber(block2.LATEST, false)
-
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	txCount, err := connection.Core.GetBlockTransactionCountByHash(block.(*dto.BlockNoDetails).Hash)
-
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	t.Log("txCount:", txCount)
+	var connection = bif.NewBif(provider)
 
 	chainId, _ := connection.Core.GetChainId()
 
@@ -219,28 +197,36 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Logf("txHash is %s \n", txHah)
-
-	time.Sleep(time.Second * 8)
+	txRes, err := connection.Core.GetTransactionByHash(txHah)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 
-	txCount, err = connection.Core.GetBlockTransactionCountByHash(block.(*dto.BlockNoDetails).Hash)
+	txCount, err := connection.Core.GetBlockTransactionCountByHash(txRes.BlockHash)
 
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	//  注意如果有其他用户发送交易，可能会大于1，该测试可能会失败
 	if txCount != 1 {
 		t.Error("invalid block transaction count")
 		t.FailNow()
 	}
-	t.Log("txCount:", txCount)
+
+	if _, err := connection.Core.GetBlockTransactionCountByHash("0x1234"); err == nil {
+		t.Error("Invalid hash not rejected")
+	}
 }
 
 func TestGetBlockTransactionCountByNumber(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := testTransferProvider(resources.Addr1, resources.Addr2)
+	provider.On("core_getBlockTransactionCountByNumber", "0x10").Return("0x1")
+	provider.On("core_getBlockTransactionCountByNumber", block2.LATEST).Return("0x1")
+
+	var connection = bif.NewBif(provider)
 
 	chainId, _ := connection.Core.GetChainId()
 
@@ -274,11 +260,11 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 		t.FailNow()
 	}
 
-	time.Sleep(time.Second * 8)
-
-	txRes, _ := connection.Core.GetTransactionByHash(txHah)
-
-	t.Logf("txRes blockNumber %+v \n", txRes)
+	txRes, err := connection.Core.GetTransactionByHash(txHah)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 
 	blockNumber := hexutil.EncodeBig(txRes.BlockNumber)
 
@@ -305,4 +291,123 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 		t.Error("invalid block transaction count")
 		t.FailNow()
 	}
-}
\ No newline at end of file
+}
+
+// testBlock returns a block of the mock node, whose extra data holds the
+// Istanbul data of a single validator
+func testBlock(t *testing.T, transactions interface{}) json.RawMessage {
+	validator := utils.StringToAddress(resources.Addr1)
+	extra, err := rlp.EncodeToBytes(&utils.IstanbulExtra{
+		Validators:    []utils.Address{validator},
+		Seal:          make([]byte, 65),
+		CommittedSeal: [][]byte{make([]byte, 65)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"number":           "0x1b4",
+		"hash":             "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+		"parentHash":       "0xe99e022112df268087ea7eafaf4790497fd21dbeeb6bd7a1721df161a6657a54",
+		"extraData":        hexutil.Encode(append(make([]byte, 32), extra...)),
+		"size":             "0x27f",
+		"timestamp":        "0x5f5e100",
+		"generator":        resources.Addr1,
+		"transactions":     transactions,
+		"transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCoreGetTypedBlocks(t *testing.T) {
+
+	hash := "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+	txHash := "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+
+	provider := mock.NewProvider()
+	provider.On("core_getBlockByNumber", block2.LATEST, false).Return(testBlock(t, []string{txHash}))
+	provider.On("core_getBlockByNumber", "0x1b4", false).Return(testBlock(t, []string{txHash}))
+	provider.On("core_getBlockByHash", hash, true).Return(testBlock(t, []map[string]interface{}{{
+		"hash":        txHash,
+		"chainId":     "0x14d",
+		"blockNumber": "0x1b4",
+		"nonce":       "0x1",
+		"gas":         "0x5208",
+		"gasPrice":    "0x1",
+		"amount":      "0x0",
+	}}))
+
+	var connection = bif.NewBif(provider)
+
+	header, err := connection.Core.GetHeaderByNumber(block2.LATEST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Int64() != 436 || header.Timestamp != 100000000 || header.Hash != hash {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if header.Istanbul == nil || len(header.Istanbul.Validators) != 1 || len(header.Istanbul.CommittedSeal) != 1 {
+		t.Fatalf("unexpected istanbul extra %+v", header.Istanbul)
+	}
+	if header.Istanbul.Validators[0] != utils.StringToAddress(resources.Addr1) {
+		t.Errorf("unexpected validator %v", header.Istanbul.Validators[0])
+	}
+
+	withHashes, err := connection.Core.GetBlockWithHashesByNumber("436")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withHashes.Size != 639 || len(withHashes.Transactions) != 1 || withHashes.Transactions[0] != txHash || withHashes.Istanbul == nil {
+		t.Errorf("unexpected block %+v", withHashes)
+	}
+
+	withTransactions, err := connection.Core.GetBlockWithTransactionsByHash(strings.TrimPrefix(hash, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withTransactions.Transactions) != 1 || withTransactions.Transactions[0].Hash != txHash || withTransactions.Number.Int64() != 436 {
+		t.Errorf("unexpected block %+v", withTransactions)
+	}
+
+	if _, err := connection.Core.GetHeaderByNumber("next"); err == nil {
+		t.Error("expected an error for an invalid block number")
+	}
+}
+
+func TestCoreGetBlocksByRange(t *testing.T) {
+
+	provider := &batchingProvider{Provider: mock.NewProvider()}
+	for number := uint64(1); number <= 150; number++ {
+		provider.On("core_getBlockByNumber", hexutil.EncodeUint64(number), true).Return(testChainBlock("aa", "aa", number))
+	}
+
+	var connection = bif.NewBif(provider)
+
+	// the range is requested by batches of 100 blocks
+	blocks, err := connection.Core.GetBlocksByRange(1, 150, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 150 || blocks[149].(*dto.BlockDetails).Number.Uint64() != 150 {
+		t.Errorf("unexpected blocks %d", len(blocks))
+	}
+	if len(provider.batches) != 2 || len(provider.batches[0]) != 100 || len(provider.batches[1]) != 50 {
+		t.Errorf("unexpected batches of %d requests", len(provider.batches))
+	}
+
+	// the ranges too large are rejected without any request
+	provider.batches = nil
+	if _, err := connection.Core.GetBlocksByRange(0, ^uint64(0), true); err == nil {
+		t.Error("expected an error for the whole chain")
+	}
+	if _, err := connection.Core.GetBlocksByRange(1, 10001, true); err == nil {
+		t.Error("expected an error for 10001 blocks")
+	}
+	if len(provider.batches) != 0 {
+		t.Errorf("unexpected batches %v", provider.batches)
+	}
+}
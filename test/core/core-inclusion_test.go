@@ -0,0 +1,210 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk/account"
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/trie"
+)
+
+func TestTrieHash(t *testing.T) {
+	// the root is the one of the go-ethereum trie tests
+	tr := trie.New(config.SECP256K1)
+	if root := tr.Hash(); root != trie.EmptyRoot(config.SECP256K1) {
+		t.Errorf("empty root %s", root.Hex())
+	}
+
+	tr.Update([]byte("doe"), []byte("reindeer"))
+	tr.Update([]byte("dog"), []byte("puppy"))
+	tr.Update([]byte("dogglesworth"), []byte("cat"))
+	if root := tr.Hash(); root != utils.HexToHash("0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3") {
+		t.Errorf("root %s", root.Hex())
+	}
+
+	tr = trie.New(config.SM2)
+	for _, key := range []string{"do", "dog", "doge", "horse"} {
+		tr.Update([]byte(key), []byte("value of "+key))
+	}
+	for _, key := range []string{"do", "dog", "doge", "horse"} {
+		proof, err := tr.Prove([]byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := trie.VerifyProof(tr.Hash(), []byte(key), proof, config.SM2)
+		if err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		if string(value) != "value of "+key {
+			t.Errorf("%s: value %q", key, value)
+		}
+	}
+	if _, err := tr.Prove([]byte("cat")); !errors.Is(err, trie.ErrMissingKey) {
+		t.Errorf("expected ErrMissingKey, got %v", err)
+	}
+}
+
+// testInclusionBlock returns a block of n signed transactions with their receipts
+func testInclusionBlock(t *testing.T, n int) (*dto.BlockWithTransactions, []*dto.TransactionReceipt) {
+	sender := utils.StringToAddress(resources.Addr1)
+	recipient := utils.StringToAddress(resources.Addr2)
+
+	block := &dto.BlockWithTransactions{BlockHeader: dto.BlockHeader{Number: big.NewInt(12), Hash: "0x0c"}}
+	var receipts []*dto.TransactionReceipt
+	for i := 0; i < n; i++ {
+		params := &account.SignTxParams{
+			ChainId:   333,
+			Nonce:     big.NewInt(int64(i)),
+			GasPrice:  big.NewInt(200),
+			GasLimit:  21000,
+			Sender:    &sender,
+			Recipient: &recipient,
+			Amount:    big.NewInt(int64(1000 + i)),
+		}
+		if i%3 == 0 {
+			params.Recipient = nil
+			params.Payload = []byte{0x60, 0x80, 0x60, 0x40, byte(i)}
+		}
+		signed, err := account.SignTransaction(params, resources.Addr1Pri, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tx := dto.TransactionResponse{
+			ChainId:          333,
+			Sender:           sender.String(""),
+			Gas:              21000,
+			GasPrice:         big.NewInt(200),
+			Payload:          hexutil.Encode(params.Payload),
+			Nonce:            uint64(i),
+			TransactionIndex: uint(i),
+			Amount:           params.Amount,
+			SignUser: []*crypto.Signature{{
+				PublicKey:  signed.Tx.SignUser[:33],
+				CryptoType: signed.Tx.SignUser[33:34],
+				Signature:  signed.Tx.SignUser[34:],
+			}},
+		}
+		if params.Recipient != nil {
+			tx.Recipient = recipient.String("")
+		}
+
+		encoded, err := account.EncodeTransaction(&tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, signed.Raw) {
+			t.Fatalf("transaction %d: encoded %x, signed %x", i, encoded, []byte(signed.Raw))
+		}
+		block.Transactions = append(block.Transactions, tx)
+
+		receipt := &dto.TransactionReceipt{
+			TransactionIndex:  big.NewInt(int64(i)),
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			GasUsed:           21000,
+			Status:            i != 4,
+		}
+		if i%2 == 0 {
+			receipt.Logs = []dto.TransactionLogs{{
+				Address: resources.Addr2,
+				Topics:  []string{crypto.Keccak256Hash(config.SECP256K1, []byte("Transfer")).Hex()},
+				Data:    hexutil.EncodeBig(big.NewInt(int64(i))),
+			}}
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	txRoot, err := core.DeriveTransactionsRoot(block.Transactions, config.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiptRoot, err := core.DeriveReceiptsRoot(receipts, config.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.TransactionsRoot, block.ReceiptsRoot = txRoot.Hex(), receiptRoot.Hex()
+	return block, receipts
+}
+
+func TestCoreInclusionProof(t *testing.T) {
+	block, receipts := testInclusionBlock(t, 20)
+
+	if err := core.VerifyBlockRoots(block, receipts, config.SECP256K1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, index := range []uint{0, 1, 7, 19} {
+		proof, err := core.ProveTransaction(block, index, config.SECP256K1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proof.BlockNumber != 12 || proof.Index != index {
+			t.Errorf("unexpected proof %+v", proof)
+		}
+		if err := core.VerifyInclusionProof(block.TransactionsRoot, proof, config.SECP256K1); err != nil {
+			t.Errorf("transaction %d: %v", index, err)
+		}
+
+		proof, err = core.ProveReceipt(&block.BlockHeader, receipts, index, config.SECP256K1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := core.VerifyInclusionProof(block.ReceiptsRoot, proof, config.SECP256K1); err != nil {
+			t.Errorf("receipt %d: %v", index, err)
+		}
+
+		// the proof of a receipt isn't one of a transaction
+		if err := core.VerifyInclusionProof(block.TransactionsRoot, proof, config.SECP256K1); !errors.Is(err, core.ErrInvalidProof) {
+			t.Errorf("expected ErrInvalidProof, got %v", err)
+		}
+	}
+
+	// a proof claiming another value or index fails
+	proof, err := core.ProveTransaction(block, 3, config.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := core.ProveTransaction(block, 4, config.SECP256K1)
+	proof.Value = other.Value
+	if err := core.VerifyInclusionProof(block.TransactionsRoot, proof, config.SECP256K1); !errors.Is(err, core.ErrInvalidProof) {
+		t.Errorf("expected ErrInvalidProof, got %v", err)
+	}
+
+	// a node changing a transaction or a receipt is caught
+	block.Transactions[5].Amount = big.NewInt(1)
+	if err := core.VerifyBlockRoots(block, nil, config.SECP256K1); !errors.Is(err, core.ErrInvalidProof) {
+		t.Errorf("expected ErrInvalidProof, got %v", err)
+	}
+	if _, err := core.ProveTransaction(block, 5, config.SECP256K1); !errors.Is(err, core.ErrInvalidProof) {
+		t.Errorf("expected ErrInvalidProof, got %v", err)
+	}
+	block.Transactions[5].Amount = big.NewInt(1005)
+
+	receipts[2].Status = false
+	if err := core.VerifyBlockRoots(block, receipts, config.SECP256K1); !errors.Is(err, core.ErrInvalidProof) {
+		t.Errorf("expected ErrInvalidProof, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+const testSender = "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck"
+
+func testPoolContent(pending []string, queued []string) map[string]interface{} {
+	transactions := func(nonces []string) map[string]interface{} {
+		txs := make(map[string]interface{})
+		for _, nonce := range nonces {
+			txs[nonce] = map[string]interface{}{"from": testSender, "nonce": "0x0"}
+		}
+		return map[string]interface{}{testSender: txs}
+	}
+	return map[string]interface{}{"pending": transactions(pending), "queued": transactions(queued)}
+}
+
+func TestCoreNonceManager(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionCount", testSender, "pending").Return("0x5")
+	provider.On("core_getTransactionCount", testSender, "latest").Return("0x3")
+	provider.On("txpool_content").Return(testPoolContent([]string{"3", "4"}, []string{"6"}))
+
+	var connection = bif.NewBif(provider)
+	manager := connection.Core.NewNonceManager(connection.TxPool)
+	ctx := context.Background()
+
+	// 5 is missing from the pool, it's handed out before 7
+	var nonces []uint64
+	for i := 0; i < 3; i++ {
+		reservation, err := manager.Next(ctx, testSender)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces = append(nonces, reservation.Nonce.Uint64())
+		if i == 1 {
+			reservation.Release()
+		} else {
+			reservation.Commit()
+		}
+	}
+	if nonces[0] != 5 || nonces[1] != 7 || nonces[2] != 7 {
+		t.Errorf("unexpected nonces %v", nonces)
+	}
+
+	// the reservations of the goroutines are distinct and sequential
+	var lock sync.Mutex
+	seen := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reservation, err := manager.Next(ctx, testSender)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reservation.Commit()
+
+			lock.Lock()
+			defer lock.Unlock()
+			seen[reservation.Nonce.Uint64()] = true
+		}()
+	}
+	wg.Wait()
+	for nonce := uint64(8); nonce < 58; nonce++ {
+		if !seen[nonce] {
+			t.Fatalf("nonce %d wasn't handed out", nonce)
+		}
+	}
+}
+
+func TestCoreNonceManager_Resync(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionCount", testSender, "pending").Return("0x2").Once()
+	provider.On("core_getTransactionCount", testSender, "pending").Return("0x9")
+
+	var connection = bif.NewBif(provider)
+	manager := connection.Core.NewNonceManager(nil)
+	ctx := context.Background()
+
+	reservation, err := manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 2 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+
+	// another sender used the account, the node rejects the nonce
+	if err := reservation.Fail(ctx, &providers.RPCError{Code: -32000, Message: "nonce too low"}); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err = manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 9 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+
+	// the other errors only release the nonce
+	if err := reservation.Fail(ctx, &providers.RPCError{Code: -32000, Message: "insufficient funds for gas * price + value"}); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err = manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 9 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+	reservation.Commit()
+
+	// the transaction with nonce 9 was lost, a nonce too high resyncs down to it
+	reservation, _ = manager.Next(ctx, testSender)
+	if err := reservation.Fail(ctx, &providers.RPCError{Code: -32000, Message: "nonce too high"}); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err = manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 9 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+}
+
+func TestCoreNonceManager_Uncertain(t *testing.T) {
+
+	provider := mock.NewProvider()
+	provider.On("core_getTransactionCount", testSender, "pending").Return("0x2").Once()
+	provider.On("core_getTransactionCount", testSender, "pending").Return("0x3")
+
+	var connection = bif.NewBif(provider)
+	manager := connection.Core.NewNonceManager(nil)
+	ctx := context.Background()
+
+	// the request timed out after the node accepted the transaction, the
+	// nonce is resynced instead of released
+	reservation, err := manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 2 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+	if err := reservation.Fail(ctx, providers.ErrTimeout); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err = manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 3 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+
+	// the transaction was lost with the connection, the node hands its nonce
+	// out again
+	if err := reservation.Fail(ctx, &providers.TransportError{Err: context.DeadlineExceeded}); err != nil {
+		t.Fatal(err)
+	}
+	reservation, err = manager.Next(ctx, testSender)
+	if err != nil || reservation.Nonce.Uint64() != 3 {
+		t.Fatalf("unexpected reservation %v %v", reservation, err)
+	}
+}
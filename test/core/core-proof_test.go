@@ -0,0 +1,176 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk/core"
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/test/resources"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/hexutil"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
+	"github.com/tchain/go-tchain-sdk/utils/trie"
+)
+
+func testNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b/16, b%16)
+	}
+	return nibbles
+}
+
+func testCompact(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		flag++
+		nibbles = append([]byte{flag}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	compact := make([]byte, len(nibbles)/2)
+	for i := range compact {
+		compact[i] = nibbles[i*2]<<4 | nibbles[i*2+1]
+	}
+	return compact
+}
+
+func testEncode(t *testing.T, value interface{}) []byte {
+	encoded, err := rlp.EncodeToBytes(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+// testRef is the reference of a child node, embedded when it's shorter than a hash
+func testRef(t *testing.T, node []byte, cryptoType config.CryptoType) rlp.RawValue {
+	if len(node) < 32 {
+		return node
+	}
+	return testEncode(t, crypto.Keccak256(cryptoType, node))
+}
+
+// testTwoLeaves returns the root of a trie of two values and the proof of the first one
+func testTwoLeaves(t *testing.T, key1, value1, key2, value2 []byte, cryptoType config.CryptoType) (utils.Hash, [][]byte) {
+	path1, path2 := testNibbles(key1), testNibbles(key2)
+	p := 0
+	for path1[p] == path2[p] {
+		p++
+	}
+
+	leaf1 := testEncode(t, []interface{}{testCompact(path1[p+1:], true), value1})
+	leaf2 := testEncode(t, []interface{}{testCompact(path2[p+1:], true), value2})
+
+	children := make([]interface{}, 17)
+	for i := range children {
+		children[i] = []byte{}
+	}
+	children[path1[p]] = testRef(t, leaf1, cryptoType)
+	children[path2[p]] = testRef(t, leaf2, cryptoType)
+	branch := testEncode(t, children)
+
+	if p == 0 {
+		return crypto.Keccak256Hash(cryptoType, branch), [][]byte{branch, leaf1}
+	}
+	extension := testEncode(t, []interface{}{testCompact(path1[:p], false), testRef(t, branch, cryptoType)})
+	return crypto.Keccak256Hash(cryptoType, extension), [][]byte{extension, branch, leaf1}
+}
+
+func testProofStrings(proof [][]byte) []string {
+	var nodes []string
+	for _, node := range proof {
+		nodes = append(nodes, hexutil.Encode(node))
+	}
+	return nodes
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+
+	for _, cryptoType := range []config.CryptoType{config.SECP256K1, config.SM2} {
+		address1 := utils.StringToAddress(resources.Addr1)
+		address2 := utils.StringToAddress(resources.Addr2)
+
+		// the storage of the first account has the slot 0, a single leaf
+		slot := crypto.Keccak256(cryptoType, make([]byte, 32))
+		storageLeaf := testEncode(t, []interface{}{testCompact(testNibbles(slot), true), testEncode(t, big.NewInt(42))})
+		storageRoot := crypto.Keccak256Hash(cryptoType, storageLeaf)
+
+		codeHash := crypto.Keccak256(cryptoType, []byte{0x60, 0x00})
+		account1 := testEncode(t, []interface{}{uint64(3), big.NewInt(1000), storageRoot, codeHash})
+		account2 := testEncode(t, []interface{}{uint64(0), big.NewInt(5), trie.EmptyRoot(cryptoType), crypto.Keccak256(cryptoType)})
+
+		stateRoot, proof := testTwoLeaves(t,
+			crypto.Keccak256(cryptoType, address1.Bytes()), account1,
+			crypto.Keccak256(cryptoType, address2.Bytes()), account2,
+			cryptoType)
+
+		result := &dto.AccountResult{
+			Address:      address1,
+			AccountProof: testProofStrings(proof),
+			Balance:      (*hexutil.Big)(big.NewInt(1000)),
+			CodeHash:     utils.BytesToHash(codeHash),
+			Nonce:        3,
+			StorageHash:  storageRoot,
+			StorageProof: []dto.StorageResult{
+				{Key: "0x0", Value: (*hexutil.Big)(big.NewInt(42)), Proof: testProofStrings([][]byte{storageLeaf})},
+				{Key: "0x1", Value: (*hexutil.Big)(big.NewInt(0)), Proof: testProofStrings([][]byte{storageLeaf})},
+			},
+		}
+
+		if err := core.VerifyAccountProof(stateRoot.Hex(), result, cryptoType); err != nil {
+			t.Fatalf("crypto %d: %v", cryptoType, err)
+		}
+
+		// a node lying about the balance or a slot is caught
+		result.Balance = (*hexutil.Big)(big.NewInt(1001))
+		if err := core.VerifyAccountProof(stateRoot.Hex(), result, cryptoType); !errors.Is(err, core.ErrInvalidProof) {
+			t.Errorf("crypto %d: expected ErrInvalidProof, got %v", cryptoType, err)
+		}
+		result.Balance = (*hexutil.Big)(big.NewInt(1000))
+
+		storage := result.StorageProof[0]
+		storage.Value = (*hexutil.Big)(big.NewInt(43))
+		if err := core.VerifyStorageProof(storageRoot.Hex(), storage, cryptoType); !errors.Is(err, core.ErrInvalidProof) {
+			t.Errorf("crypto %d: expected ErrInvalidProof, got %v", cryptoType, err)
+		}
+
+		// the proof doesn't match another state root
+		if err := core.VerifyAccountProof(storageRoot.Hex(), result, cryptoType); !errors.Is(err, core.ErrInvalidProof) {
+			t.Errorf("crypto %d: expected ErrInvalidProof, got %v", cryptoType, err)
+		}
+
+		// the missing accounts are proven empty
+		missing := &dto.AccountResult{
+			Address:      address2,
+			AccountProof: testProofStrings([][]byte{storageLeaf}),
+			Balance:      (*hexutil.Big)(big.NewInt(0)),
+			CodeHash:     utils.BytesToHash(crypto.Keccak256(cryptoType)),
+			StorageHash:  trie.EmptyRoot(cryptoType),
+		}
+		if err := core.VerifyAccountProof(storageRoot.Hex(), missing, cryptoType); err != nil {
+			t.Errorf("crypto %d: %v", cryptoType, err)
+		}
+	}
+}
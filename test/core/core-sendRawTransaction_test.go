@@ -18,30 +18,31 @@ import (
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/account"
 	"github.com/tchain/go-tchain-sdk/core/block"
-	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
-	"github.com/tchain/go-tchain-sdk/test/resources"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"math/big"
-	"strconv"
 	"testing"
-	"time"
 )
 
 // 测试转账
 func TestCoreSendTransaction(t *testing.T) {
 
-	var connection = bif.NewBif(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
-
 	priKey := "e41219552564c956edeb0fa782c7760a6f5ade504768b3570c68dc0459a7889a"
 
 	recipientStr := "did:bid:qwer:zftAgNtnQzLMGJHKPMdn9quPvuikNWUZ"
 
+	provider := testTransferProvider("did:bid:qwer:sf25XGBQU8E8wGFo9wGKo95jUgtYPM24Y", recipientStr)
+	provider.On("core_getBalance", recipientStr, block.LATEST).Return("0xde0b6b3a7640000")
+
+	var connection = bif.NewBif(provider)
+
 	balance, err := connection.Core.GetBalance(recipientStr, block.LATEST)
-	if err == nil {
-		balBif, _ := utils.FromWei(balance)
-		t.Log("recipient balance is ", balBif)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if balBif, _ := utils.FromWei(balance); balBif != "1" {
+		t.Errorf("unexpected recipient balance %v", balBif)
 	}
 
 	var recipient utils.Address
@@ -68,14 +69,18 @@ func TestCoreSendTransaction(t *testing.T) {
 	}
 
 	txID, err := connection.Core.SendRawTransaction(hexutil.Encode(res.Raw))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 
-	var receipt *dto.TransactionReceipt
-
-	for receipt == nil {
-		time.Sleep(time.Second)
-		receipt, err = connection.Core.GetTransactionReceipt(txID)
+	receipt, err := connection.Core.GetTransactionReceipt(txID)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
 	}
 
-	// if success, get transaction hash
-	t.Log(txID)
+	if receipt.TransactionHash != txID || !receipt.Status {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/tchain/go-tchain-sdk/crypto"
 	"github.com/tchain/go-tchain-sdk/crypto/config"
 	"github.com/tchain/go-tchain-sdk/dto"
-	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/test/resources"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 	"math/big"
@@ -90,19 +90,28 @@ func TestGenerate(t *testing.T) {
 }
 
 func TestMonitor(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(ip+":"+strconv.FormatUint(port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProviderAt(t, ip+":"+strconv.FormatUint(port, 10)))
 
 	for true {
-		number, _ := connection.Core.GetBlockNumber()
-		block, _ := connection.Core.GetBlockByNumber(number.String(), false)
-		b := block.(*dto.BlockNoDetails)
+		number, err := connection.Core.GetBlockNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, err := connection.Core.GetBlockByNumber(hexutil.EncodeBig(number), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, ok := block.(*dto.BlockNoDetails)
+		if !ok {
+			t.Fatalf("unexpected block %T", block)
+		}
 		fmt.Printf("number:%d, timestamp:%d, txLength:%d\n", number.Uint64(), b.Timestamp, len(b.Transactions))
 		time.Sleep(4 * time.Second)
 	}
 }
 
 func TestPressure(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(ip+":"+strconv.FormatUint(port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProviderAt(t, ip+":"+strconv.FormatUint(port, 10)))
 
 	generateKey, _ := crypto.GenerateKey(config.SECP256K1)
 
@@ -145,7 +154,7 @@ func TestPressure(t *testing.T) {
 }
 
 func TestInit(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(ip+":"+strconv.FormatUint(port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProviderAt(t, ip+":"+strconv.FormatUint(port, 10)))
 
 	for _, to := range tos {
 		txObj := tx(connection, privateKey, cryptoType, utils.StringToAddress(to), big.NewInt(1000000000000000000))
@@ -177,7 +186,10 @@ func TestInit(t *testing.T) {
 			fmt.Println(err)
 		}
 
-		number, _ := connection.Core.GetBlockNumber()
+		number, err := connection.Core.GetBlockNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
 		balance, err := connection.Core.GetBalance(from.String(chainCode), hexutil.EncodeUint64(number.Uint64()))
 		if err != nil {
 			fmt.Println(err)
@@ -188,9 +200,12 @@ func TestInit(t *testing.T) {
 }
 
 func TestBalance(t *testing.T) {
-	var connection = bif.NewBif(providers.NewHTTPProvider(ip+":"+strconv.FormatUint(port, 10), 10, false))
+	var connection = bif.NewBif(resources.NewProviderAt(t, ip+":"+strconv.FormatUint(port, 10)))
 
-	number, _ := connection.Core.GetBlockNumber()
+	number, err := connection.Core.GetBlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
 	fmt.Println(number.String())
 
 	from, err := key(privateKey, config.SECP256K1)
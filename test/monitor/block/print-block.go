@@ -7,36 +7,32 @@ import (
 	"time"
 
 	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core/block"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
-	"github.com/tchain/go-tchain-sdk/utils"
-	"github.com/tchain/go-tchain-sdk/utils/hexutil"
 )
 
 func main() {
 	var connection = bif.NewBif(providers.NewHTTPProvider(os.Args[1], 10, false))
 
 	for {
-		block, err := connection.Core.GetBlockByNumber("latest", false)
+		header, err := connection.Core.GetHeaderByNumber(block.LATEST)
 
 		if err != nil {
 			fmt.Println(err)
+		} else {
+			printInfo(header)
 		}
-		b := block.(*dto.BlockNoDetails)
-		printInfo(b)
 		period, err := strconv.ParseInt(os.Args[2], 10, 64)
 		time.Sleep(time.Duration(period) * time.Second)
 	}
 }
 
-func printInfo(b *dto.BlockNoDetails) {
-	extra, e := hexutil.Decode(b.ExtraData)
-	if e != nil {
-		fmt.Println(e)
-	}
-	istanbulExtra, e := utils.ExtractIstanbulExtra(extra)
-	if e != nil {
-		fmt.Println(e)
+func printInfo(b *dto.BlockHeader) {
+	istanbulExtra := b.Istanbul
+	if istanbulExtra == nil {
+		fmt.Println("no istanbul extra data in block", b.Number)
+		return
 	}
 
 	fmt.Printf("%-10d %-20s %-50s || %2d\t",
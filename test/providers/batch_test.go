@@ -0,0 +1,138 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+// batchResponse answers a request of Test_Batch_Errors, core_unknown is rejected
+func batchResponse(request map[string]interface{}) map[string]interface{} {
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": request["id"]}
+	if request["method"] == "core_chainId" {
+		response["result"] = "0x2"
+	} else {
+		response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	return response
+}
+
+// Test_Batch_Errors checks that the transports handle the errors of a batch
+// alike: a rejected element gets an *RPCError and keeps its Result, a lost
+// connection fails the whole call
+func Test_Batch_Errors(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&requests)
+		if requests[0]["method"] == "core_drop" {
+			connection, _, _ := w.(http.Hijacker).Hijack()
+			connection.Close()
+			return
+		}
+
+		var responses []map[string]interface{}
+		for _, request := range requests {
+			responses = append(responses, batchResponse(request))
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer httpServer.Close()
+
+	websocketAddress, stop := newWebsocketNode(t, func(conn *websocket.Conn) {
+		for {
+			var requests []map[string]interface{}
+			if err := conn.ReadJSON(&requests); err != nil {
+				return
+			}
+			if requests[0]["method"] == "core_drop" {
+				return
+			}
+
+			var responses []map[string]interface{}
+			for _, request := range requests {
+				responses = append(responses, batchResponse(request))
+			}
+			if err := conn.WriteJSON(responses); err != nil {
+				return
+			}
+		}
+	})
+	defer stop()
+
+	// the mock provider isn't a BatchProvider, its batches are sent one request at a time
+	fallback := mock.NewProvider()
+	fallback.On("core_chainId").Return("0x2")
+	fallback.On("core_unknown").ReturnError(-32601, "method not found")
+	fallback.On("core_drop").Fail(&providers.TransportError{Err: errors.New("connection reset")})
+
+	websocketProvider, err := providers.NewWebSocketProviderWithOptions(websocketAddress, providers.WithoutReconnect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer websocketProvider.Close()
+
+	for name, provider := range map[string]providers.ProviderInterface{
+		"http":      providers.NewHTTPProvider(strings.TrimPrefix(httpServer.URL, "http://"), 10, false),
+		"websocket": websocketProvider,
+		"fallback":  fallback,
+	} {
+		elems := []providers.BatchElem{
+			{Method: "core_chainId", Result: &dto.CoreRequestResult{}},
+			{Method: "core_unknown", Result: &dto.CoreRequestResult{}},
+		}
+		if err := providers.BatchSendRequestContext(context.Background(), provider, elems); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		chainId, err := elems[0].Result.(*dto.CoreRequestResult).ToUint64()
+		if elems[0].Error != nil || err != nil || chainId != 2 {
+			t.Errorf("%s: unexpected chainId %d, %v %v", name, chainId, elems[0].Error, err)
+		}
+		var rpcError *providers.RPCError
+		if !errors.As(elems[1].Error, &rpcError) || rpcError.Code != -32601 {
+			t.Errorf("%s: expected an RPCError, got %v", name, elems[1].Error)
+		}
+		if result := elems[1].Result.(*dto.CoreRequestResult); result.ID != 0 || result.Error != nil {
+			t.Errorf("%s: the result of the rejected element was set: %+v", name, result)
+		}
+
+		elems = []providers.BatchElem{
+			{Method: "core_drop", Result: &dto.CoreRequestResult{}},
+			{Method: "core_chainId", Result: &dto.CoreRequestResult{}},
+		}
+		err = providers.BatchSendRequestContext(context.Background(), provider, elems)
+		var transportError *providers.TransportError
+		if !errors.As(err, &transportError) {
+			t.Errorf("%s: expected a TransportError, got %v", name, err)
+		}
+		for i, elem := range elems {
+			if elem.Error != nil {
+				t.Errorf("%s: element %d has the transport error %v", name, i, elem.Error)
+			}
+		}
+	}
+}
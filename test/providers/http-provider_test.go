@@ -15,6 +15,16 @@
 package test
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/account"
@@ -25,7 +35,12 @@ import (
 	"github.com/tchain/go-tchain-sdk/txpool"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -485,3 +500,271 @@ func Test_HTTP_Txpool_ToTxPoolContent(t *testing.T) {
 	fmt.Println(content)
 }
 
+func Test_HTTP_Core_GetBlockNumberCtx_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var connection = bif.NewBif(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := connection.Core.GetBlockNumberCtx(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+		t.FailNow()
+	}
+
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("request was not aborted by the context")
+	}
+}
+
+func Test_HTTP_Core_GetTransactionReceipts_Batch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var responses []string
+		for _, request := range requests {
+			hash := request["params"].([]interface{})[0].(string)
+			result := "null"
+			if hash == "0x01" {
+				result = `{"transactionHash":"0x01","transactionIndex":"0x0","blockHash":"0x02","blockNumber":"0x10","cumulativeGasUsed":"0x5208","gasUsed":"0x5208","status":"0x1","logs":[]}`
+			}
+			responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%v,"result":%s}`, request["id"], result))
+		}
+		w.Write([]byte("[" + strings.Join(responses, ",") + "]"))
+	}))
+	defer server.Close()
+
+	var connection = bif.NewBif(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	receipts, err := connection.Core.GetTransactionReceipts([]string{"0x01", "0x03"})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(receipts) != 2 || receipts[0] == nil || receipts[1] != nil {
+		t.Errorf("unexpected receipts %v", receipts)
+		t.FailNow()
+	}
+
+	if receipts[0].BlockNumber.Uint64() != 16 || !receipts[0].Status {
+		t.Errorf("unexpected receipt %v", receipts[0])
+	}
+}
+
+func Test_HTTP_TypedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		switch request["method"] {
+		case "core_sendRawTransaction":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%v,"error":{"code":-32000,"message":"nonce too low"}}`, request["id"])
+		case "core_blockNumber":
+			w.Write([]byte("<html>bad gateway</html>"))
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var connection = bif.NewBif(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	_, err := connection.Core.SendRawTransaction("0x00")
+	var rpcError *providers.RPCError
+	if !errors.As(err, &rpcError) || rpcError.Code != -32000 {
+		t.Errorf("expected an RPCError, got %v", err)
+	}
+	if !errors.Is(err, providers.ErrNonceTooLow) || errors.Is(err, providers.ErrInsufficientFunds) {
+		t.Errorf("unexpected match of %v", err)
+	}
+
+	_, err = connection.Core.GetBlockNumber()
+	var decodeError *providers.DecodeError
+	if !errors.As(err, &decodeError) {
+		t.Errorf("expected a DecodeError, got %v", err)
+	}
+
+	_, err = connection.Core.GetChainId()
+	var statusError *providers.HTTPStatusError
+	if !errors.As(err, &statusError) || statusError.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected an HTTPStatusError, got %v", err)
+	}
+
+	server.Close()
+	_, err = connection.Core.GetChainId()
+	var transportError *providers.TransportError
+	if !errors.As(err, &transportError) {
+		t.Errorf("expected a TransportError, got %v", err)
+	}
+}
+
+func Test_HTTP_Options_Headers_Token(t *testing.T) {
+	var logins int32
+	var current atomic.Value
+	current.Store("token-1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+current.Load().(string) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("X-Api-Key") != "key" || r.Header.Get("X-Request-Signature") == "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x14d"}`)
+	}))
+	defer server.Close()
+
+	source := providers.NewCachedTokenSource(providers.TokenSourceFunc(func(ctx context.Context) (*providers.Token, error) {
+		n := atomic.AddInt32(&logins, 1)
+		return &providers.Token{AccessToken: fmt.Sprintf("token-%d", n), Expiry: time.Now().Add(time.Hour)}, nil
+	}), 0)
+
+	provider, err := providers.NewHTTPProviderWithOptions(strings.TrimPrefix(server.URL, "http://"),
+		providers.WithHTTPHeader("X-Api-Key", "key"),
+		providers.WithHTTPHeaderFunc(func(ctx context.Context) (http.Header, error) {
+			return http.Header{"X-Request-Signature": []string{"signature"}}, nil
+		}),
+		providers.WithTokenSource(source),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connection := bif.NewBif(provider)
+
+	for i := 0; i < 3; i++ {
+		if _, err := connection.Core.GetChainId(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&logins); n != 1 {
+		t.Errorf("expected the token to be cached, got %d logins", n)
+	}
+
+	// the gateway revokes the token, a new one is fetched
+	current.Store("token-2")
+	if _, err := connection.Core.GetChainId(); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&logins); n != 2 {
+		t.Errorf("expected a new login, got %d logins", n)
+	}
+}
+
+func Test_HTTP_Options_Gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			http.Error(w, "expected a compressed request", http.StatusBadRequest)
+			return
+		}
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var request map[string]interface{}
+		if err := json.NewDecoder(reader).Decode(&request); err != nil || request["method"] != "core_chainId" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		fmt.Fprintf(writer, `{"jsonrpc":"2.0","id":%v,"result":"0x14d"}`, request["id"])
+		writer.Close()
+	}))
+	defer server.Close()
+
+	provider, err := providers.NewHTTPProviderWithOptions(strings.TrimPrefix(server.URL, "http://"), providers.WithGzip())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainId, err := bif.NewBif(provider).Core.GetChainId()
+	if err != nil || chainId != 333 {
+		t.Errorf("unexpected chain id %d %v", chainId, err)
+	}
+}
+
+// newClientCertificate returns a self-signed certificate for the client
+// authentication
+func newClientCertificate(t *testing.T) (tls.Certificate, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(crand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: certificate}, certificate
+}
+
+func Test_HTTP_Options_MutualTLS(t *testing.T) {
+	clientCertificate, clientCA := newClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x14d"}`)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	// without a client certificate the handshake fails
+	provider, err := providers.NewHTTPProviderWithOptions(address, providers.WithRootCAs(rootCAs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bif.NewBif(provider).Core.GetChainId()
+	var transportError *providers.TransportError
+	if !errors.As(err, &transportError) {
+		t.Errorf("expected a TransportError, got %v", err)
+	}
+
+	provider, err = providers.NewHTTPProviderWithOptions(address, providers.WithRootCAs(rootCAs), providers.WithClientCertificate(clientCertificate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainId, err := bif.NewBif(provider).Core.GetChainId()
+	if err != nil || chainId != 333 {
+		t.Errorf("unexpected chain id %d %v", chainId, err)
+	}
+
+	if _, err := providers.NewHTTPProviderWithOptions(address, providers.WithRootCAFile(filepath.Join(t.TempDir(), "missing.pem"))); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+}
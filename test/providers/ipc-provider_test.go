@@ -15,7 +15,17 @@
 package test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/providers"
@@ -33,3 +43,193 @@ func Test_IPCProvider(t *testing.T) {
 	}
 
 }
+
+// newIPCNode listens on a unix socket in a temporary directory and passes
+// every accepted connection to serve
+func newIPCNode(t *testing.T, serve func(conn net.Conn)) (string, func()) {
+	endpoint := filepath.Join(t.TempDir(), "bif.ipc")
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				serve(conn)
+			}()
+		}
+	}()
+
+	return endpoint, func() { listener.Close() }
+}
+
+func Test_IPCProvider_Multiplexing(t *testing.T) {
+	const calls = 10
+	var dials int32
+	endpoint, stop := newIPCNode(t, func(conn net.Conn) {
+		atomic.AddInt32(&dials, 1)
+		decoder := json.NewDecoder(conn)
+		encoder := json.NewEncoder(conn)
+
+		// wait for all the calls and answer them in reverse order
+		var requests []map[string]interface{}
+		for len(requests) < calls {
+			var request map[string]interface{}
+			if err := decoder.Decode(&request); err != nil {
+				return
+			}
+			requests = append(requests, request)
+		}
+		for i := len(requests) - 1; i >= 0; i-- {
+			params := requests[i]["params"].([]interface{})
+			encoder.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": requests[i]["id"], "result": params[0]})
+		}
+		io.Copy(io.Discard, conn)
+	})
+	defer stop()
+
+	provider := providers.NewIPCProvider(endpoint)
+	defer provider.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			expected := fmt.Sprintf("0x%x", i)
+
+			var response struct {
+				Result []byte `json:"result"`
+			}
+			if err := provider.SendRequest(&response, "test_echo", []string{expected}); err != nil {
+				t.Error(err)
+				return
+			}
+			var result string
+			if err := json.Unmarshal(response.Result, &result); err != nil || result != expected {
+				t.Errorf("expected %s, got %s %v", expected, response.Result, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&dials); n != 1 {
+		t.Errorf("expected a single connection, got %d", n)
+	}
+}
+
+func Test_IPCProvider_Reconnect_Subscribe(t *testing.T) {
+	var connections int32
+	endpoint, stop := newIPCNode(t, func(conn net.Conn) {
+		n := atomic.AddInt32(&connections, 1)
+		id := fmt.Sprintf("0x%d", n)
+		decoder := json.NewDecoder(conn)
+		encoder := json.NewEncoder(conn)
+
+		for {
+			var request map[string]interface{}
+			if err := decoder.Decode(&request); err != nil {
+				return
+			}
+			if request["method"] != "core_subscribe" {
+				encoder.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": true})
+				continue
+			}
+
+			encoder.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": id})
+			encoder.Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "core_subscription",
+				"params":  map[string]interface{}{"subscription": id, "result": fmt.Sprintf("0x%064d", n)},
+			})
+			if n == 1 {
+				// the node restarts
+				return
+			}
+		}
+	})
+	defer stop()
+
+	provider, err := providers.NewIPCProviderWithOptions(endpoint, providers.WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	hashes := make(chan string)
+	sub, err := bif.NewBif(provider).Core.SubscribeNewPendingTransactions(context.Background(), hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	for i := 1; i <= 2; i++ {
+		select {
+		case hash := <-hashes:
+			if hash != fmt.Sprintf("0x%064d", i) {
+				t.Errorf("unexpected hash %s", hash)
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification not received")
+		}
+	}
+}
+
+func Test_IPCProvider_Dial(t *testing.T) {
+	endpoint := filepath.Join(t.TempDir(), "bif.ipc")
+	provider := providers.NewIPCProvider(endpoint)
+	defer provider.Close()
+
+	// the concurrent requests waiting for the dial of the missing socket get
+	// its TransportError
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var transportErr *providers.TransportError
+			if err := provider.SendRequest(new(interface{}), "test_echo", nil); !errors.As(err, &transportErr) {
+				t.Errorf("expected a TransportError, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the next request dials again once the node listens
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var request map[string]interface{}
+		if err := json.NewDecoder(conn).Decode(&request); err == nil {
+			json.NewEncoder(conn).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": "0x1"})
+		}
+		io.Copy(io.Discard, conn)
+	}()
+
+	connection := bif.NewBif(provider)
+	if number, err := connection.Core.GetBlockNumber(); err != nil || number.Uint64() != 1 {
+		t.Errorf("unexpected block number %v %v", number, err)
+	}
+
+	// a closed provider isn't dialed again
+	provider.Close()
+	if _, err := connection.Core.GetBlockNumber(); !errors.Is(err, providers.ErrProviderClosed) {
+		t.Errorf("expected ErrProviderClosed, got %v", err)
+	}
+}
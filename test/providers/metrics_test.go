@@ -0,0 +1,170 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+func Test_Metrics_Requests(t *testing.T) {
+	node := mock.NewProvider()
+	node.On("core_chainId").Return("0x14d")
+	node.On("core_sendRawTransaction").ReturnError(-32000, "nonce too low")
+
+	metrics := providers.NewRPCMetrics("bif", []float64{1, 0.5})
+	connection := bif.NewBif(providers.NewMiddlewareProvider(node, providers.Metrics(metrics, "node-1")))
+
+	for i := 0; i < 2; i++ {
+		if _, err := connection.Core.GetChainId(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := connection.Core.SendRawTransaction("0x00"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var out bytes.Buffer
+	if _, err := metrics.WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE bif_rpc_requests_total counter",
+		`bif_rpc_requests_total{endpoint="node-1",method="core_chainId"} 2`,
+		`bif_rpc_errors_total{endpoint="node-1",method="core_sendRawTransaction",type="rpc"} 1`,
+		"# TYPE bif_rpc_request_duration_seconds histogram",
+		`bif_rpc_request_duration_seconds_bucket{endpoint="node-1",method="core_chainId",le="0.5"} 2`,
+		`bif_rpc_request_duration_seconds_bucket{endpoint="node-1",method="core_chainId",le="+Inf"} 2`,
+		`bif_rpc_request_duration_seconds_count{endpoint="node-1",method="core_sendRawTransaction"} 1`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("missing %q in\n%s", line, out.String())
+		}
+	}
+	if strings.Contains(out.String(), `method="core_chainId",type=`) {
+		t.Errorf("unexpected errors of core_chainId in\n%s", out.String())
+	}
+}
+
+func Test_Metrics_InFlight(t *testing.T) {
+	var maxPending int32
+	address, stop := newSlowWebsocketNode(t, 300*time.Millisecond, &maxPending)
+	defer stop()
+
+	provider, err := providers.NewWebSocketProviderWithOptions(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	metrics := providers.NewRPCMetrics("bif", nil)
+	metrics.AddConnection("ws", provider)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.SendRequest(&dto.CoreRequestResult{}, "core_blockNumber", nil)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if stats := provider.Stats(); stats.InFlight != 3 || stats.State != providers.StateConnected {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	var out bytes.Buffer
+	metrics.WriteTo(&out)
+	if !strings.Contains(out.String(), `bif_rpc_in_flight{endpoint="ws"} 3`) {
+		t.Errorf("missing the in-flight requests in\n%s", out.String())
+	}
+
+	wg.Wait()
+	if stats := provider.Stats(); stats.InFlight != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+type testSpan struct {
+	name       string
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (span *testSpan) SetAttributes(attributes ...providers.Attribute) {
+	for _, attribute := range attributes {
+		span.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (span *testSpan) End(err error) {
+	span.err = err
+	span.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (tracer *testTracer) Start(ctx context.Context, name string, attributes ...providers.Attribute) (context.Context, providers.Span) {
+	span := &testSpan{name: name, attributes: map[string]string{}}
+	span.SetAttributes(attributes...)
+	tracer.spans = append(tracer.spans, span)
+	return ctx, span
+}
+
+func Test_Tracing(t *testing.T) {
+	node := mock.NewProvider()
+	node.On("core_call").ReturnError(3, "execution reverted")
+
+	tracer := &testTracer{}
+	connection := bif.NewBif(providers.NewMiddlewareProvider(node, providers.Tracing(tracer, "node-1")))
+
+	transaction := &dto.TransactionParameters{ChainId: 333, Recipient: "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck", Payload: "0x00"}
+	if _, err := connection.Core.Call(transaction); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected a span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "core_call" || !span.ended || span.err == nil {
+		t.Errorf("unexpected span %+v", span)
+	}
+	expected := map[string]string{
+		"rpc.system":                "jsonrpc",
+		"rpc.method":                "core_call",
+		"server.address":            "node-1",
+		"bif.transaction.recipient": "did:bid:ZFT4CziA2ktCNgfQPqSm1GpQxSck",
+		"error.type":                "rpc",
+		"rpc.jsonrpc.error_code":    "3",
+	}
+	for key, value := range expected {
+		if span.attributes[key] != value {
+			t.Errorf("expected %s=%s, got %q", key, value, span.attributes[key])
+		}
+	}
+}
@@ -0,0 +1,224 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/dto"
+	"github.com/tchain/go-tchain-sdk/providers"
+)
+
+// newFlakyNode fails the first failures requests with 503, then answers "0x1"
+func newFlakyNode(failures int32, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%v,"result":"0x1"}`, request["id"])
+	}))
+}
+
+func newMiddlewareConnection(server *httptest.Server, middlewares ...providers.Middleware) *bif.Bif {
+	client := &http.Client{Timeout: 10 * time.Second}
+	provider := providers.NewHTTPProviderWithClient(strings.TrimPrefix(server.URL, "http://"), 10, false, client)
+	return bif.NewBif(providers.NewMiddlewareProvider(provider, middlewares...))
+}
+
+func Test_Middleware_Retry(t *testing.T) {
+	var requests int32
+	server := newFlakyNode(2, &requests)
+	defer server.Close()
+
+	connection := newMiddlewareConnection(server, providers.Retry(providers.RetryPolicy{MinBackoff: time.Millisecond}))
+
+	number, err := connection.Core.GetBlockNumber()
+	if err != nil || number.Uint64() != 1 {
+		t.Errorf("unexpected block number %v, %v", number, err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+
+	// the writes are never retried
+	atomic.StoreInt32(&requests, 0)
+	server2 := newFlakyNode(1, &requests)
+	defer server2.Close()
+	connection = newMiddlewareConnection(server2, providers.Retry(providers.RetryPolicy{MinBackoff: time.Millisecond}))
+
+	var statusError *providers.HTTPStatusError
+	if _, err := connection.Core.SendRawTransaction("0x00"); !errors.As(err, &statusError) {
+		t.Errorf("expected an HTTPStatusError, got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected a single attempt, got %d", n)
+	}
+}
+
+type testLogger struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (logger *testLogger) Printf(format string, v ...interface{}) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.lines = append(logger.lines, fmt.Sprintf(format, v...))
+}
+
+func Test_Middleware_Logging_Redaction(t *testing.T) {
+	var requests int32
+	server := newFlakyNode(0, &requests)
+	defer server.Close()
+
+	logger := &testLogger{}
+	connection := newMiddlewareConnection(server, providers.Logging(providers.LogConfig{
+		Logger:        logger,
+		RedactMethods: []string{"core_sendRawTransaction"},
+		LogResults:    true,
+	}))
+
+	params := []interface{}{map[string]interface{}{"sender": "did:bid:EFTTQWPMdtghuZByPsfQAUuPkWkWYb", "password": "secret"}}
+	if err := connection.Provider.SendRequest(&dto.RequestResult{}, "core_signTransaction", params); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := connection.Core.SendRawTransaction("0xsignedpayload"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.lines) != 2 {
+		t.Fatalf("unexpected logs %v", logger.lines)
+	}
+	if !strings.Contains(logger.lines[0], "core_signTransaction") || !strings.Contains(logger.lines[0], "EFTTQWPMdtghuZByPsfQAUuPkWkWYb") {
+		t.Errorf("unexpected log %s", logger.lines[0])
+	}
+	for _, line := range logger.lines {
+		if strings.Contains(line, "secret") || strings.Contains(line, "signedpayload") {
+			t.Errorf("log not redacted: %s", line)
+		}
+	}
+}
+
+func Test_Middleware_Logging_DefaultLogger(t *testing.T) {
+	var requests int32
+	server := newFlakyNode(0, &requests)
+	defer server.Close()
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	// the standard logger is used without a Logger
+	connection := newMiddlewareConnection(server, providers.Logging(providers.LogConfig{}))
+	if _, err := connection.Core.GetBlockNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "rpc core_blockNumber") {
+		t.Errorf("unexpected log %q", output.String())
+	}
+}
+
+func Test_Middleware_RateLimit(t *testing.T) {
+	var requests int32
+	server := newFlakyNode(0, &requests)
+	defer server.Close()
+
+	connection := newMiddlewareConnection(server, providers.RateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := connection.Core.GetBlockNumber(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("5 requests at 20/s took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	connection.Core.GetBlockNumberCtx(context.Background())
+	if _, err := connection.Core.GetBlockNumberCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the limiter to honour the context, got %v", err)
+	}
+
+	// the requests aren't limited without a positive rate
+	for _, rate := range []float64{0, -1} {
+		connection = newMiddlewareConnection(server, providers.RateLimit(rate, 0))
+		start = time.Now()
+		for i := 0; i < 5; i++ {
+			if _, err := connection.Core.GetBlockNumber(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("5 requests without a rate took %s", elapsed)
+		}
+	}
+}
+
+func Test_Middleware_Hooks_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%v,"result":"0x2"}`, request["id"])
+	}))
+	defer server.Close()
+
+	blocked := errors.New("blocked")
+	var after []string
+	connection := newMiddlewareConnection(server,
+		providers.OnMethod("core_sendRawTransaction", func(ctx context.Context, method string, params interface{}) error {
+			return blocked
+		}, nil),
+		providers.OnMethod("*", nil, func(ctx context.Context, method string, params interface{}, v interface{}, err error) {
+			after = append(after, method)
+		}),
+		providers.Headers(func(ctx context.Context) (http.Header, error) {
+			return http.Header{"Authorization": {"Bearer token"}}, nil
+		}),
+	)
+
+	if chainId, err := connection.Core.GetChainId(); err != nil || chainId != 2 {
+		t.Errorf("unexpected chain id %d, %v", chainId, err)
+	}
+	if _, err := connection.Core.SendRawTransaction("0x00"); err != blocked {
+		t.Errorf("expected the before hook error, got %v", err)
+	}
+	if len(after) != 1 || after[0] != "core_chainId" {
+		t.Errorf("unexpected after hooks %v", after)
+	}
+}
@@ -0,0 +1,106 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/core/block"
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+func Test_Mock_Scripted(t *testing.T) {
+	provider := mock.NewProvider()
+	provider.On("core_chainId").Return("0x14d")
+	provider.On("core_getTransactionCount", "did:bid:sf1", block.LATEST).Return("0x5").Once()
+	provider.On("core_sendRawTransaction").ReturnError(-32000, "nonce too low")
+
+	connection := bif.NewBif(provider)
+
+	chainId, err := connection.Core.GetChainId()
+	if err != nil || chainId != 333 {
+		t.Fatalf("unexpected chain id %d %v", chainId, err)
+	}
+
+	if len(provider.Pending()) != 1 {
+		t.Errorf("expected a pending expectation, got %v", provider.Pending())
+	}
+	nonce, err := connection.Core.GetTransactionCount("did:bid:sf1", block.LATEST)
+	if err != nil || nonce.Uint64() != 5 {
+		t.Fatalf("unexpected nonce %v %v", nonce, err)
+	}
+	if len(provider.Pending()) != 0 {
+		t.Errorf("unexpected pending expectations %v", provider.Pending())
+	}
+
+	// the expectation was used up
+	_, err = connection.Core.GetTransactionCount("did:bid:sf1", block.LATEST)
+	if !errors.Is(err, mock.ErrUnexpectedCall) {
+		t.Errorf("expected ErrUnexpectedCall, got %v", err)
+	}
+
+	_, err = connection.Core.SendRawTransaction("0x00")
+	if !errors.Is(err, providers.ErrNonceTooLow) {
+		t.Errorf("expected ErrNonceTooLow, got %v", err)
+	}
+
+	if calls := provider.Calls(); len(calls) != 4 || calls[3].Method != "core_sendRawTransaction" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
+
+func Test_Mock_Record_Replay(t *testing.T) {
+	node := newTestNode(333, 10)
+	defer node.server.Close()
+
+	golden := filepath.Join(t.TempDir(), "golden.json")
+	recorder := mock.NewRecorder(node.provider(), golden)
+
+	chainId, err := bif.NewBif(recorder).Core.GetChainId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := bif.NewBif(recorder).Core.GetBlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the node is gone, the replayer answers from the golden file
+	node.server.Close()
+	replayer, err := mock.NewReplayer(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connection := bif.NewBif(replayer)
+
+	replayedChainId, err := connection.Core.GetChainId()
+	if err != nil || replayedChainId != chainId {
+		t.Errorf("expected chain id %d, got %d %v", chainId, replayedChainId, err)
+	}
+	replayedNumber, err := connection.Core.GetBlockNumber()
+	if err != nil || replayedNumber.Cmp(number) != 0 {
+		t.Errorf("expected block number %v, got %v %v", number, replayedNumber, err)
+	}
+	if _, err := connection.Core.GetChainId(); !errors.Is(err, mock.ErrUnexpectedCall) {
+		t.Errorf("expected ErrUnexpectedCall, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+/********************************************************************************
+   This file is part of go-bif.
+   go-bif is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   go-bif is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with go-bif.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tchain/go-tchain-sdk"
+	"github.com/tchain/go-tchain-sdk/providers"
+)
+
+// testNode is a local HTTP node identified by its chain id
+type testNode struct {
+	server      *httptest.Server
+	chainId     uint64
+	blockNumber uint64
+	down        int32
+	drop        int32
+	requests    int32
+}
+
+func newTestNode(chainId uint64, blockNumber uint64) *testNode {
+	node := &testNode{chainId: chainId, blockNumber: blockNumber}
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&node.down) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request["method"] != "core_blockNumber" {
+			atomic.AddInt32(&node.requests, 1)
+			if atomic.LoadInt32(&node.drop) == 1 {
+				// the request was received but the connection is lost before the answer
+				connection, _, _ := w.(http.Hijacker).Hijack()
+				connection.Close()
+				return
+			}
+		}
+
+		result := fmt.Sprintf("0x%x", node.chainId)
+		switch request["method"] {
+		case "core_blockNumber":
+			result = fmt.Sprintf("0x%x", node.blockNumber)
+		case "core_sendRawTransaction":
+			result = fmt.Sprintf("0x%064x", node.chainId)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%v,"result":"%s"}`, request["id"], result)
+	}))
+	return node
+}
+
+func (node *testNode) provider() providers.ProviderInterface {
+	return providers.NewHTTPProvider(strings.TrimPrefix(node.server.URL, "http://"), 10, false)
+}
+
+func Test_MultiProvider_Failover(t *testing.T) {
+	primary, secondary := newTestNode(1, 10), newTestNode(2, 10)
+	defer primary.server.Close()
+	defer secondary.server.Close()
+
+	provider, err := providers.NewMultiProvider(
+		[]providers.ProviderInterface{primary.provider(), secondary.provider()},
+		providers.WithStrategy(providers.Failover),
+		providers.WithHealthCheck(20*time.Millisecond, time.Second),
+		providers.WithMaxFailures(1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+	connection := bif.NewBif(provider)
+
+	if chainId, err := connection.Core.GetChainId(); err != nil || chainId != 1 {
+		t.Errorf("expected the primary, got %d %v", chainId, err)
+	}
+
+	atomic.StoreInt32(&primary.down, 1)
+	if chainId, err := connection.Core.GetChainId(); err != nil || chainId != 2 {
+		t.Errorf("expected the secondary, got %d %v", chainId, err)
+	}
+	if provider.Status()[0].Healthy {
+		t.Errorf("the primary must be ejected")
+	}
+
+	// the primary is re-admitted by the health check
+	atomic.StoreInt32(&primary.down, 0)
+	deadline := time.Now().Add(5 * time.Second)
+	for !provider.Status()[0].Healthy && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if chainId, err := connection.Core.GetChainId(); err != nil || chainId != 1 {
+		t.Errorf("expected the primary after its recovery, got %d %v", chainId, err)
+	}
+}
+
+func Test_MultiProvider_MostUpToDate(t *testing.T) {
+	behind, ahead := newTestNode(1, 10), newTestNode(2, 12)
+	defer behind.server.Close()
+	defer ahead.server.Close()
+
+	provider, err := providers.NewMultiProvider(
+		[]providers.ProviderInterface{behind.provider(), ahead.provider()},
+		providers.WithStrategy(providers.MostUpToDate),
+		providers.WithHealthCheck(time.Hour, time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	if chainId, err := bif.NewBif(provider).Core.GetChainId(); err != nil || chainId != 2 {
+		t.Errorf("expected the most up to date node, got %d %v", chainId, err)
+	}
+	if status := provider.Status(); status[0].BlockNumber != 10 || status[1].BlockNumber != 12 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func Test_MultiProvider_RoundRobin_StickyWrites(t *testing.T) {
+	first, second := newTestNode(1, 10), newTestNode(2, 10)
+	defer first.server.Close()
+	defer second.server.Close()
+
+	provider, err := providers.NewMultiProvider(
+		[]providers.ProviderInterface{first.provider(), second.provider()},
+		providers.WithHealthCheck(0, 0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+	connection := bif.NewBif(provider)
+
+	for i := 0; i < 4; i++ {
+		if _, err := connection.Core.GetChainId(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if atomic.LoadInt32(&first.requests) != 2 || atomic.LoadInt32(&second.requests) != 2 {
+		t.Errorf("reads not balanced: %d %d", first.requests, second.requests)
+	}
+
+	var hashes []string
+	for i := 0; i < 3; i++ {
+		hash, err := connection.Core.SendRawTransaction("0x00")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, hash)
+	}
+	if hashes[0] != hashes[1] || hashes[1] != hashes[2] {
+		t.Errorf("writes were sent to different nodes: %v", hashes)
+	}
+}
+
+func Test_MultiProvider_WritesNotResent(t *testing.T) {
+	primary, secondary := newTestNode(1, 10), newTestNode(2, 10)
+	defer primary.server.Close()
+	defer secondary.server.Close()
+
+	provider, err := providers.NewMultiProvider(
+		[]providers.ProviderInterface{primary.provider(), secondary.provider()},
+		providers.WithStrategy(providers.Failover),
+		providers.WithHealthCheck(0, 0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+	connection := bif.NewBif(provider)
+
+	// the primary may have broadcast the transaction before the connection was lost
+	atomic.StoreInt32(&primary.drop, 1)
+	if _, err := connection.Core.SendRawTransaction("0x00"); err == nil {
+		t.Errorf("expected the error of the primary")
+	}
+	if requests := atomic.LoadInt32(&secondary.requests); requests != 0 {
+		t.Errorf("the write was sent again to the secondary: %d requests", requests)
+	}
+
+	// the reads are sent again
+	if chainId, err := connection.Core.GetChainId(); err != nil || chainId != 2 {
+		t.Errorf("expected the secondary, got %d %v", chainId, err)
+	}
+
+	// the primary turns the request down, the write goes to the secondary
+	atomic.StoreInt32(&primary.drop, 0)
+	atomic.StoreInt32(&primary.down, 1)
+	if hash, err := connection.Core.SendRawTransaction("0x00"); err != nil || hash != fmt.Sprintf("0x%064x", 2) {
+		t.Errorf("expected the secondary, got %s %v", hash, err)
+	}
+}
+
+func Test_MultiProvider_NoEndpoint(t *testing.T) {
+	if _, err := providers.NewMultiProvider(nil); err != providers.ErrNoEndpoint {
+		t.Errorf("expected ErrNoEndpoint, got %v", err)
+	}
+}
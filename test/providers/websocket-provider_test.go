@@ -15,6 +15,8 @@
 package test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/tchain/go-tchain-sdk/account"
 	block2 "github.com/tchain/go-tchain-sdk/core/block"
@@ -23,11 +25,16 @@ import (
 	"github.com/tchain/go-tchain-sdk/txpool"
 	"github.com/tchain/go-tchain-sdk/utils"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/tchain/go-tchain-sdk"
 	"github.com/tchain/go-tchain-sdk/providers"
 )
@@ -344,3 +351,370 @@ func Test_Websocket_Txpool_ToTxPoolContent(t *testing.T) {
 
 	fmt.Println(content)
 }
+
+func Test_Websocket_BatchSendRequest(t *testing.T) {
+	address, stop := newWebsocketNode(t, func(conn *websocket.Conn) {
+		for {
+			var requests []map[string]interface{}
+			if err := conn.ReadJSON(&requests); err != nil {
+				return
+			}
+
+			var responses []map[string]interface{}
+			// answer in reverse order, the ids must be matched by the provider
+			for i := len(requests) - 1; i >= 0; i-- {
+				response := map[string]interface{}{"jsonrpc": "2.0", "id": requests[i]["id"]}
+				if requests[i]["method"] == "core_chainId" {
+					response["result"] = "0x2"
+				} else {
+					response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+				}
+				responses = append(responses, response)
+			}
+			if err := conn.WriteJSON(responses); err != nil {
+				return
+			}
+		}
+	})
+	defer stop()
+
+	provider := providers.NewWebSocketProvider(address)
+	defer provider.Close()
+
+	elems := []providers.BatchElem{
+		{Method: "core_chainId", Result: &dto.CoreRequestResult{}},
+		{Method: "core_unknown", Result: &dto.CoreRequestResult{}},
+	}
+	if err := provider.BatchSendRequest(elems); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	chainId, err := elems[0].Result.(*dto.CoreRequestResult).ToUint64()
+	if err != nil || chainId != 2 {
+		t.Errorf("unexpected chainId %d, %v", chainId, err)
+	}
+
+	if elems[1].Error == nil {
+		t.Errorf("expected an error for the unknown method")
+	}
+}
+
+func Test_Websocket_Core_SubscribeNewHeads(t *testing.T) {
+	unsubscribed := make(chan struct{})
+	address, stop := newWebsocketNode(t, func(conn *websocket.Conn) {
+		for {
+			var request map[string]interface{}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+
+			switch request["method"] {
+			case "core_subscribe":
+				conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": "0x9ce59a13059e417087c02d3236a0b1cc"})
+				// the notification follows the response immediately, it must not be lost
+				conn.WriteJSON(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"method":  "core_subscription",
+					"params": map[string]interface{}{
+						"subscription": "0x9ce59a13059e417087c02d3236a0b1cc",
+						"result": map[string]interface{}{
+							"number":     "0x1b4",
+							"hash":       "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+							"parentHash": "0xe99e022112df268087ea7eafaf4790497fd21dbeeb6bd7a1721df161a6657a54",
+							"timestamp":  "0x55ba467c",
+						},
+					},
+				})
+			case "core_unsubscribe":
+				conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": true})
+				close(unsubscribed)
+			}
+		}
+	})
+	defer stop()
+
+	connection := bif.NewBif(providers.NewWebSocketProvider(address))
+	defer connection.Provider.Close()
+
+	heads := make(chan *dto.BlockHeader)
+	sub, err := connection.Core.SubscribeNewHeads(context.Background(), heads)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	select {
+	case head := <-heads:
+		if head.Number.Uint64() != 436 || head.Timestamp != 0x55ba467c {
+			t.Errorf("unexpected header %d %d", head.Number, head.Timestamp)
+		}
+	case err := <-sub.Err():
+		t.Error(err)
+	case <-time.After(5 * time.Second):
+		t.Error("no header received")
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Error(err)
+	}
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Error("core_unsubscribe not received")
+	}
+
+	if _, ok := <-sub.Err(); ok {
+		t.Error("error channel must be closed after Unsubscribe")
+	}
+}
+
+func Test_HTTP_Core_Subscribe_Unsupported(t *testing.T) {
+	connection := bif.NewBif(providers.NewHTTPProvider("127.0.0.1:1", 1, false))
+
+	_, err := connection.Core.SubscribeNewPendingTransactions(context.Background(), make(chan string))
+	if err != providers.ErrNotificationsUnsupported {
+		t.Errorf("expected ErrNotificationsUnsupported, got %v", err)
+	}
+}
+
+func Test_Websocket_Dial_Error(t *testing.T) {
+	_, err := providers.NewWebSocketProviderWithOptions("ws://127.0.0.1:1")
+	if err == nil {
+		t.Error("expected a dial error")
+	}
+}
+
+func Test_Websocket_PendingCall_ConnectionLost(t *testing.T) {
+	address, stop := newWebsocketNode(t, func(conn *websocket.Conn) {
+		var request map[string]interface{}
+		// drop the connection without answering
+		conn.ReadJSON(&request)
+	})
+	defer stop()
+
+	provider, err := providers.NewWebSocketProviderWithOptions(address, providers.WithoutReconnect())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer provider.Close()
+
+	start := time.Now()
+	_, err = bif.NewBif(provider).Core.GetBlockNumber()
+	if !errors.Is(err, providers.ErrConnectionLost) {
+		t.Errorf("expected ErrConnectionLost, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("the pending call did not fail fast")
+	}
+
+	_, err = bif.NewBif(provider).Core.GetBlockNumber()
+	if !errors.Is(err, providers.ErrConnectionLost) {
+		t.Errorf("expected ErrConnectionLost, got %v", err)
+	}
+}
+
+func Test_Websocket_Reconnect_Resubscribe(t *testing.T) {
+	var connections int32
+	address, stop := newWebsocketNode(t, func(conn *websocket.Conn) {
+		n := atomic.AddInt32(&connections, 1)
+		id := fmt.Sprintf("0x%d", n)
+
+		for {
+			var request map[string]interface{}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if request["method"] != "core_subscribe" {
+				conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": true})
+				continue
+			}
+
+			conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": id})
+			conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "core_subscription",
+				"params":  map[string]interface{}{"subscription": id, "result": fmt.Sprintf("0x%064d", n)},
+			})
+			if n == 1 {
+				// drop the first connection once the subscription is served
+				return
+			}
+		}
+	})
+	defer stop()
+
+	var states []providers.ConnectionState
+	var statesLock sync.Mutex
+	provider, err := providers.NewWebSocketProviderWithOptions(address,
+		providers.WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond),
+		providers.WithStateCallback(func(state providers.ConnectionState, err error) {
+			statesLock.Lock()
+			states = append(states, state)
+			statesLock.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer provider.Close()
+
+	hashes := make(chan string)
+	sub, err := bif.NewBif(provider).Core.SubscribeNewPendingTransactions(context.Background(), hashes)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer sub.Unsubscribe()
+
+	for i := 1; i <= 2; i++ {
+		select {
+		case hash := <-hashes:
+			if hash != fmt.Sprintf("0x%064d", i) {
+				t.Errorf("unexpected hash %s", hash)
+			}
+		case err := <-sub.Err():
+			t.Error(err)
+			t.FailNow()
+		case <-time.After(5 * time.Second):
+			t.Error("notification not received")
+			t.FailNow()
+		}
+	}
+
+	if sub.ID() != "0x2" {
+		t.Errorf("expected the subscription of the new connection, got %s", sub.ID())
+	}
+	if provider.State() != providers.StateConnected {
+		t.Errorf("unexpected state %s", provider.State())
+	}
+
+	statesLock.Lock()
+	defer statesLock.Unlock()
+	expected := []providers.ConnectionState{providers.StateConnected, providers.StateDisconnected, providers.StateReconnecting, providers.StateConnected}
+	if fmt.Sprint(states) != fmt.Sprint(expected) {
+		t.Errorf("unexpected state changes %v", states)
+	}
+}
+
+func Test_Websocket_ReconnectBackoff_Floor(t *testing.T) {
+	var dials int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first connection is dropped at once, the node is down afterwards
+		if atomic.AddInt32(&dials, 1) > 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	// a zero backoff is raised to 10ms instead of redialing in a busy loop
+	provider, err := providers.NewWebSocketProviderWithOptions("ws"+strings.TrimPrefix(server.URL, "http"),
+		providers.WithReconnectBackoff(0, -time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	provider.Close()
+
+	if n := atomic.LoadInt32(&dials); n < 3 || n > 25 {
+		t.Errorf("expected a redial every 10ms, got %d dials in 200ms", n)
+	}
+}
+
+// newSlowWebsocketNode answers every request with "0x1" after delay, and
+// records the highest number of requests it had pending at once
+func newSlowWebsocketNode(t *testing.T, delay time.Duration, maxPending *int32) (string, func()) {
+	return newWebsocketNode(t, func(conn *websocket.Conn) {
+		var writeLock sync.Mutex
+		var pending int32
+		for {
+			var request map[string]interface{}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+
+			n := atomic.AddInt32(&pending, 1)
+			for {
+				max := atomic.LoadInt32(maxPending)
+				if n <= max || atomic.CompareAndSwapInt32(maxPending, max, n) {
+					break
+				}
+			}
+
+			go func(id interface{}) {
+				time.Sleep(delay)
+				atomic.AddInt32(&pending, -1)
+
+				writeLock.Lock()
+				defer writeLock.Unlock()
+				conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": "0x1"})
+			}(request["id"])
+		}
+	})
+}
+
+func Test_Websocket_RequestTimeout(t *testing.T) {
+	var maxPending int32
+	address, stop := newSlowWebsocketNode(t, 200*time.Millisecond, &maxPending)
+	defer stop()
+
+	provider, err := providers.NewWebSocketProviderWithOptions(address, providers.WithRequestTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer provider.Close()
+	connection := bif.NewBif(provider)
+
+	if _, err := connection.Core.GetBlockNumber(); err != providers.ErrTimeout {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+
+	// the deadline of the call takes precedence over the timeout of the provider
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	number, err := connection.Core.GetBlockNumberCtx(ctx)
+	if err != nil || number.Uint64() != 1 {
+		t.Errorf("unexpected block number %v, %v", number, err)
+	}
+}
+
+func Test_Websocket_MaxInFlight(t *testing.T) {
+	var maxPending int32
+	address, stop := newSlowWebsocketNode(t, 20*time.Millisecond, &maxPending)
+	defer stop()
+
+	provider, err := providers.NewWebSocketProviderWithOptions(address, providers.WithMaxInFlight(2))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	defer provider.Close()
+	connection := bif.NewBif(provider)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := connection.Core.GetBlockNumber(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&maxPending); max > 2 {
+		t.Errorf("%d requests were in flight at once", max)
+	}
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tchain/go-tchain-sdk/providers"
+	"github.com/tchain/go-tchain-sdk/providers/mock"
+)
+
+// ProviderModeEnv selects how the provider of NewProvider reaches the node:
+//
+//	live   - the node at IP00
+//	record - the node at IP00, and the traffic is saved to the golden file of the test
+//	replay - the golden file of the test, without any node, the default
+//
+// The tests which don't need a node script a mock.Provider instead, so that
+// they run in every mode.
+const ProviderModeEnv = "BIF_PROVIDER_MODE"
+
+// NewProvider returns the provider of the test t according to ProviderModeEnv,
+// the golden files are kept in test/resources/golden. In replay mode the
+// tests without a golden file are skipped.
+func NewProvider(t testing.TB) providers.ProviderInterface {
+	return NewProviderAt(t, IP00+":"+strconv.FormatUint(Port, 10))
+}
+
+// NewProviderAt is like NewProvider for the tests of the node at address,
+// e.g. a subchain node
+func NewProviderAt(t testing.TB, address string) providers.ProviderInterface {
+	live := providers.NewHTTPProvider(address, 10, false)
+
+	switch os.Getenv(ProviderModeEnv) {
+	case "live":
+		return live
+	case "record":
+		recorder := mock.NewRecorder(live, GoldenFile(t))
+		t.Cleanup(func() {
+			if err := recorder.Save(); err != nil {
+				t.Error(err)
+			}
+		})
+		return recorder
+	default:
+		replayer, err := mock.NewReplayer(GoldenFile(t))
+		if os.IsNotExist(err) {
+			t.Skipf("no golden file for %s", t.Name())
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return replayer
+	}
+}
+
+// GoldenFile returns the path of the golden file of the test t
+func GoldenFile(t testing.TB) string {
+	_, filename, _, _ := runtime.Caller(0)
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	return filepath.Join(path.Dir(filename), "golden", name+".json")
+}
@@ -15,17 +15,20 @@
 package test
 
 import (
+	"github.com/tchain/go-tchain-sdk/providers/mock"
 	"github.com/tchain/go-tchain-sdk/test/resources"
 	"github.com/tchain/go-tchain-sdk/txpool"
-	"strconv"
 	"testing"
-
-	"github.com/tchain/go-tchain-sdk/providers"
 )
 
+const testTxHash = "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+
 func TestGetStatus(t *testing.T) {
 
-	var connection = txpool.NewTxPool(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("txpool_status").Return(map[string]string{"pending": "0x2", "queued": "0x1"})
+
+	var connection = txpool.NewTxPool(provider)
 
 	status, err := connection.GetStatus()
 	if err != nil {
@@ -33,30 +36,61 @@ func TestGetStatus(t *testing.T) {
 		t.FailNow()
 	}
 
-	t.Log(status)
-
+	if status["pending"] != 2 || status["queued"] != 1 {
+		t.Errorf("unexpected status %v", status)
+	}
 }
 
 func TestTxPoolInspect(t *testing.T) {
 
-	var connection = txpool.NewTxPool(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	summary := resources.Addr2 + ": 10000 wei + 21000 gas × 45 wei"
+
+	provider := mock.NewProvider()
+	provider.On("txpool_inspect").Return(map[string]interface{}{
+		"pending": map[string]interface{}{resources.Addr1: map[string]string{"5": summary}},
+		"queued":  map[string]interface{}{},
+	})
+
+	var connection = txpool.NewTxPool(provider)
 
 	inspect, err := connection.Inspect()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(inspect)
+
+	if inspect["pending"][resources.Addr1]["5"] != summary || len(inspect["queued"]) != 0 {
+		t.Errorf("unexpected inspect %v", inspect)
+	}
 }
 
 func TestTxPoolContent(t *testing.T) {
 
-	var connection = txpool.NewTxPool(providers.NewHTTPProvider(resources.IP00+":"+strconv.FormatUint(resources.Port, 10), 10, false))
+	provider := mock.NewProvider()
+	provider.On("txpool_content").Return(map[string]interface{}{
+		"pending": map[string]interface{}{resources.Addr1: map[string]interface{}{"5": map[string]interface{}{
+			"hash":     testTxHash,
+			"from":     resources.Addr1,
+			"to":       resources.Addr2,
+			"nonce":    "0x5",
+			"gas":      "0x5208",
+			"gasPrice": "0x2d",
+			"value":    "0x2710",
+			"input":    "0x",
+		}}},
+		"queued": map[string]interface{}{},
+	})
+
+	var connection = txpool.NewTxPool(provider)
 
 	transactions, err := connection.Content()
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
-	t.Log(transactions)
-}
\ No newline at end of file
+
+	transaction := transactions["pending"][resources.Addr1]["5"]
+	if transaction == nil || transaction.Hash.String() != testTxHash || transaction.To != resources.Addr2 || uint64(transaction.Nonce) != 5 || transaction.Value.ToInt().Int64() != 10000 {
+		t.Errorf("unexpected content %v", transactions)
+	}
+}
@@ -15,6 +15,7 @@
 package txpool
 
 import (
+	"context"
 	"github.com/tchain/go-tchain-sdk/dto"
 	"github.com/tchain/go-tchain-sdk/providers"
 	"github.com/tchain/go-tchain-sdk/utils/hexutil"
@@ -44,10 +45,15 @@ func NewTxPool(provider providers.ProviderInterface) *TxPool {
   Call permissions: Anyone
 */
 func (txPool *TxPool) GetStatus() (map[string]hexutil.Uint, error) {
+	return txPool.GetStatusCtx(context.Background())
+}
+
+// GetStatusCtx is like GetStatus but honours the cancellation and deadline of ctx.
+func (txPool *TxPool) GetStatusCtx(ctx context.Context) (map[string]hexutil.Uint, error) {
 
 	pointer := &dto.TxPoolRequestResult{}
 
-	if err := txPool.provider.SendRequest(pointer, "txpool_status", nil); err != nil {
+	if err := txPool.provider.SendRequestContext(ctx, pointer, "txpool_status", nil); err != nil {
 		return nil, err
 	}
 
@@ -68,10 +74,15 @@ func (txPool *TxPool) GetStatus() (map[string]hexutil.Uint, error) {
   Call permissions: Anyone
 */
 func (txPool *TxPool) Inspect() (map[string]map[string]map[string]string, error) {
+	return txPool.InspectCtx(context.Background())
+}
+
+// InspectCtx is like Inspect but honours the cancellation and deadline of ctx.
+func (txPool *TxPool) InspectCtx(ctx context.Context) (map[string]map[string]map[string]string, error) {
 
 	pointer := &dto.TxPoolRequestResult{}
 
-	if err := txPool.provider.SendRequest(pointer, "txpool_inspect", nil); err != nil {
+	if err := txPool.provider.SendRequestContext(ctx, pointer, "txpool_inspect", nil); err != nil {
 		return nil, err
 	}
 
@@ -92,10 +103,15 @@ func (txPool *TxPool) Inspect() (map[string]map[string]map[string]string, error)
   Call permissions: Anyone
 */
 func (txPool *TxPool) Content() (map[string]map[string]map[string]*dto.RPCTransaction, error) {
+	return txPool.ContentCtx(context.Background())
+}
+
+// ContentCtx is like Content but honours the cancellation and deadline of ctx.
+func (txPool *TxPool) ContentCtx(ctx context.Context) (map[string]map[string]map[string]*dto.RPCTransaction, error) {
 
 	pointer := &dto.TxPoolRequestResult{}
 
-	if err := txPool.provider.SendRequest(pointer, "txpool_content", nil); err != nil {
+	if err := txPool.provider.SendRequestContext(ctx, pointer, "txpool_content", nil); err != nil {
 		return nil, err
 	}
 
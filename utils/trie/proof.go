@@ -0,0 +1,171 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package trie verifies the Merkle-Patricia proofs of the state, transactions
+// and receipts tries. The nodes are hashed with Keccak256 or SM3, depending on
+// the crypto type of the chain.
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
+)
+
+// ErrMissingNode is returned when a node of the path isn't in the proof
+var ErrMissingNode = errors.New("missing trie node in the proof")
+
+// EmptyRoot returns the root hash of an empty trie
+func EmptyRoot(cryptoType config.CryptoType) utils.Hash {
+	return crypto.Keccak256Hash(cryptoType, []byte{0x80})
+}
+
+// VerifyProof checks the proof of key against the trie root. It returns the
+// value of key, nil when the proof shows key isn't in the trie.
+func VerifyProof(root utils.Hash, key []byte, proof [][]byte, cryptoType config.CryptoType) ([]byte, error) {
+	nodes := make(map[utils.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[crypto.Keccak256Hash(cryptoType, node)] = node
+	}
+
+	node, ok := nodes[root]
+	if !ok {
+		if root == EmptyRoot(cryptoType) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: root %x", ErrMissingNode, root)
+	}
+
+	path := keybytesToHex(key)
+	for {
+		elems, _, err := rlp.SplitList(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie node: %v", err)
+		}
+		count, err := rlp.CountValues(elems)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie node: %v", err)
+		}
+
+		var child []byte
+		switch count {
+		case 2:
+			compact, rest, err := rlp.SplitString(elems)
+			if err != nil {
+				return nil, fmt.Errorf("invalid short node: %v", err)
+			}
+			nibbles := compactToHex(compact)
+			if len(path) < len(nibbles) || !bytes.Equal(nibbles, path[:len(nibbles)]) {
+				// the path diverges, key isn't in the trie
+				return nil, nil
+			}
+			path = path[len(nibbles):]
+
+			if hasTerm(nibbles) {
+				value, _, err := rlp.SplitString(rest)
+				if err != nil {
+					return nil, fmt.Errorf("invalid leaf node: %v", err)
+				}
+				return value, nil
+			}
+			child = rest
+		case 17:
+			if len(path) == 0 {
+				return nil, errors.New("invalid proof: key ends in a branch")
+			}
+			child = elems
+			for i := byte(0); i < path[0]; i++ {
+				if _, _, child, err = rlp.Split(child); err != nil {
+					return nil, fmt.Errorf("invalid branch node: %v", err)
+				}
+			}
+			if path[0] == 16 {
+				value, _, err := rlp.SplitString(child)
+				if err != nil {
+					return nil, fmt.Errorf("invalid branch node: %v", err)
+				}
+				if len(value) == 0 {
+					return nil, nil
+				}
+				return value, nil
+			}
+			path = path[1:]
+		default:
+			return nil, fmt.Errorf("invalid trie node with %d elements", count)
+		}
+
+		// the child is embedded when its encoding is shorter than a hash
+		kind, content, _, err := rlp.Split(child)
+		if err != nil {
+			return nil, fmt.Errorf("invalid child reference: %v", err)
+		}
+		switch {
+		case kind == rlp.List:
+			node = child[:len(child)-len(skip(child))]
+		case len(content) == 0:
+			return nil, nil
+		case len(content) == utils.HashLength:
+			hash := utils.BytesToHash(content)
+			if node, ok = nodes[hash]; !ok {
+				return nil, fmt.Errorf("%w: %x", ErrMissingNode, hash)
+			}
+		default:
+			return nil, fmt.Errorf("invalid child reference of %d bytes", len(content))
+		}
+	}
+}
+
+// skip returns the bytes after the first RLP value of b
+func skip(b []byte) []byte {
+	_, _, rest, _ := rlp.Split(b)
+	return rest
+}
+
+// keybytesToHex converts a key to nibbles, with the terminator 16
+func keybytesToHex(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2+1)
+	for i, b := range key {
+		nibbles[i*2] = b / 16
+		nibbles[i*2+1] = b % 16
+	}
+	nibbles[len(nibbles)-1] = 16
+	return nibbles
+}
+
+// compactToHex converts the compact key of a short node to nibbles, the
+// nibbles of a leaf end with the terminator 16
+func compactToHex(compact []byte) []byte {
+	if len(compact) == 0 {
+		return compact
+	}
+	base := keybytesToHex(compact)
+	// remove the terminator unless the key is a leaf's
+	if base[0] < 2 {
+		base = base[:len(base)-1]
+	}
+	// an odd key keeps the nibble of the flag
+	chop := 2 - base[0]&1
+	return base[chop:]
+}
+
+func hasTerm(nibbles []byte) bool {
+	return len(nibbles) > 0 && nibbles[len(nibbles)-1] == 16
+}
@@ -0,0 +1,223 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/tchain/go-tchain-sdk/crypto"
+	"github.com/tchain/go-tchain-sdk/crypto/config"
+	"github.com/tchain/go-tchain-sdk/utils"
+	"github.com/tchain/go-tchain-sdk/utils/rlp"
+)
+
+// ErrMissingKey is returned when proving a key which isn't in the trie
+var ErrMissingKey = errors.New("key not found in the trie")
+
+// Trie is an in-memory Merkle-Patricia trie, it rebuilds the small tries of
+// a block, e.g. the transactions and receipts tries, to compute their root
+// and the proofs of their keys
+type Trie struct {
+	cryptoType config.CryptoType
+	values     map[string][]byte
+}
+
+// New creates an empty trie hashed with the hash of cryptoType
+func New(cryptoType config.CryptoType) *Trie {
+	return &Trie{cryptoType: cryptoType, values: make(map[string][]byte)}
+}
+
+// Update sets the value of key, an empty value deletes key
+func (t *Trie) Update(key, value []byte) {
+	if len(value) == 0 {
+		delete(t.values, string(key))
+		return
+	}
+	t.values[string(key)] = append([]byte(nil), value...)
+}
+
+// Get returns the value of key, nil when key isn't in the trie
+func (t *Trie) Get(key []byte) []byte {
+	return t.values[string(key)]
+}
+
+// Hash returns the root hash of the trie
+func (t *Trie) Hash() utils.Hash {
+	root := t.build()
+	if root == nil {
+		return EmptyRoot(t.cryptoType)
+	}
+	return crypto.Keccak256Hash(t.cryptoType, t.encode(root))
+}
+
+// Prove returns the nodes from the root to the value of key, the proof is
+// checked by VerifyProof
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	if _, ok := t.values[string(key)]; !ok {
+		return nil, ErrMissingKey
+	}
+
+	var proof [][]byte
+	path := keybytesToHex(key)
+	n := t.build()
+	for n != nil {
+		encoded := t.encode(n)
+		// the embedded nodes are in the proof as part of their parent
+		if len(proof) == 0 || len(encoded) >= utils.HashLength {
+			proof = append(proof, encoded)
+		}
+
+		switch {
+		case n.children != nil:
+			n, path = n.children[path[0]], path[1:]
+		case n.child != nil:
+			n, path = n.child, path[len(n.key):]
+		default:
+			n = nil
+		}
+	}
+	return proof, nil
+}
+
+// node is a node of the trie: a leaf or an extension, which are short nodes
+// with a key, or a branch with 16 children and the value at the terminator
+type node struct {
+	key      []byte
+	value    []byte
+	child    *node
+	children []*node
+}
+
+// build creates the nodes of the trie from its values
+func (t *Trie) build() *node {
+	if len(t.values) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(t.values))
+	for key := range t.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	paths := make([][]byte, len(keys))
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		paths[i] = keybytesToHex([]byte(key))
+		values[i] = t.values[key]
+	}
+	return buildNode(paths, values)
+}
+
+// buildNode creates the node of sorted and distinct paths
+func buildNode(paths [][]byte, values [][]byte) *node {
+	if len(paths) == 1 {
+		return &node{key: paths[0], value: values[0]}
+	}
+
+	// the paths are sorted, the common prefix of all is the one of the first and last
+	first, last := paths[0], paths[len(paths)-1]
+	prefix := 0
+	for prefix < len(first) && prefix < len(last) && first[prefix] == last[prefix] {
+		prefix++
+	}
+	if prefix > 0 {
+		stripped := make([][]byte, len(paths))
+		for i, path := range paths {
+			stripped[i] = path[prefix:]
+		}
+		return &node{key: first[:prefix], child: buildNode(stripped, values)}
+	}
+
+	branch := &node{children: make([]*node, 17)}
+	for start := 0; start < len(paths); {
+		nibble := paths[start][0]
+		end := start + 1
+		for end < len(paths) && paths[end][0] == nibble {
+			end++
+		}
+
+		if nibble == 16 {
+			branch.value = values[start]
+		} else {
+			stripped := make([][]byte, end-start)
+			for i := range stripped {
+				stripped[i] = paths[start+i][1:]
+			}
+			branch.children[nibble] = buildNode(stripped, values[start:end])
+		}
+		start = end
+	}
+	return branch
+}
+
+// encode returns the RLP encoding of n
+func (t *Trie) encode(n *node) []byte {
+	var encoded []byte
+	switch {
+	case n.children != nil:
+		items := make([]interface{}, 17)
+		for i, child := range n.children[:16] {
+			if child == nil {
+				items[i] = []byte{}
+			} else {
+				items[i] = t.reference(child)
+			}
+		}
+		items[16] = n.value
+		if n.value == nil {
+			items[16] = []byte{}
+		}
+		encoded, _ = rlp.EncodeToBytes(items)
+	case n.child != nil:
+		encoded, _ = rlp.EncodeToBytes([]interface{}{hexToCompact(n.key), t.reference(n.child)})
+	default:
+		encoded, _ = rlp.EncodeToBytes([]interface{}{hexToCompact(n.key), n.value})
+	}
+	return encoded
+}
+
+// reference returns the reference of a child: its encoding when it's
+// shorter than a hash, its hash otherwise
+func (t *Trie) reference(n *node) rlp.RawValue {
+	encoded := t.encode(n)
+	if len(encoded) < utils.HashLength {
+		return encoded
+	}
+	hash, _ := rlp.EncodeToBytes(crypto.Keccak256(t.cryptoType, encoded))
+	return hash
+}
+
+// hexToCompact converts nibbles to the compact key of a short node
+func hexToCompact(nibbles []byte) []byte {
+	terminator := byte(0)
+	if hasTerm(nibbles) {
+		terminator = 1
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+	compact := make([]byte, len(nibbles)/2+1)
+	compact[0] = terminator << 5
+	if len(nibbles)&1 == 1 {
+		compact[0] |= 1<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	}
+	for i := 0; i < len(nibbles)/2; i++ {
+		compact[i+1] = nibbles[i*2]<<4 | nibbles[i*2+1]
+	}
+	return compact
+}